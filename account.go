@@ -2,6 +2,7 @@ package stripe
 
 import (
 	"encoding/json"
+	"net/url"
 
 	"github.com/stripe/stripe-go/form"
 )
@@ -66,13 +67,13 @@ type AccountParams struct {
 	BusinessPrimaryColor string                        `form:"business_primary_color"`
 	BusinessUrl          string                        `form:"business_url"`
 	Country              string                        `form:"country"`
-	DebitNegativeBal     bool                          `form:"debit_negative_balances"`
+	DebitNegativeBal     *bool                         `form:"debit_negative_balances"`
 	DefaultCurrency      string                        `form:"default_currency"`
 	Email                string                        `form:"email"`
 	ExternalAccount      *AccountExternalAccountParams `form:"external_account"`
 	FromRecipient        string                        `form:"from_recipient"`
 	LegalEntity          *LegalEntity                  `form:"legal_entity"`
-	NoDebitNegativeBal   bool                          `form:"debit_negative_balances,invert"`
+	NoDebitNegativeBal   bool                          `form:"debit_negative_balances,invert"` // Deprecated: use DebitNegativeBal
 	PayoutSchedule       *PayoutScheduleParams         `form:"payout_schedule"`
 	PayoutStatement      string                        `form:"payout_statement_descriptor"`
 	Statement            string                        `form:"statement_descriptor"`
@@ -113,10 +114,23 @@ func (p *AccountExternalAccountParams) AppendTo(body *form.Values, keyParts []st
 	}
 }
 
+// UnmarshalForm implements form.Unmarshaler for the token encoded by the
+// custom AppendTo in place of the other parameters.
+func (p *AccountExternalAccountParams) UnmarshalForm(values url.Values, keyParts []string) error {
+	if len(keyParts) > 0 {
+		if token := values.Get(form.FormatKey(keyParts)); token != "" {
+			p.Token = token
+		}
+	}
+
+	type externalAccountParams AccountExternalAccountParams
+	return form.UnmarshalPrefixed(values, (*externalAccountParams)(p), keyParts)
+}
+
 // PayoutScheduleParams are the parameters allowed for payout schedules.
 type PayoutScheduleParams struct {
 	Delay        uint64   `form:"delay_days"`
-	Interval     Interval `form:"interval"`
+	Interval     Interval `form:"interval" validate:"oneof=daily manual monthly weekly"`
 	MinimumDelay bool     `form:"-"` // See custom AppendTo
 	MonthAnchor  uint64   `form:"monthly_anchor"`
 	WeekAnchor   string   `form:"weekly_anchor"`
@@ -128,6 +142,19 @@ func (p *PayoutScheduleParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// UnmarshalForm implements form.Unmarshaler for the minimum delay encoded by
+// the custom AppendTo.
+func (p *PayoutScheduleParams) UnmarshalForm(values url.Values, keyParts []string) error {
+	delayKey := form.FormatKey(append(keyParts, "delay_days"))
+	if values.Get(delayKey) == "minimum" {
+		p.MinimumDelay = true
+		values = formValuesWithout(values, delayKey)
+	}
+
+	type payoutScheduleParams PayoutScheduleParams
+	return form.UnmarshalPrefixed(values, (*payoutScheduleParams)(p), keyParts)
+}
+
 // Account is the resource representing your Stripe account.
 // For more details see https://stripe.com/docs/api/#account.
 type Account struct {
@@ -183,15 +210,19 @@ type Account struct {
 // This custom unmarshaling is needed because the resulting
 // property may be an ID or the full struct if it was expanded.
 func (a *Account) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		a.ID = id
+		return nil
+	}
+
 	type account Account
 	var aa account
 	err := json.Unmarshal(data, &aa)
-	if err == nil {
-		*a = Account(aa)
-	} else {
-		// the ID is surrounded by "\" characters, so strip them
-		a.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
+
+	*a = Account(aa)
 	return nil
 }
 
@@ -223,10 +254,7 @@ const (
 )
 
 // AccountList is a list of accounts as returned from a list endpoint.
-type AccountList struct {
-	ListMeta
-	Values []*Account `json:"data"`
-}
+type AccountList = List[*Account]
 
 // ExternalAccountList is a list of external accounts that may be either bank
 // accounts or cards.
@@ -384,6 +412,15 @@ func (d *IdentityDocument) AppendTo(body *form.Values, keyParts []string) {
 	body.Add(form.FormatKey(keyParts), d.ID)
 }
 
+// UnmarshalForm implements form.Unmarshaler for the ID encoded by the custom
+// AppendTo.
+func (d *IdentityDocument) UnmarshalForm(values url.Values, keyParts []string) error {
+	if len(keyParts) > 0 {
+		d.ID = values.Get(form.FormatKey(keyParts))
+	}
+	return nil
+}
+
 // PayoutSchedule is the structure for an account's payout schedule.
 type PayoutSchedule struct {
 	Delay       uint64   `json:"delay_days" form:"delay_days"`
@@ -410,16 +447,18 @@ type AccountRejectParams struct {
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (d *IdentityDocument) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		d.ID = id
+		return nil
+	}
+
 	type identityDocument IdentityDocument
 	var doc identityDocument
 	err := json.Unmarshal(data, &doc)
-
-	if err == nil {
-		*d = IdentityDocument(doc)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		d.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*d = IdentityDocument(doc)
 	return nil
 }
@@ -17,6 +17,10 @@ func New(params *stripe.AccountParams) (*stripe.Account, error) {
 }
 
 func (c Client) New(params *stripe.AccountParams) (*stripe.Account, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 
 	// Type is now required on creation and not allowed on update
@@ -51,6 +55,10 @@ func GetByID(id string, params *stripe.AccountParams) (*stripe.Account, error) {
 }
 
 func (c Client) GetByID(id string, params *stripe.AccountParams) (*stripe.Account, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -72,6 +80,10 @@ func Update(id string, params *stripe.AccountParams) (*stripe.Account, error) {
 }
 
 func (c Client) Update(id string, params *stripe.AccountParams) (*stripe.Account, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -93,6 +105,10 @@ func Del(id string, params *stripe.AccountParams) (*stripe.Account, error) {
 }
 
 func (c Client) Del(id string, params *stripe.AccountParams) (*stripe.Account, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -132,26 +148,19 @@ func List(params *stripe.AccountListParams) *Iter {
 func (c Client) List(params *stripe.AccountListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Account, stripe.ListMeta, error) {
 		list := &stripe.AccountList{}
 		err := c.B.Call("GET", "/accounts", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -159,13 +168,13 @@ func (c Client) List(params *stripe.AccountListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Account]
 }
 
 // Account returns the most recent Account
 // visited by a call to Next.
 func (i *Iter) Account() *stripe.Account {
-	return i.Current().(*stripe.Account)
+	return i.Current()
 }
 
 func getC() Client {
@@ -42,7 +42,7 @@ func TestAccountNew(t *testing.T) {
 		BusinessUrl:          "www.stripe.com",
 		BusinessName:         "Stripe",
 		BusinessPrimaryColor: "#ffffff",
-		DebitNegativeBal:     true,
+		DebitNegativeBal:     stripe.Bool(true),
 		SupportEmail:         "foo@bar.com",
 		SupportUrl:           "www.stripe.com",
 		SupportPhone:         "4151234567",
@@ -21,7 +21,4 @@ type ApplePayDomainListParams struct {
 }
 
 // ApplePayDomainList is a list of ApplePayDomains as returned from a list endpoint.
-type ApplePayDomainList struct {
-	ListMeta
-	Values []*ApplePayDomain `json:"data"`
-}
+type ApplePayDomainList = List[*ApplePayDomain]
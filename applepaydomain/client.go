@@ -18,6 +18,10 @@ func New(params *stripe.ApplePayDomainParams) (*stripe.ApplePayDomain, error) {
 }
 
 func (c Client) New(params *stripe.ApplePayDomainParams) (*stripe.ApplePayDomain, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -32,6 +36,10 @@ func Get(id string, params *stripe.ApplePayDomainParams) (*stripe.ApplePayDomain
 }
 
 func (c Client) Get(id string, params *stripe.ApplePayDomainParams) (*stripe.ApplePayDomain, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -53,6 +61,10 @@ func Del(id string, params *stripe.ApplePayDomainParams) (*stripe.ApplePayDomain
 }
 
 func (c Client) Del(id string, params *stripe.ApplePayDomainParams) (*stripe.ApplePayDomain, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -76,25 +88,18 @@ func List(params *stripe.ApplePayDomainListParams) *Iter {
 func (c Client) List(params *stripe.ApplePayDomainListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.ApplePayDomain, stripe.ListMeta, error) {
 		list := &stripe.ApplePayDomainList{}
 		err := c.B.Call("GET", "/apple_pay/domains", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -102,13 +107,13 @@ func (c Client) List(params *stripe.ApplePayDomainListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.ApplePayDomain]
 }
 
 // ApplePayDomain returns the most recent ApplePayDomain
 // visited by a call to Next.
 func (i *Iter) ApplePayDomain() *stripe.ApplePayDomain {
-	return i.Current().(*stripe.ApplePayDomain)
+	return i.Current()
 }
 
 func getC() Client {
@@ -108,18 +108,35 @@ type Transaction struct {
 	Type       TransactionType   `json:"type"`
 }
 
-// TransactionList is a list of transactions as returned from a list endpoint.
-type TransactionList struct {
-	ListMeta
-	Values []*Transaction `json:"data"`
+// AmountMoney returns the gross amount of the transaction in its currency.
+func (t *Transaction) AmountMoney() Money {
+	return Money{Amount: t.Amount, Currency: t.Currency}
+}
+
+// FeeMoney returns the fees of the transaction in its currency.
+func (t *Transaction) FeeMoney() Money {
+	return Money{Amount: t.Fee, Currency: t.Currency}
+}
+
+// NetMoney returns the net amount of the transaction in its currency.
+func (t *Transaction) NetMoney() Money {
+	return Money{Amount: t.Net, Currency: t.Currency}
 }
 
+// TransactionList is a list of transactions as returned from a list endpoint.
+type TransactionList = List[*Transaction]
+
 // Amount is a structure wrapping an amount value and its currency.
 type Amount struct {
 	Value    int64    `json:"amount"`
 	Currency Currency `json:"currency"`
 }
 
+// Money returns the amount in its currency.
+func (a *Amount) Money() Money {
+	return Money{Amount: a.Value, Currency: a.Currency}
+}
+
 // TxFee is a structure that breaks down the fees in a transaction.
 type TxFee struct {
 	Application string   `json:"application"`
@@ -129,20 +146,28 @@ type TxFee struct {
 	Type        string   `json:"type"`
 }
 
+// Money returns the amount of the fee in its currency.
+func (f *TxFee) Money() Money {
+	return Money{Amount: f.Amount, Currency: f.Currency}
+}
+
 // UnmarshalJSON handles deserialization of a Transaction.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (t *Transaction) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		t.ID = id
+		return nil
+	}
+
 	type transaction Transaction
 	var tt transaction
 	err := json.Unmarshal(data, &tt)
-	if err == nil {
-		*t = Transaction(tt)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		t.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*t = Transaction(tt)
 	return nil
 }
 
@@ -150,40 +175,40 @@ func (t *Transaction) UnmarshalJSON(data []byte) error {
 // This custom unmarshaling is needed because the specific
 // type of transaction source it refers to is specified in the JSON
 func (s *TransactionSource) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		s.ID = id
+		return nil
+	}
+
 	type source TransactionSource
 	var ss source
 	err := json.Unmarshal(data, &ss)
-	if err == nil {
-		*s = TransactionSource(ss)
-
-		switch s.Type {
-		case TransactionSourceCharge:
-			err = json.Unmarshal(data, &s.Charge)
-		case TransactionSourceDispute:
-			err = json.Unmarshal(data, &s.Dispute)
-		case TransactionSourceFee:
-			err = json.Unmarshal(data, &s.Fee)
-		case TransactionSourcePayout:
-			err = json.Unmarshal(data, &s.Payout)
-		case TransactionSourceRecipientTransfer:
-			err = json.Unmarshal(data, &s.RecipientTransfer)
-		case TransactionSourceRefund:
-			err = json.Unmarshal(data, &s.Refund)
-		case TransactionSourceReversal:
-			err = json.Unmarshal(data, &s.Reversal)
-		case TransactionSourceTransfer:
-			err = json.Unmarshal(data, &s.Transfer)
-		}
-
-		if err != nil {
-			return err
-		}
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		s.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
-	return nil
+	*s = TransactionSource(ss)
+
+	switch s.Type {
+	case TransactionSourceCharge:
+		err = json.Unmarshal(data, &s.Charge)
+	case TransactionSourceDispute:
+		err = json.Unmarshal(data, &s.Dispute)
+	case TransactionSourceFee:
+		err = json.Unmarshal(data, &s.Fee)
+	case TransactionSourcePayout:
+		err = json.Unmarshal(data, &s.Payout)
+	case TransactionSourceRecipientTransfer:
+		err = json.Unmarshal(data, &s.RecipientTransfer)
+	case TransactionSourceRefund:
+		err = json.Unmarshal(data, &s.Refund)
+	case TransactionSourceReversal:
+		err = json.Unmarshal(data, &s.Reversal)
+	case TransactionSourceTransfer:
+		err = json.Unmarshal(data, &s.Transfer)
+	}
+
+	return err
 }
 
 // MarshalJSON handles serialization of a TransactionSource.
@@ -38,6 +38,10 @@ func Get(params *stripe.BalanceParams) (*stripe.Balance, error) {
 }
 
 func (c Client) Get(params *stripe.BalanceParams) (*stripe.Balance, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -60,6 +64,10 @@ func GetTx(id string, params *stripe.TxParams) (*stripe.Transaction, error) {
 }
 
 func (c Client) GetTx(id string, params *stripe.TxParams) (*stripe.Transaction, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -84,25 +92,18 @@ func List(params *stripe.TxListParams) *Iter {
 func (c Client) List(params *stripe.TxListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Transaction, stripe.ListMeta, error) {
 		list := &stripe.TransactionList{}
 		err := c.B.Call("GET", "/balance/history", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -110,13 +111,13 @@ func (c Client) List(params *stripe.TxListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Transaction]
 }
 
 // Charge returns the most recent Transaction
 // visited by a call to Next.
 func (i *Iter) Transaction() *stripe.Transaction {
-	return i.Current().(*stripe.Transaction)
+	return i.Current()
 }
 
 func getC() Client {
@@ -113,41 +113,41 @@ type BankAccountListParams struct {
 
 // BankAccount represents a Stripe bank account.
 type BankAccount struct {
-	AccountHolderName string            `json:"account_holder_name"`
-	AccountHolderType string            `json:"account_holder_type"`
-	Country           string            `json:"country"`
-	Currency          Currency          `json:"currency"`
-	Customer          *Customer         `json:"customer"`
-	Default           bool              `json:"default_for_currency"`
-	Deleted           bool              `json:"deleted"`
-	Fingerprint       string            `json:"fingerprint"`
-	ID                string            `json:"id"`
-	LastFour          string            `json:"last4"`
-	Meta              map[string]string `json:"metadata"`
-	Name              string            `json:"bank_name"`
-	Routing           string            `json:"routing_number"`
-	Status            BankAccountStatus `json:"status"`
+	AccountHolderName string                `json:"account_holder_name"`
+	AccountHolderType string                `json:"account_holder_type"`
+	Country           string                `json:"country"`
+	Currency          Currency              `json:"currency"`
+	Customer          *Expandable[Customer] `json:"customer"`
+	Default           bool                  `json:"default_for_currency"`
+	Deleted           bool                  `json:"deleted"`
+	Fingerprint       string                `json:"fingerprint"`
+	ID                string                `json:"id"`
+	LastFour          string                `json:"last4"`
+	Meta              map[string]string     `json:"metadata"`
+	Name              string                `json:"bank_name"`
+	Routing           string                `json:"routing_number"`
+	Status            BankAccountStatus     `json:"status"`
 }
 
 // BankAccountList is a list object for bank accounts.
-type BankAccountList struct {
-	ListMeta
-	Values []*BankAccount `json:"data"`
-}
+type BankAccountList = List[*BankAccount]
 
 // UnmarshalJSON handles deserialization of a BankAccount.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (b *BankAccount) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		b.ID = id
+		return nil
+	}
+
 	type bankAccount BankAccount
 	var bb bankAccount
 	err := json.Unmarshal(data, &bb)
-	if err == nil {
-		*b = BankAccount(bb)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		b.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*b = BankAccount(bb)
 	return nil
 }
@@ -28,6 +28,10 @@ func New(params *stripe.BankAccountParams) (*stripe.BankAccount, error) {
 }
 
 func (c Client) New(params *stripe.BankAccountParams) (*stripe.BankAccount, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 
 	// Note that we call this special append method instead of the standard one
@@ -52,6 +56,10 @@ func Get(id string, params *stripe.BankAccountParams) (*stripe.BankAccount, erro
 }
 
 func (c Client) Get(id string, params *stripe.BankAccountParams) (*stripe.BankAccount, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -81,6 +89,10 @@ func Update(id string, params *stripe.BankAccountParams) (*stripe.BankAccount, e
 }
 
 func (c Client) Update(id string, params *stripe.BankAccountParams) (*stripe.BankAccount, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -110,6 +122,10 @@ func Del(id string, params *stripe.BankAccountParams) (*stripe.BankAccount, erro
 }
 
 func (c Client) Del(id string, params *stripe.BankAccountParams) (*stripe.BankAccount, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -141,13 +157,11 @@ func List(params *stripe.BankAccountListParams) *Iter {
 func (c Client) List(params *stripe.BankAccountListParams) *Iter {
 	body := &form.Values{}
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	form.AppendTo(body, params)
 	lp = &params.ListParams
-	p = params.ToParams()
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.BankAccount, stripe.ListMeta, error) {
 		list := &stripe.BankAccountList{}
 		var err error
 
@@ -159,12 +173,7 @@ func (c Client) List(params *stripe.BankAccountListParams) *Iter {
 			err = errors.New("Invalid bank account params: either Customer or AccountID need to be set")
 		}
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -172,13 +181,13 @@ func (c Client) List(params *stripe.BankAccountListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.BankAccount]
 }
 
 // BankAccount returns the most recent BankAccount
 // visited by a call to Next.
 func (i *Iter) BankAccount() *stripe.BankAccount {
-	return i.Current().(*stripe.BankAccount)
+	return i.Current()
 }
 
 func getC() Client {
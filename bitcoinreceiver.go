@@ -8,9 +8,11 @@ import (
 // For more details see https://stripe.com/docs/api/#list_bitcoin_receivers.
 type BitcoinReceiverListParams struct {
 	ListParams `form:"*"`
-	NotActive  bool `form:"active,invert"`
-	NotFilled  bool `form:"filled,invert"`
-	Uncaptured bool `form:"uncaptured_funds"`
+	Active     *bool `form:"active"`
+	NotActive  bool  `form:"active,invert"` // Deprecated: use Active
+	Filled     *bool `form:"filled"`
+	NotFilled  bool  `form:"filled,invert"` // Deprecated: use Filled
+	Uncaptured bool  `form:"uncaptured_funds"`
 }
 
 // BitcoinReceiverParams is the set of parameters that can be used when creating a BitcoinReceiver.
@@ -58,24 +60,24 @@ type BitcoinReceiver struct {
 }
 
 // BitcoinReceiverList is a list of bitcoin receivers as retrieved from a list endpoint.
-type BitcoinReceiverList struct {
-	ListMeta
-	Values []*BitcoinReceiver `json:"data"`
-}
+type BitcoinReceiverList = List[*BitcoinReceiver]
 
 // UnmarshalJSON handles deserialization of a BitcoinReceiver.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (br *BitcoinReceiver) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		br.ID = id
+		return nil
+	}
+
 	type bitcoinReceiver BitcoinReceiver
 	var r bitcoinReceiver
 	err := json.Unmarshal(data, &r)
-	if err == nil {
-		*br = BitcoinReceiver(r)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		br.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*br = BitcoinReceiver(r)
 	return nil
 }
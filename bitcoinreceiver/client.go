@@ -24,6 +24,10 @@ func New(params *stripe.BitcoinReceiverParams) (*stripe.BitcoinReceiver, error)
 }
 
 func (c Client) New(params *stripe.BitcoinReceiverParams) (*stripe.BitcoinReceiver, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -59,6 +63,10 @@ func Update(id string, params *stripe.BitcoinReceiverUpdateParams) (*stripe.Bitc
 }
 
 func (c Client) Update(id string, params *stripe.BitcoinReceiverUpdateParams) (*stripe.BitcoinReceiver, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -77,25 +85,18 @@ func List(params *stripe.BitcoinReceiverListParams) *Iter {
 func (c Client) List(params *stripe.BitcoinReceiverListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.BitcoinReceiver, stripe.ListMeta, error) {
 		list := &stripe.BitcoinReceiverList{}
 		err := c.B.Call("GET", "/bitcoin/receivers", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -103,13 +104,13 @@ func (c Client) List(params *stripe.BitcoinReceiverListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.BitcoinReceiver]
 }
 
 // BitcoinReceiver returns the most recent BitcoinReceiver
 // visited by a call to Next.
 func (i *Iter) BitcoinReceiver() *stripe.BitcoinReceiver {
-	return i.Current().(*stripe.BitcoinReceiver)
+	return i.Current()
 }
 
 func getC() Client {
@@ -12,10 +12,7 @@ type BitcoinTransactionListParams struct {
 // BitcoinTransactionList is a list object for BitcoinTransactions.
 // It is a child object of BitcoinRecievers
 // For more details see https://stripe.com/docs/api/#retrieve_bitcoin_receiver
-type BitcoinTransactionList struct {
-	ListMeta
-	Values []*BitcoinTransaction `json:"data"`
-}
+type BitcoinTransactionList = List[*BitcoinTransaction]
 
 // BitcoinTransaction is the resource representing a Stripe bitcoin transaction.
 // For more details see https://stripe.com/docs/api/#bitcoin_receivers
@@ -33,15 +30,18 @@ type BitcoinTransaction struct {
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (bt *BitcoinTransaction) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		bt.ID = id
+		return nil
+	}
+
 	type bitcoinTransaction BitcoinTransaction
 	var t bitcoinTransaction
 	err := json.Unmarshal(data, &t)
-	if err == nil {
-		*bt = BitcoinTransaction(t)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		bt.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*bt = BitcoinTransaction(t)
 	return nil
 }
@@ -23,25 +23,18 @@ func List(params *stripe.BitcoinTransactionListParams) *Iter {
 func (c Client) List(params *stripe.BitcoinTransactionListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.BitcoinTransaction, stripe.ListMeta, error) {
 		list := &stripe.BitcoinTransactionList{}
 		err := c.B.Call("GET", fmt.Sprintf("/bitcoin/receivers/%v/transactions", params.Receiver), c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -49,13 +42,13 @@ func (c Client) List(params *stripe.BitcoinTransactionListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.BitcoinTransaction]
 }
 
 // BitcoinTransaction returns the most recent BitcoinTransaction
 // visited by a call to Next.
 func (i *Iter) BitcoinTransaction() *stripe.BitcoinTransaction {
-	return i.Current().(*stripe.BitcoinTransaction)
+	return i.Current()
 }
 
 func getC() Client {
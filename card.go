@@ -149,18 +149,18 @@ type CardListParams struct {
 // Card is the resource representing a Stripe credit/debit card.
 // For more details see https://stripe.com/docs/api#cards.
 type Card struct {
-	Address1      string       `json:"address_line1"`
-	Address1Check Verification `json:"address_line1_check"`
-	Address2      string       `json:"address_line2"`
-	Brand         CardBrand    `json:"brand"`
-	CVCCheck      Verification `json:"cvc_check"`
-	CardCountry   string       `json:"country"`
-	City          string       `json:"address_city"`
-	Country       string       `json:"address_country"`
-	Currency      Currency     `json:"currency"`
-	Customer      *Customer    `json:"customer"`
-	Default       bool         `json:"default_for_currency"`
-	Deleted       bool         `json:"deleted"`
+	Address1      string                `json:"address_line1"`
+	Address1Check Verification          `json:"address_line1_check"`
+	Address2      string                `json:"address_line2"`
+	Brand         CardBrand             `json:"brand"`
+	CVCCheck      Verification          `json:"cvc_check"`
+	CardCountry   string                `json:"country"`
+	City          string                `json:"address_city"`
+	Country       string                `json:"address_country"`
+	Currency      Currency              `json:"currency"`
+	Customer      *Expandable[Customer] `json:"customer"`
+	Default       bool                  `json:"default_for_currency"`
+	Deleted       bool                  `json:"deleted"`
 
 	// Description is a succinct summary of the card's information.
 	//
@@ -185,38 +185,38 @@ type Card struct {
 	// as part of standard API requests.
 	Issuer string `json:"issuer"`
 
-	LastFour           string             `json:"last4"`
-	Meta               map[string]string  `json:"metadata"`
-	Month              uint8              `json:"exp_month"`
-	Name               string             `json:"name"`
-	Recipient          *Recipient         `json:"recipient"`
-	State              string             `json:"address_state"`
-	ThreeDSecure       *ThreeDSecure      `json:"three_d_secure"`
-	TokenizationMethod TokenizationMethod `json:"tokenization_method"`
-	Year               uint16             `json:"exp_year"`
-	Zip                string             `json:"address_zip"`
-	ZipCheck           Verification       `json:"address_zip_check"`
+	LastFour           string                 `json:"last4"`
+	Meta               map[string]string      `json:"metadata"`
+	Month              uint8                  `json:"exp_month"`
+	Name               string                 `json:"name"`
+	Recipient          *Expandable[Recipient] `json:"recipient"`
+	State              string                 `json:"address_state"`
+	ThreeDSecure       *ThreeDSecure          `json:"three_d_secure"`
+	TokenizationMethod TokenizationMethod     `json:"tokenization_method"`
+	Year               uint16                 `json:"exp_year"`
+	Zip                string                 `json:"address_zip"`
+	ZipCheck           Verification           `json:"address_zip_check"`
 }
 
 // CardList is a list object for cards.
-type CardList struct {
-	ListMeta
-	Values []*Card `json:"data"`
-}
+type CardList = List[*Card]
 
 // UnmarshalJSON handles deserialization of a Card.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (c *Card) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		c.ID = id
+		return nil
+	}
+
 	type card Card
 	var cc card
 	err := json.Unmarshal(data, &cc)
-	if err == nil {
-		*c = Card(cc)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		c.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*c = Card(cc)
 	return nil
 }
@@ -41,6 +41,10 @@ func New(params *stripe.CardParams) (*stripe.Card, error) {
 }
 
 func (c Client) New(params *stripe.CardParams) (*stripe.Card, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	if params == nil {
 		return nil, errors.New("params should not be nil")
 	}
@@ -75,6 +79,10 @@ func Get(id string, params *stripe.CardParams) (*stripe.Card, error) {
 }
 
 func (c Client) Get(id string, params *stripe.CardParams) (*stripe.Card, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	if params == nil {
 		return nil, errors.New("params should not be nil")
 	}
@@ -111,6 +119,10 @@ func Update(id string, params *stripe.CardParams) (*stripe.Card, error) {
 }
 
 func (c Client) Update(id string, params *stripe.CardParams) (*stripe.Card, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	if params == nil {
 		return nil, errors.New("params should not be nil")
 	}
@@ -141,6 +153,10 @@ func Del(id string, params *stripe.CardParams) (*stripe.Card, error) {
 }
 
 func (c Client) Del(id string, params *stripe.CardParams) (*stripe.Card, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	if params == nil {
 		return nil, errors.New("params should not be nil")
 	}
@@ -177,15 +193,13 @@ func List(params *stripe.CardListParams) *Iter {
 func (c Client) List(params *stripe.CardListParams) *Iter {
 	body := &form.Values{}
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Card, stripe.ListMeta, error) {
 		list := &stripe.CardList{}
 		var err error
 
@@ -203,12 +217,7 @@ func (c Client) List(params *stripe.CardListParams) *Iter {
 			err = errors.New("Invalid card params: either account, customer or recipient need to be set")
 		}
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -216,13 +225,13 @@ func (c Client) List(params *stripe.CardListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Card]
 }
 
 // Card returns the most recent Card
 // visited by a call to Next.
 func (i *Iter) Card() *stripe.Card {
-	return i.Current().(*stripe.Card)
+	return i.Current()
 }
 
 func getC() Client {
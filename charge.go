@@ -16,8 +16,8 @@ type FraudReport string
 // For more details see https://stripe.com/docs/api#create_charge and https://stripe.com/docs/api#update_charge.
 type ChargeParams struct {
 	Params        `form:"*"`
-	Amount        uint64              `form:"amount"`
-	Currency      Currency            `form:"currency"`
+	Amount        uint64              `form:"amount" validate:"required"`
+	Currency      Currency            `form:"currency" validate:"required"`
 	Customer      string              `form:"customer"`
 	Desc          string              `form:"description"`
 	Destination   *DestinationParams  `form:"destination"`
@@ -25,7 +25,8 @@ type ChargeParams struct {
 	ExchangeRate  float64             `form:"exchange_rate"`
 	Fee           uint64              `form:"application_fee"`
 	FraudDetails  *FraudDetailsParams `form:"fraud_details"`
-	NoCapture     bool                `form:"capture,invert"`
+	Capture       *bool               `form:"capture"`
+	NoCapture     bool                `form:"capture,invert"` // Deprecated: use Capture
 	OnBehalfOf    string              `form:"on_behalf_of"`
 	Shipping      *ShippingDetails    `form:"shipping"`
 	Source        *SourceParams       `form:"*"` // SourceParams has custom encoding so brought to top level with "*"
@@ -75,62 +76,73 @@ type CaptureParams struct {
 // Charge is the resource representing a Stripe charge.
 // For more details see https://stripe.com/docs/api#charges.
 type Charge struct {
-	Amount         uint64            `json:"amount"`
-	AmountRefunded uint64            `json:"amount_refunded"`
-	Application    *Application      `json:"application"`
-	Captured       bool              `json:"captured"`
-	Created        int64             `json:"created"`
-	Currency       Currency          `json:"currency"`
-	Customer       *Customer         `json:"customer"`
-	Desc           string            `json:"description"`
-	Dest           *Account          `json:"destination"`
-	Dispute        *Dispute          `json:"dispute"`
-	Email          string            `json:"receipt_email"`
-	FailCode       string            `json:"failure_code"`
-	FailMsg        string            `json:"failure_message"`
-	Fee            *Fee              `json:"application_fee"`
-	FraudDetails   *FraudDetails     `json:"fraud_details"`
-	ID             string            `json:"id"`
-	Invoice        *Invoice          `json:"invoice"`
-	Live           bool              `json:"livemode"`
-	Meta           map[string]string `json:"metadata"`
-	Outcome        *ChargeOutcome    `json:"outcome"`
-	Paid           bool              `json:"paid"`
-	ReceiptNumber  string            `json:"receipt_number"`
-	Refunded       bool              `json:"refunded"`
-	Refunds        *RefundList       `json:"refunds"`
-	Review         *Review           `json:"review"`
-	Shipping       *ShippingDetails  `json:"shipping"`
-	Source         *PaymentSource    `json:"source"`
-	SourceTransfer *Transfer         `json:"source_transfer"`
-	Statement      string            `json:"statement_descriptor"`
-	Status         string            `json:"status"`
-	Transfer       *Transfer         `json:"transfer"`
-	TransferGroup  string            `json:"transfer_group"`
-	Tx             *Transaction      `json:"balance_transaction"`
+	Amount         uint64                   `json:"amount"`
+	AmountRefunded uint64                   `json:"amount_refunded"`
+	Application    *Expandable[Application] `json:"application"`
+	Captured       bool                     `json:"captured"`
+	Created        int64                    `json:"created"`
+	Currency       Currency                 `json:"currency"`
+	Customer       *Expandable[Customer]    `json:"customer"`
+	Desc           string                   `json:"description"`
+	Dest           *Expandable[Account]     `json:"destination"`
+	Dispute        *Expandable[Dispute]     `json:"dispute"`
+	Email          string                   `json:"receipt_email"`
+	FailCode       string                   `json:"failure_code"`
+	FailMsg        string                   `json:"failure_message"`
+	Fee            *Expandable[Fee]         `json:"application_fee"`
+	FraudDetails   *FraudDetails            `json:"fraud_details"`
+	ID             string                   `json:"id"`
+	Invoice        *Expandable[Invoice]     `json:"invoice"`
+	Live           bool                     `json:"livemode"`
+	Meta           map[string]string        `json:"metadata"`
+	Outcome        *ChargeOutcome           `json:"outcome"`
+	Paid           bool                     `json:"paid"`
+	ReceiptNumber  string                   `json:"receipt_number"`
+	Refunded       bool                     `json:"refunded"`
+	Refunds        *RefundList              `json:"refunds"`
+	Review         *Expandable[Review]      `json:"review"`
+	Shipping       *ShippingDetails         `json:"shipping"`
+	Source         *PaymentSource           `json:"source"`
+	SourceTransfer *Expandable[Transfer]    `json:"source_transfer"`
+	Statement      string                   `json:"statement_descriptor"`
+	Status         string                   `json:"status"`
+	Transfer       *Expandable[Transfer]    `json:"transfer"`
+	TransferGroup  string                   `json:"transfer_group"`
+	Tx             *Expandable[Transaction] `json:"balance_transaction"`
+}
+
+// AmountMoney returns the amount of the charge in its currency.
+func (c *Charge) AmountMoney() Money {
+	return moneyFromUint64(c.Amount, c.Currency)
+}
+
+// AmountRefundedMoney returns the amount refunded from the charge in its currency.
+func (c *Charge) AmountRefundedMoney() Money {
+	return moneyFromUint64(c.AmountRefunded, c.Currency)
 }
 
 // UnmarshalJSON handles deserialization of a charge.
 // This custom unmarshaling is needed because the resulting
 // property may be an ID or the full struct if it was expanded.
 func (c *Charge) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		c.ID = id
+		return nil
+	}
+
 	type charge Charge
 	var cc charge
 	err := json.Unmarshal(data, &cc)
-	if err == nil {
-		*c = Charge(cc)
-	} else {
-		// the ID is surrounded by "\" characters, so strip them
-		c.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
+
+	*c = Charge(cc)
 	return nil
 }
 
 // ChargeList is a list of charges as retrieved from a list endpoint.
-type ChargeList struct {
-	ListMeta
-	Values []*Charge `json:"data"`
-}
+type ChargeList = List[*Charge]
 
 // FraudDetails is the structure detailing fraud status.
 type FraudDetails struct {
@@ -171,15 +183,18 @@ var depth int = -1
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (c *ChargeOutcomeRule) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		c.ID = id
+		return nil
+	}
+
 	type chargeOutcomeRule ChargeOutcomeRule
 	var cc chargeOutcomeRule
 	err := json.Unmarshal(data, &cc)
-	if err == nil {
-		*c = ChargeOutcomeRule(cc)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		c.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*c = ChargeOutcomeRule(cc)
 	return nil
 }
@@ -2,6 +2,9 @@
 package client
 
 import (
+	"context"
+	"net/http"
+
 	. "github.com/stripe/stripe-go"
 	"github.com/stripe/stripe-go/account"
 	"github.com/stripe/stripe-go/balance"
@@ -148,15 +151,121 @@ type API struct {
 	PaymentSource *paymentsource.Client
 	// ExchangeRates is the client used to invoke /exchange_rates APIs.
 	ExchangeRates *exchangerate.Client
+
+	// key and backends are what the resource clients were set up with, for
+	// fetching references with Fetch.
+	key      string
+	backends *Backends
+}
+
+// Options configures a client. Fields left empty fall back to the package
+// level defaults of the stripe package.
+type Options struct {
+	// Key is the secret API key requests are authenticated with. It
+	// defaults to stripe.Key.
+	Key string
+
+	// APIVersion is the version of the Stripe API requested. It defaults
+	// to the version the library was built against.
+	APIVersion string
+
+	// APIURL and UploadsURL are the base URLs of the API and uploads
+	// services. They default to stripe.APIURL and stripe.UploadsURL.
+	APIURL     string
+	UploadsURL string
+
+	// HTTPClient is the client requests are made with. It defaults to the
+	// one set with stripe.SetHTTPClient.
+	HTTPClient *http.Client
+
+	// Logger receives the client's log records. It defaults to
+	// stripe.Logger, filtered by stripe.LogLevel.
+	Logger StructuredLogger
+
+	// RetryPolicy controls how failed requests are retried. They aren't
+	// when it's nil.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter paces the client's requests, which are sent as fast as
+	// possible when it's nil. It's shared by the API and uploads backends.
+	RateLimiter *RateLimiter
+
+	// Telemetry collects metrics about the client's requests. It's shared
+	// by the API and uploads backends.
+	Telemetry *Telemetry
+
+	// AppInfo identifies the plugin using the client. It defaults to the
+	// one set with stripe.SetAppInfo.
+	AppInfo *AppInfo
+
+	// StripeAccount is the connected account requests are made on behalf
+	// of when their Params don't name one.
+	StripeAccount string
+
+	// ValidateParams enables validating the parameters of the client's
+	// requests before they're made, even if the package level
+	// stripe.ValidateParams isn't. It's ignored when Backends are set, in
+	// which case it's enabled with BackendConfiguration.ValidateParams.
+	ValidateParams bool
+
+	// Backends, when set, are used as they are instead of backends built
+	// from the options above.
+	Backends *Backends
+}
+
+// backends returns the backends configured by the options.
+func (o *Options) backends() *Backends {
+	if o.Backends != nil {
+		return o.Backends
+	}
+
+	newBackend := func(backend SupportedBackend, url string) *BackendConfiguration {
+		return &BackendConfiguration{
+			Type:          backend,
+			URL:           url,
+			HTTPClient:    o.HTTPClient,
+			APIVersion:    o.APIVersion,
+			AppInfo:       o.AppInfo,
+			StripeAccount: o.StripeAccount,
+			RetryPolicy:   o.RetryPolicy,
+			RateLimiter:   o.RateLimiter,
+			Telemetry:     o.Telemetry,
+			Logger:        o.Logger,
+
+			ValidateParams: o.ValidateParams,
+		}
+	}
+
+	apiURL, uploadsURL := o.APIURL, o.UploadsURL
+	if apiURL == "" {
+		apiURL = APIURL
+	}
+	if uploadsURL == "" {
+		uploadsURL = UploadsURL
+	}
+
+	return &Backends{
+		API:     newBackend(APIBackend, apiURL),
+		Uploads: newBackend(UploadsBackend, uploadsURL),
+	}
 }
 
 // Init initializes the Stripe client with the appropriate secret key
 // as well as providing the ability to override the backend as needed.
+// The package level backends are used when backends is nil.
 func (a *API) Init(key string, backends *Backends) {
 	if backends == nil {
 		backends = &Backends{API: GetBackend(APIBackend), Uploads: GetBackend(UploadsBackend)}
 	}
 
+	a.init(key, backends)
+}
+
+// init sets up every resource client with the same key and backends.
+func (a *API) init(key string, backends *Backends) {
+	a.key = key
+	a.backends = backends
+
 	a.Charges = &charge.Client{B: backends.API, Key: key}
 	a.Customers = &customer.Client{B: backends.API, Key: key}
 	a.Cards = &card.Client{B: backends.API, Key: key}
@@ -195,10 +304,28 @@ func (a *API) Init(key string, backends *Backends) {
 	a.ExchangeRates = &exchangerate.Client{B: backends.API, Key: key}
 }
 
-// New creates a new Stripe client with the appropriate secret key
-// as well as providing the ability to override the backends as needed.
-func New(key string, backends *Backends) *API {
+// New creates a new Stripe client configured by options. Unlike the package
+// level functions of the resource packages, the client only uses the
+// package level configuration for the options it leaves empty, so that
+// several clients with different keys or settings can be used side by side.
+func New(options *Options) *API {
+	if options == nil {
+		options = &Options{}
+	}
+
+	key := options.Key
+	if key == "" {
+		key = Key
+	}
+
 	api := API{}
-	api.Init(key, backends)
+	api.init(key, options.backends())
 	return &api
 }
+
+// Fetch returns the object ref points to, retrieving it with the key and
+// backends of api if it wasn't expanded, like the resource clients of api
+// would.
+func Fetch[T any](ctx context.Context, api *API, ref *Expandable[T]) (*T, error) {
+	return ref.FetchWith(ctx, api.backends, api.key)
+}
@@ -1,9 +1,13 @@
 package client
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
 )
 
 func TestAPIInit(t *testing.T) {
@@ -13,6 +17,77 @@ func TestAPIInit(t *testing.T) {
 }
 
 func TestAPINew(t *testing.T) {
-	api := New("sk_test_123", nil)
+	api := New(&Options{Key: "sk_test_123"})
 	assert.Equal(t, "sk_test_123", api.Charges.Key)
+
+	backend := api.Charges.B.(*stripe.BackendConfiguration)
+	assert.Equal(t, stripe.APIURL, backend.URL)
+	assert.Equal(t, stripe.UploadsURL, api.FileUploads.B.(*stripe.BackendConfiguration).URL)
+	assert.True(t, backend != stripe.GetBackend(stripe.APIBackend))
+}
+
+func TestAPINewDefaults(t *testing.T) {
+	previous := stripe.Key
+	stripe.Key = "sk_test_global"
+	defer func() { stripe.Key = previous }()
+
+	api := New(nil)
+	assert.Equal(t, "sk_test_global", api.Customers.Key)
+}
+
+func TestAPINewOptions(t *testing.T) {
+	var headers []http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header)
+		w.Write([]byte(`{"id":"cus_123"}`))
+	}))
+	defer ts.Close()
+
+	options := &Options{
+		APIURL:        ts.URL,
+		APIVersion:    "2017-08-15",
+		AppInfo:       &stripe.AppInfo{Name: "MyAwesomePlugin"},
+		StripeAccount: "acct_123",
+	}
+
+	options.Key = "sk_test_123"
+	first := New(options)
+	options.Key = "sk_test_456"
+	options.StripeAccount = ""
+	second := New(options)
+
+	_, err := first.Customers.Get("cus_123", nil)
+	assert.NoError(t, err)
+
+	params := &stripe.CustomerParams{}
+	params.StripeAccount = "acct_456"
+	_, err = first.Customers.Get("cus_123", params)
+	assert.NoError(t, err)
+
+	_, err = second.Customers.Get("cus_123", nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Bearer sk_test_123", headers[0].Get("Authorization"))
+	assert.Equal(t, "2017-08-15", headers[0].Get("Stripe-Version"))
+	assert.Contains(t, headers[0].Get("User-Agent"), "MyAwesomePlugin")
+	assert.Equal(t, "acct_123", headers[0].Get("Stripe-Account"))
+	assert.Equal(t, "acct_456", headers[1].Get("Stripe-Account"))
+	assert.Equal(t, "Bearer sk_test_456", headers[2].Get("Authorization"))
+	assert.Equal(t, "", headers[2].Get("Stripe-Account"))
+}
+
+func TestFetch(t *testing.T) {
+	var auth, path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, path = r.Header.Get("Authorization"), r.URL.Path
+		w.Write([]byte(`{"id":"ch_123","amount":100}`))
+	}))
+	defer ts.Close()
+
+	api := New(&Options{Key: "sk_test_123", APIURL: ts.URL})
+	charge, err := Fetch(context.Background(), api, stripe.NewExpandableID[stripe.Charge]("ch_123"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), charge.Amount)
+	assert.Equal(t, "Bearer sk_test_123", auth)
+	assert.Equal(t, "/charges/ch_123", path)
 }
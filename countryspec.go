@@ -22,10 +22,7 @@ type CountrySpec struct {
 }
 
 // CountrySpecList is a list of country specs as retrieved from a list endpoint.
-type CountrySpecList struct {
-	ListMeta
-	Values []*CountrySpec `json:"data"`
-}
+type CountrySpecList = List[*CountrySpec]
 
 // CountrySpecListParams are the parameters allowed during CountrySpec listing.
 type CountrySpecListParams struct {
@@ -33,25 +33,18 @@ func List(params *stripe.CountrySpecListParams) *Iter {
 func (c Client) List(params *stripe.CountrySpecListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.CountrySpec, stripe.ListMeta, error) {
 		list := &stripe.CountrySpecList{}
 		err := c.B.Call("GET", "/country_specs", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -59,13 +52,13 @@ func (c Client) List(params *stripe.CountrySpecListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.CountrySpec]
 }
 
 // CountrySpec returns the most recent CountrySpec
 // visited by a call to Next.
 func (i *Iter) CountrySpec() *stripe.CountrySpec {
-	return i.Current().(*stripe.CountrySpec)
+	return i.Current()
 }
 
 func getC() Client {
@@ -48,24 +48,24 @@ type Coupon struct {
 }
 
 // CouponList is a list of coupons as retrieved from a list endpoint.
-type CouponList struct {
-	ListMeta
-	Values []*Coupon `json:"data"`
-}
+type CouponList = List[*Coupon]
 
 // UnmarshalJSON handles deserialization of a Coupon.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (c *Coupon) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		c.ID = id
+		return nil
+	}
+
 	type coupon Coupon
 	var cc coupon
 	err := json.Unmarshal(data, &cc)
-	if err == nil {
-		*c = Coupon(cc)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		c.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*c = Coupon(cc)
 	return nil
 }
@@ -27,6 +27,10 @@ func New(params *stripe.CouponParams) (*stripe.Coupon, error) {
 }
 
 func (c Client) New(params *stripe.CouponParams) (*stripe.Coupon, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -43,6 +47,10 @@ func Get(id string, params *stripe.CouponParams) (*stripe.Coupon, error) {
 }
 
 func (c Client) Get(id string, params *stripe.CouponParams) (*stripe.Coupon, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -66,6 +74,10 @@ func Update(id string, params *stripe.CouponParams) (*stripe.Coupon, error) {
 }
 
 func (c Client) Update(id string, params *stripe.CouponParams) (*stripe.Coupon, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -82,6 +94,10 @@ func Del(id string, params *stripe.CouponParams) (*stripe.Coupon, error) {
 }
 
 func (c Client) Del(id string, params *stripe.CouponParams) (*stripe.Coupon, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -106,25 +122,18 @@ func List(params *stripe.CouponListParams) *Iter {
 func (c Client) List(params *stripe.CouponListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Coupon, stripe.ListMeta, error) {
 		list := &stripe.CouponList{}
 		err := c.B.Call("GET", "/coupons", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -132,13 +141,13 @@ func (c Client) List(params *stripe.CouponListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Coupon]
 }
 
 // Coupon returns the most recent Coupon
 // visited by a call to Next.
 func (i *Iter) Coupon() *stripe.Coupon {
-	return i.Current().(*stripe.Coupon)
+	return i.Current()
 }
 
 func getC() Client {
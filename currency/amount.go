@@ -0,0 +1,95 @@
+package currency
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+// FormatAmount returns an amount in integer minor units, like
+// ChargeParams.Amount, as a decimal string in the major unit, like "10.50"
+// for 1050 in USD, or "1050" for 1050 in JPY which is a zero-decimal
+// currency.
+func FormatAmount(amount int64, c stripe.Currency) (string, error) {
+	info, err := lookup(c)
+	if err != nil {
+		return "", err
+	}
+
+	whole, frac := splitAmount(amount, info.Decimals())
+	s := whole
+	if frac != "" {
+		s += "." + frac
+	}
+	if amount < 0 {
+		s = "-" + s
+	}
+	return s, nil
+}
+
+// ParseAmount returns a decimal string in the major unit, like "10.50", as an
+// amount in integer minor units, like 1050 for USD. It's an error for the
+// string to have more decimals than the currency, unless they're zeros.
+func ParseAmount(s string, c stripe.Currency) (int64, error) {
+	info, err := lookup(c)
+	if err != nil {
+		return 0, err
+	}
+	decimals := info.Decimals()
+
+	str := strings.TrimSpace(s)
+	sign := ""
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		sign, str = str[:1], str[1:]
+	}
+
+	whole, frac := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		whole, frac = str[:i], str[i+1:]
+	}
+	if whole+frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("Invalid amount: %q", s)
+	}
+
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > decimals {
+		return 0, fmt.Errorf("Invalid amount: %q has more than %d decimals for %s",
+			s, decimals, strings.ToUpper(string(info.Code)))
+	}
+	frac += strings.Repeat("0", decimals-len(frac))
+
+	amount, err := strconv.ParseInt(sign+"0"+whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid amount: %q is out of range", s)
+	}
+	return amount, nil
+}
+
+// splitAmount returns the digits of the whole and fractional parts of the
+// absolute value of an amount in minor units.
+func splitAmount(amount int64, decimals int) (string, string) {
+	abs := uint64(amount)
+	if amount < 0 {
+		abs = -abs
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if decimals == 0 {
+		return digits, ""
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-decimals], digits[len(digits)-decimals:]
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -1,5 +1,145 @@
 package currency
 
 import (
+	"math"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
 	_ "github.com/stripe/stripe-go/testing"
 )
+
+func TestLookup(t *testing.T) {
+	info, ok := Lookup(USD)
+	assert.True(t, ok)
+	assert.Equal(t, &Info{Code: USD, Name: "United States Dollar", Numeric: "840",
+		Exponent: 2, Symbol: "$"}, info)
+
+	info, ok = Lookup("BHD")
+	assert.True(t, ok)
+	assert.Equal(t, 3, info.Decimals())
+
+	_, ok = Lookup("xyz")
+	assert.False(t, ok)
+
+	// Every currency is in the registry.
+	for code, info := range registry {
+		assert.Equal(t, code, info.Code)
+		assert.Len(t, info.Numeric, 3)
+	}
+}
+
+func TestInfo_Decimals(t *testing.T) {
+	assert.Equal(t, 2, registry[USD].Decimals())
+	assert.Equal(t, 0, registry[JPY].Decimals())
+	assert.Equal(t, 3, registry[KWD].Decimals())
+
+	// Stripe expects MGA in the major unit and ISK with two decimals, unlike
+	// ISO 4217.
+	assert.Equal(t, 0, registry[MGA].Decimals())
+	assert.Equal(t, 2, registry[ISK].Decimals())
+}
+
+func TestParse(t *testing.T) {
+	c, err := Parse(" EUR ")
+	assert.NoError(t, err)
+	assert.Equal(t, EUR, c)
+
+	_, err = Parse("euro")
+	assert.EqualError(t, err, `Unknown currency: "euro"`)
+}
+
+func TestIsZeroDecimal(t *testing.T) {
+	assert.True(t, IsZeroDecimal(JPY))
+	assert.True(t, IsZeroDecimal("KRW"))
+	assert.False(t, IsZeroDecimal(USD))
+	assert.False(t, IsZeroDecimal("xyz"))
+}
+
+func TestFormatAmount(t *testing.T) {
+	testCases := []struct {
+		amount   int64
+		currency stripe.Currency
+		want     string
+	}{
+		{1050, USD, "10.50"},
+		{5, USD, "0.05"},
+		{-1050, EUR, "-10.50"},
+		{1050, JPY, "1050"},
+		{1050, BHD, "1.050"},
+		{math.MinInt64, USD, "-92233720368547758.08"},
+	}
+	for _, tc := range testCases {
+		s, err := FormatAmount(tc.amount, tc.currency)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, s)
+	}
+
+	_, err := FormatAmount(100, "xyz")
+	assert.Error(t, err)
+}
+
+func TestParseAmount(t *testing.T) {
+	testCases := []struct {
+		s        string
+		currency stripe.Currency
+		want     int64
+	}{
+		{"10.50", USD, 1050},
+		{"10.5", USD, 1050},
+		{"10", USD, 1000},
+		{".05", USD, 5},
+		{"-10.50", EUR, -1050},
+		{"1050", JPY, 1050},
+		{"1050.00", JPY, 1050},
+		{"1.05", BHD, 1050},
+		{"-92233720368547758.08", USD, math.MinInt64},
+	}
+	for _, tc := range testCases {
+		amount, err := ParseAmount(tc.s, tc.currency)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, amount)
+	}
+
+	_, err := ParseAmount("10.505", USD)
+	assert.EqualError(t, err, `Invalid amount: "10.505" has more than 2 decimals for USD`)
+
+	_, err = ParseAmount("10.5", JPY)
+	assert.Error(t, err)
+
+	for _, s := range []string{"", ".", "1,000", "1e3", "abc"} {
+		_, err = ParseAmount(s, USD)
+		assert.Error(t, err, s)
+	}
+
+	_, err = ParseAmount("92233720368547758.08", USD)
+	assert.EqualError(t, err, `Invalid amount: "92233720368547758.08" is out of range`)
+}
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		amount   int64
+		currency stripe.Currency
+		locale   string
+		want     string
+	}{
+		{123456, USD, "en-US", "$1,234.56"},
+		{-123456, USD, "en", "-$1,234.56"},
+		{100, USD, "", "$1.00"},
+		{123456, EUR, "de-DE", "1.234,56\u00a0€"},
+		{123456, EUR, "fr_FR", "1\u202f234,56\u00a0€"},
+		{123456, EUR, "nl", "€\u00a01.234,56"},
+		{123456789, JPY, "ja-JP", "¥123,456,789"},
+		{123456, CHF, "de-CH", "CHF\u00a01’234.56"},
+		{123456, SEK, "sv-SE", "1\u00a0234,56\u00a0kr"},
+		{123456, KWD, "xx", "KD123.456"},
+	}
+	for _, tc := range testCases {
+		s, err := Format(tc.amount, tc.currency, tc.locale)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, s)
+	}
+
+	_, err := Format(100, "xyz", "en")
+	assert.Error(t, err)
+}
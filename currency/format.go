@@ -0,0 +1,110 @@
+package currency
+
+import (
+	"strings"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+// numberFormat describes how a locale displays amounts of money.
+type numberFormat struct {
+	decimal string
+	group   string
+
+	// symbolFirst is whether the symbol goes before the number, and
+	// symbolSpace whether it's separated from it by a space.
+	symbolFirst bool
+	symbolSpace bool
+}
+
+// Non-breaking spaces, so that amounts aren't split across lines.
+const (
+	nbsp       = "\u00a0"
+	narrowNbsp = "\u202f"
+)
+
+// numberFormats are the formats of the locales supported by Format, by
+// language or language and region.
+var numberFormats = map[string]numberFormat{
+	"da":    {decimal: ",", group: ".", symbolSpace: true},
+	"de":    {decimal: ",", group: ".", symbolSpace: true},
+	"de-ch": {decimal: ".", group: "’", symbolFirst: true, symbolSpace: true},
+	"en":    {decimal: ".", group: ",", symbolFirst: true},
+	"es":    {decimal: ",", group: ".", symbolSpace: true},
+	"fi":    {decimal: ",", group: nbsp, symbolSpace: true},
+	"fr":    {decimal: ",", group: narrowNbsp, symbolSpace: true},
+	"fr-ch": {decimal: ".", group: narrowNbsp, symbolSpace: true},
+	"it":    {decimal: ",", group: ".", symbolSpace: true},
+	"ja":    {decimal: ".", group: ",", symbolFirst: true},
+	"ko":    {decimal: ".", group: ",", symbolFirst: true},
+	"nb":    {decimal: ",", group: nbsp, symbolSpace: true},
+	"nl":    {decimal: ",", group: ".", symbolFirst: true, symbolSpace: true},
+	"pl":    {decimal: ",", group: nbsp, symbolSpace: true},
+	"pt":    {decimal: ",", group: ".", symbolFirst: true, symbolSpace: true},
+	"pt-pt": {decimal: ",", group: nbsp, symbolSpace: true},
+	"sv":    {decimal: ",", group: nbsp, symbolSpace: true},
+	"zh":    {decimal: ".", group: ",", symbolFirst: true},
+}
+
+// Format returns an amount in integer minor units, like
+// ChargeParams.Amount, formatted with the currency's symbol for display in a
+// locale, like "$1,234.56" for 123456 in USD and "en-US", or "1.234,56 €"
+// for 123456 in EUR and "de-DE".
+//
+// Locales are BCP 47 language tags. Locales which aren't supported are
+// formatted like English.
+func Format(amount int64, c stripe.Currency, locale string) (string, error) {
+	info, err := lookup(c)
+	if err != nil {
+		return "", err
+	}
+	format := localeFormat(locale)
+
+	whole, frac := splitAmount(amount, info.Decimals())
+
+	var sb strings.Builder
+	if amount < 0 {
+		sb.WriteString("-")
+	}
+	if format.symbolFirst {
+		sb.WriteString(info.Symbol)
+		if format.symbolSpace {
+			sb.WriteString(nbsp)
+		}
+	}
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			sb.WriteString(format.group)
+		}
+		sb.WriteRune(digit)
+	}
+	if frac != "" {
+		sb.WriteString(format.decimal)
+		sb.WriteString(frac)
+	}
+	if !format.symbolFirst {
+		if format.symbolSpace {
+			sb.WriteString(nbsp)
+		}
+		sb.WriteString(info.Symbol)
+	}
+	return sb.String(), nil
+}
+
+// localeFormat returns the format of a locale, falling back to its language's
+// and then to English.
+func localeFormat(locale string) numberFormat {
+	tag := strings.ToLower(strings.Replace(locale, "_", "-", -1))
+	for tag != "" {
+		if format, ok := numberFormats[tag]; ok {
+			return format
+		}
+
+		i := strings.LastIndexByte(tag, '-')
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	return numberFormats["en"]
+}
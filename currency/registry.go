@@ -0,0 +1,230 @@
+package currency
+
+import (
+	"fmt"
+	"strings"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+// Info describes a currency.
+type Info struct {
+	// Code is the lowercase ISO 4217 code of the currency, like "usd".
+	Code stripe.Currency
+
+	// Name is the English name of the currency.
+	Name string
+
+	// Numeric is the ISO 4217 numeric code of the currency, like "840" for
+	// USD.
+	Numeric string
+
+	// Exponent is the number of digits of the currency's minor unit according
+	// to ISO 4217, like 2 for USD or 3 for BHD.
+	Exponent int
+
+	// Symbol is the symbol amounts in the currency are displayed with.
+	Symbol string
+
+	// ZeroDecimal is true for the currencies Stripe expects amounts in the
+	// major unit for, like JPY.
+	//
+	// For more details see https://stripe.com/docs/currencies#zero-decimal.
+	ZeroDecimal bool
+}
+
+// Decimals returns the number of decimal places of the amounts Stripe
+// expects in integer minor units, like in ChargeParams.Amount. It's the
+// currency's ISO 4217 exponent, except for zero-decimal currencies, and for
+// currencies without a minor unit Stripe still expects two decimals for,
+// like ISK.
+func (i *Info) Decimals() int {
+	if i.ZeroDecimal {
+		return 0
+	}
+	if i.Exponent < 2 {
+		return 2
+	}
+	return i.Exponent
+}
+
+// Lookup returns the information about a currency, and whether the currency
+// is known.
+func Lookup(c stripe.Currency) (*Info, bool) {
+	info, ok := registry[stripe.Currency(strings.ToLower(string(c)))]
+	return info, ok
+}
+
+// Parse returns the currency for an ISO 4217 code like "USD" or "usd", or an
+// error if the currency isn't known.
+func Parse(s string) (stripe.Currency, error) {
+	info, ok := Lookup(stripe.Currency(strings.TrimSpace(s)))
+	if !ok {
+		return "", fmt.Errorf("Unknown currency: %q", s)
+	}
+	return info.Code, nil
+}
+
+// IsZeroDecimal returns whether Stripe expects amounts in the currency in the
+// major unit, like for JPY.
+func IsZeroDecimal(c stripe.Currency) bool {
+	info, ok := Lookup(c)
+	return ok && info.ZeroDecimal
+}
+
+// lookup is like Lookup but returns an error for currencies that aren't
+// known.
+func lookup(c stripe.Currency) (*Info, error) {
+	info, ok := Lookup(c)
+	if !ok {
+		return nil, fmt.Errorf("Unknown currency: %q", c)
+	}
+	return info, nil
+}
+
+var registry = map[stripe.Currency]*Info{
+	AED: {Code: AED, Name: "United Arab Emirates Dirham", Numeric: "784", Exponent: 2, Symbol: "د.إ", ZeroDecimal: false},
+	AFN: {Code: AFN, Name: "Afghan Afghani", Numeric: "971", Exponent: 2, Symbol: "؋", ZeroDecimal: false},
+	ALL: {Code: ALL, Name: "Albanian Lek", Numeric: "008", Exponent: 2, Symbol: "L", ZeroDecimal: false},
+	AMD: {Code: AMD, Name: "Armenian Dram", Numeric: "051", Exponent: 2, Symbol: "֏", ZeroDecimal: false},
+	ANG: {Code: ANG, Name: "Netherlands Antillean Gulden", Numeric: "532", Exponent: 2, Symbol: "ƒ", ZeroDecimal: false},
+	AOA: {Code: AOA, Name: "Angolan Kwanza", Numeric: "973", Exponent: 2, Symbol: "Kz", ZeroDecimal: false},
+	ARS: {Code: ARS, Name: "Argentine Peso", Numeric: "032", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	AUD: {Code: AUD, Name: "Australian Dollar", Numeric: "036", Exponent: 2, Symbol: "A$", ZeroDecimal: false},
+	AWG: {Code: AWG, Name: "Aruban Florin", Numeric: "533", Exponent: 2, Symbol: "ƒ", ZeroDecimal: false},
+	AZN: {Code: AZN, Name: "Azerbaijani Manat", Numeric: "944", Exponent: 2, Symbol: "₼", ZeroDecimal: false},
+	BAM: {Code: BAM, Name: "Bosnia & Herzegovina Convertible Mark", Numeric: "977", Exponent: 2, Symbol: "KM", ZeroDecimal: false},
+	BBD: {Code: BBD, Name: "Barbadian Dollar", Numeric: "052", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	BDT: {Code: BDT, Name: "Bangladeshi Taka", Numeric: "050", Exponent: 2, Symbol: "৳", ZeroDecimal: false},
+	BGN: {Code: BGN, Name: "Bulgarian Lev", Numeric: "975", Exponent: 2, Symbol: "лв", ZeroDecimal: false},
+	BHD: {Code: BHD, Name: "Bahraini Dinar", Numeric: "048", Exponent: 3, Symbol: "BD", ZeroDecimal: false},
+	BIF: {Code: BIF, Name: "Burundian Franc", Numeric: "108", Exponent: 0, Symbol: "FBu", ZeroDecimal: true},
+	BMD: {Code: BMD, Name: "Bermudian Dollar", Numeric: "060", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	BND: {Code: BND, Name: "Brunei Dollar", Numeric: "096", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	BOB: {Code: BOB, Name: "Bolivian Boliviano", Numeric: "068", Exponent: 2, Symbol: "Bs.", ZeroDecimal: false},
+	BRL: {Code: BRL, Name: "Brazilian Real", Numeric: "986", Exponent: 2, Symbol: "R$", ZeroDecimal: false},
+	BSD: {Code: BSD, Name: "Bahamian Dollar", Numeric: "044", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	BWP: {Code: BWP, Name: "Botswana Pula", Numeric: "072", Exponent: 2, Symbol: "P", ZeroDecimal: false},
+	BZD: {Code: BZD, Name: "Belize Dollar", Numeric: "084", Exponent: 2, Symbol: "BZ$", ZeroDecimal: false},
+	CAD: {Code: CAD, Name: "Canadian Dollar", Numeric: "124", Exponent: 2, Symbol: "CA$", ZeroDecimal: false},
+	CDF: {Code: CDF, Name: "Congolese Franc", Numeric: "976", Exponent: 2, Symbol: "FC", ZeroDecimal: false},
+	CHF: {Code: CHF, Name: "Swiss Franc", Numeric: "756", Exponent: 2, Symbol: "CHF", ZeroDecimal: false},
+	CLP: {Code: CLP, Name: "Chilean Peso", Numeric: "152", Exponent: 0, Symbol: "$", ZeroDecimal: true},
+	CNY: {Code: CNY, Name: "Chinese Renminbi Yuan", Numeric: "156", Exponent: 2, Symbol: "CN¥", ZeroDecimal: false},
+	COP: {Code: COP, Name: "Colombian Peso", Numeric: "170", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	CRC: {Code: CRC, Name: "Costa Rican Colón", Numeric: "188", Exponent: 2, Symbol: "₡", ZeroDecimal: false},
+	CVE: {Code: CVE, Name: "Cape Verdean Escudo", Numeric: "132", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	CZK: {Code: CZK, Name: "Czech Koruna", Numeric: "203", Exponent: 2, Symbol: "Kč", ZeroDecimal: false},
+	DJF: {Code: DJF, Name: "Djiboutian Franc", Numeric: "262", Exponent: 0, Symbol: "Fdj", ZeroDecimal: true},
+	DKK: {Code: DKK, Name: "Danish Krone", Numeric: "208", Exponent: 2, Symbol: "kr", ZeroDecimal: false},
+	DOP: {Code: DOP, Name: "Dominican Peso", Numeric: "214", Exponent: 2, Symbol: "RD$", ZeroDecimal: false},
+	DZD: {Code: DZD, Name: "Algerian Dinar", Numeric: "012", Exponent: 2, Symbol: "DA", ZeroDecimal: false},
+	EEK: {Code: EEK, Name: "Estonian Kroon", Numeric: "233", Exponent: 2, Symbol: "kr", ZeroDecimal: false},
+	EGP: {Code: EGP, Name: "Egyptian Pound", Numeric: "818", Exponent: 2, Symbol: "E£", ZeroDecimal: false},
+	ETB: {Code: ETB, Name: "Ethiopian Birr", Numeric: "230", Exponent: 2, Symbol: "Br", ZeroDecimal: false},
+	EUR: {Code: EUR, Name: "Euro", Numeric: "978", Exponent: 2, Symbol: "€", ZeroDecimal: false},
+	FJD: {Code: FJD, Name: "Fijian Dollar", Numeric: "242", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	FKP: {Code: FKP, Name: "Falkland Islands Pound", Numeric: "238", Exponent: 2, Symbol: "£", ZeroDecimal: false},
+	GBP: {Code: GBP, Name: "British Pound", Numeric: "826", Exponent: 2, Symbol: "£", ZeroDecimal: false},
+	GEL: {Code: GEL, Name: "Georgian Lari", Numeric: "981", Exponent: 2, Symbol: "₾", ZeroDecimal: false},
+	GIP: {Code: GIP, Name: "Gibraltar Pound", Numeric: "292", Exponent: 2, Symbol: "£", ZeroDecimal: false},
+	GMD: {Code: GMD, Name: "Gambian Dalasi", Numeric: "270", Exponent: 2, Symbol: "D", ZeroDecimal: false},
+	GNF: {Code: GNF, Name: "Guinean Franc", Numeric: "324", Exponent: 0, Symbol: "FG", ZeroDecimal: true},
+	GTQ: {Code: GTQ, Name: "Guatemalan Quetzal", Numeric: "320", Exponent: 2, Symbol: "Q", ZeroDecimal: false},
+	GYD: {Code: GYD, Name: "Guyanese Dollar", Numeric: "328", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	HKD: {Code: HKD, Name: "Hong Kong Dollar", Numeric: "344", Exponent: 2, Symbol: "HK$", ZeroDecimal: false},
+	HNL: {Code: HNL, Name: "Honduran Lempira", Numeric: "340", Exponent: 2, Symbol: "L", ZeroDecimal: false},
+	HRK: {Code: HRK, Name: "Croatian Kuna", Numeric: "191", Exponent: 2, Symbol: "kn", ZeroDecimal: false},
+	HTG: {Code: HTG, Name: "Haitian Gourde", Numeric: "332", Exponent: 2, Symbol: "G", ZeroDecimal: false},
+	HUF: {Code: HUF, Name: "Hungarian Forint", Numeric: "348", Exponent: 2, Symbol: "Ft", ZeroDecimal: false},
+	IDR: {Code: IDR, Name: "Indonesian Rupiah", Numeric: "360", Exponent: 2, Symbol: "Rp", ZeroDecimal: false},
+	ILS: {Code: ILS, Name: "Israeli New Sheqel", Numeric: "376", Exponent: 2, Symbol: "₪", ZeroDecimal: false},
+	INR: {Code: INR, Name: "Indian Rupee", Numeric: "356", Exponent: 2, Symbol: "₹", ZeroDecimal: false},
+	ISK: {Code: ISK, Name: "Icelandic Króna", Numeric: "352", Exponent: 0, Symbol: "kr", ZeroDecimal: false},
+	JMD: {Code: JMD, Name: "Jamaican Dollar", Numeric: "388", Exponent: 2, Symbol: "J$", ZeroDecimal: false},
+	JOD: {Code: JOD, Name: "Jordanian Dinar", Numeric: "400", Exponent: 3, Symbol: "JD", ZeroDecimal: false},
+	JPY: {Code: JPY, Name: "Japanese Yen", Numeric: "392", Exponent: 0, Symbol: "¥", ZeroDecimal: true},
+	KES: {Code: KES, Name: "Kenyan Shilling", Numeric: "404", Exponent: 2, Symbol: "KSh", ZeroDecimal: false},
+	KGS: {Code: KGS, Name: "Kyrgyzstani Som", Numeric: "417", Exponent: 2, Symbol: "сом", ZeroDecimal: false},
+	KHR: {Code: KHR, Name: "Cambodian Riel", Numeric: "116", Exponent: 2, Symbol: "៛", ZeroDecimal: false},
+	KMF: {Code: KMF, Name: "Comorian Franc", Numeric: "174", Exponent: 0, Symbol: "CF", ZeroDecimal: true},
+	KRW: {Code: KRW, Name: "South Korean Won", Numeric: "410", Exponent: 0, Symbol: "₩", ZeroDecimal: true},
+	KWD: {Code: KWD, Name: "Kuwaiti Dinar", Numeric: "414", Exponent: 3, Symbol: "KD", ZeroDecimal: false},
+	KYD: {Code: KYD, Name: "Cayman Islands Dollar", Numeric: "136", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	KZT: {Code: KZT, Name: "Kazakhstani Tenge", Numeric: "398", Exponent: 2, Symbol: "₸", ZeroDecimal: false},
+	LAK: {Code: LAK, Name: "Lao Kip", Numeric: "418", Exponent: 2, Symbol: "₭", ZeroDecimal: false},
+	LBP: {Code: LBP, Name: "Lebanese Pound", Numeric: "422", Exponent: 2, Symbol: "L£", ZeroDecimal: false},
+	LKR: {Code: LKR, Name: "Sri Lankan Rupee", Numeric: "144", Exponent: 2, Symbol: "Rs", ZeroDecimal: false},
+	LRD: {Code: LRD, Name: "Liberian Dollar", Numeric: "430", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	LSL: {Code: LSL, Name: "Lesotho Loti", Numeric: "426", Exponent: 2, Symbol: "L", ZeroDecimal: false},
+	LTL: {Code: LTL, Name: "Lithuanian Litas", Numeric: "440", Exponent: 2, Symbol: "Lt", ZeroDecimal: false},
+	LVL: {Code: LVL, Name: "Latvian Lats", Numeric: "428", Exponent: 2, Symbol: "Ls", ZeroDecimal: false},
+	MAD: {Code: MAD, Name: "Moroccan Dirham", Numeric: "504", Exponent: 2, Symbol: "DH", ZeroDecimal: false},
+	MDL: {Code: MDL, Name: "Moldovan Leu", Numeric: "498", Exponent: 2, Symbol: "L", ZeroDecimal: false},
+	MGA: {Code: MGA, Name: "Malagasy Ariary", Numeric: "969", Exponent: 2, Symbol: "Ar", ZeroDecimal: true},
+	MKD: {Code: MKD, Name: "Macedonian Denar", Numeric: "807", Exponent: 2, Symbol: "ден", ZeroDecimal: false},
+	MNT: {Code: MNT, Name: "Mongolian Tögrög", Numeric: "496", Exponent: 2, Symbol: "₮", ZeroDecimal: false},
+	MOP: {Code: MOP, Name: "Macanese Pataca", Numeric: "446", Exponent: 2, Symbol: "MOP$", ZeroDecimal: false},
+	MRO: {Code: MRO, Name: "Mauritanian Ouguiya", Numeric: "478", Exponent: 2, Symbol: "UM", ZeroDecimal: false},
+	MUR: {Code: MUR, Name: "Mauritian Rupee", Numeric: "480", Exponent: 2, Symbol: "Rs", ZeroDecimal: false},
+	MVR: {Code: MVR, Name: "Maldivian Rufiyaa", Numeric: "462", Exponent: 2, Symbol: "Rf", ZeroDecimal: false},
+	MWK: {Code: MWK, Name: "Malawian Kwacha", Numeric: "454", Exponent: 2, Symbol: "MK", ZeroDecimal: false},
+	MXN: {Code: MXN, Name: "Mexican Peso", Numeric: "484", Exponent: 2, Symbol: "MX$", ZeroDecimal: false},
+	MYR: {Code: MYR, Name: "Malaysian Ringgit", Numeric: "458", Exponent: 2, Symbol: "RM", ZeroDecimal: false},
+	MZN: {Code: MZN, Name: "Mozambican Metical", Numeric: "943", Exponent: 2, Symbol: "MT", ZeroDecimal: false},
+	NAD: {Code: NAD, Name: "Namibian Dollar", Numeric: "516", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	NGN: {Code: NGN, Name: "Nigerian Naira", Numeric: "566", Exponent: 2, Symbol: "₦", ZeroDecimal: false},
+	NIO: {Code: NIO, Name: "Nicaraguan Córdoba", Numeric: "558", Exponent: 2, Symbol: "C$", ZeroDecimal: false},
+	NOK: {Code: NOK, Name: "Norwegian Krone", Numeric: "578", Exponent: 2, Symbol: "kr", ZeroDecimal: false},
+	NPR: {Code: NPR, Name: "Nepalese Rupee", Numeric: "524", Exponent: 2, Symbol: "Rs", ZeroDecimal: false},
+	NZD: {Code: NZD, Name: "New Zealand Dollar", Numeric: "554", Exponent: 2, Symbol: "NZ$", ZeroDecimal: false},
+	OMR: {Code: OMR, Name: "Omani Rial", Numeric: "512", Exponent: 3, Symbol: "RO", ZeroDecimal: false},
+	PAB: {Code: PAB, Name: "Panamanian Balboa", Numeric: "590", Exponent: 2, Symbol: "B/.", ZeroDecimal: false},
+	PEN: {Code: PEN, Name: "Peruvian Nuevo Sol", Numeric: "604", Exponent: 2, Symbol: "S/", ZeroDecimal: false},
+	PGK: {Code: PGK, Name: "Papua New Guinean Kina", Numeric: "598", Exponent: 2, Symbol: "K", ZeroDecimal: false},
+	PHP: {Code: PHP, Name: "Philippine Peso", Numeric: "608", Exponent: 2, Symbol: "₱", ZeroDecimal: false},
+	PKR: {Code: PKR, Name: "Pakistani Rupee", Numeric: "586", Exponent: 2, Symbol: "Rs", ZeroDecimal: false},
+	PLN: {Code: PLN, Name: "Polish Złoty", Numeric: "985", Exponent: 2, Symbol: "zł", ZeroDecimal: false},
+	PYG: {Code: PYG, Name: "Paraguayan Guaraní", Numeric: "600", Exponent: 0, Symbol: "₲", ZeroDecimal: true},
+	QAR: {Code: QAR, Name: "Qatari Riyal", Numeric: "634", Exponent: 2, Symbol: "QR", ZeroDecimal: false},
+	RON: {Code: RON, Name: "Romanian Leu", Numeric: "946", Exponent: 2, Symbol: "lei", ZeroDecimal: false},
+	RSD: {Code: RSD, Name: "Serbian Dinar", Numeric: "941", Exponent: 2, Symbol: "din.", ZeroDecimal: false},
+	RUB: {Code: RUB, Name: "Russian Ruble", Numeric: "643", Exponent: 2, Symbol: "₽", ZeroDecimal: false},
+	RWF: {Code: RWF, Name: "Rwandan Franc", Numeric: "646", Exponent: 0, Symbol: "RF", ZeroDecimal: true},
+	SAR: {Code: SAR, Name: "Saudi Riyal", Numeric: "682", Exponent: 2, Symbol: "SR", ZeroDecimal: false},
+	SBD: {Code: SBD, Name: "Solomon Islands Dollar", Numeric: "090", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	SCR: {Code: SCR, Name: "Seychellois Rupee", Numeric: "690", Exponent: 2, Symbol: "Rs", ZeroDecimal: false},
+	SEK: {Code: SEK, Name: "Swedish Krona", Numeric: "752", Exponent: 2, Symbol: "kr", ZeroDecimal: false},
+	SGD: {Code: SGD, Name: "Singapore Dollar", Numeric: "702", Exponent: 2, Symbol: "S$", ZeroDecimal: false},
+	SHP: {Code: SHP, Name: "Saint Helenian Pound", Numeric: "654", Exponent: 2, Symbol: "£", ZeroDecimal: false},
+	SLL: {Code: SLL, Name: "Sierra Leonean Leone", Numeric: "694", Exponent: 2, Symbol: "Le", ZeroDecimal: false},
+	SOS: {Code: SOS, Name: "Somali Shilling", Numeric: "706", Exponent: 2, Symbol: "Sh", ZeroDecimal: false},
+	SRD: {Code: SRD, Name: "Surinamese Dollar", Numeric: "968", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	STD: {Code: STD, Name: "São Tomé and Príncipe Dobra", Numeric: "678", Exponent: 2, Symbol: "Db", ZeroDecimal: false},
+	SVC: {Code: SVC, Name: "Salvadoran Colón", Numeric: "222", Exponent: 2, Symbol: "₡", ZeroDecimal: false},
+	SZL: {Code: SZL, Name: "Swazi Lilangeni", Numeric: "748", Exponent: 2, Symbol: "E", ZeroDecimal: false},
+	THB: {Code: THB, Name: "Thai Baht", Numeric: "764", Exponent: 2, Symbol: "฿", ZeroDecimal: false},
+	TJS: {Code: TJS, Name: "Tajikistani Somoni", Numeric: "972", Exponent: 2, Symbol: "SM", ZeroDecimal: false},
+	TND: {Code: TND, Name: "Tunisian Dinar", Numeric: "788", Exponent: 3, Symbol: "DT", ZeroDecimal: false},
+	TOP: {Code: TOP, Name: "Tongan Paʻanga", Numeric: "776", Exponent: 2, Symbol: "T$", ZeroDecimal: false},
+	TRY: {Code: TRY, Name: "Turkish Lira", Numeric: "949", Exponent: 2, Symbol: "₺", ZeroDecimal: false},
+	TTD: {Code: TTD, Name: "Trinidad and Tobago Dollar", Numeric: "780", Exponent: 2, Symbol: "TT$", ZeroDecimal: false},
+	TWD: {Code: TWD, Name: "New Taiwan Dollar", Numeric: "901", Exponent: 2, Symbol: "NT$", ZeroDecimal: false},
+	TZS: {Code: TZS, Name: "Tanzanian Shilling", Numeric: "834", Exponent: 2, Symbol: "TSh", ZeroDecimal: false},
+	UAH: {Code: UAH, Name: "Ukrainian Hryvnia", Numeric: "980", Exponent: 2, Symbol: "₴", ZeroDecimal: false},
+	UGX: {Code: UGX, Name: "Ugandan Shilling", Numeric: "800", Exponent: 0, Symbol: "USh", ZeroDecimal: true},
+	USD: {Code: USD, Name: "United States Dollar", Numeric: "840", Exponent: 2, Symbol: "$", ZeroDecimal: false},
+	UYU: {Code: UYU, Name: "Uruguayan Peso", Numeric: "858", Exponent: 2, Symbol: "$U", ZeroDecimal: false},
+	UZS: {Code: UZS, Name: "Uzbekistani Som", Numeric: "860", Exponent: 2, Symbol: "soʻm", ZeroDecimal: false},
+	VEF: {Code: VEF, Name: "Venezuelan Bolívar", Numeric: "937", Exponent: 2, Symbol: "Bs.", ZeroDecimal: false},
+	VND: {Code: VND, Name: "Vietnamese Đồng", Numeric: "704", Exponent: 0, Symbol: "₫", ZeroDecimal: true},
+	VUV: {Code: VUV, Name: "Vanuatu Vatu", Numeric: "548", Exponent: 0, Symbol: "VT", ZeroDecimal: true},
+	WST: {Code: WST, Name: "Samoan Tala", Numeric: "882", Exponent: 2, Symbol: "WS$", ZeroDecimal: false},
+	XAF: {Code: XAF, Name: "Central African Cfa Franc", Numeric: "950", Exponent: 0, Symbol: "FCFA", ZeroDecimal: true},
+	XCD: {Code: XCD, Name: "East Caribbean Dollar", Numeric: "951", Exponent: 2, Symbol: "EC$", ZeroDecimal: false},
+	XOF: {Code: XOF, Name: "West African Cfa Franc", Numeric: "952", Exponent: 0, Symbol: "CFA", ZeroDecimal: true},
+	XPF: {Code: XPF, Name: "Cfp Franc", Numeric: "953", Exponent: 0, Symbol: "CFPF", ZeroDecimal: true},
+	YER: {Code: YER, Name: "Yemeni Rial", Numeric: "886", Exponent: 2, Symbol: "YR", ZeroDecimal: false},
+	ZAR: {Code: ZAR, Name: "South African Rand", Numeric: "710", Exponent: 2, Symbol: "R", ZeroDecimal: false},
+	ZMW: {Code: ZMW, Name: "Zambian Kwacha", Numeric: "967", Exponent: 2, Symbol: "ZK", ZeroDecimal: false},
+}
@@ -8,11 +8,11 @@ import (
 // For more details see https://stripe.com/docs/api#create_customer and https://stripe.com/docs/api#update_customer.
 type CustomerParams struct {
 	Params         `form:"*"`
-	Balance        int64                    `form:"account_balance"`
-	BalanceZero    bool                     `form:"account_balance,zero"`
+	Balance        *int64                   `form:"account_balance"`
+	BalanceZero    bool                     `form:"account_balance,zero"` // Deprecated: use Balance
 	BusinessVatID  string                   `form:"business_vat_id"`
-	Coupon         string                   `form:"coupon"`
-	CouponEmpty    bool                     `form:"coupon,empty"`
+	Coupon         *string                  `form:"coupon"`
+	CouponEmpty    bool                     `form:"coupon,empty"` // Deprecated: use Coupon
 	DefaultSource  string                   `form:"default_source"`
 	Desc           string                   `form:"description"`
 	Email          string                   `form:"email"`
@@ -20,10 +20,10 @@ type CustomerParams struct {
 	Quantity       uint64                   `form:"quantity"`
 	Shipping       *CustomerShippingDetails `form:"shipping"`
 	Source         *SourceParams            `form:"*"` // SourceParams has custom encoding so brought to top level with "*"
-	TaxPercent     float64                  `form:"tax_percent"`
-	TaxPercentZero bool                     `form:"tax_percent,zero"`
-	Token          string                   `form:"-"` // This doesn't seem to be used?
-	TrialEnd       int64                    `form:"trial_end"`
+	TaxPercent     *float64                 `form:"tax_percent"`
+	TaxPercentZero bool                     `form:"tax_percent,zero"` // Deprecated: use TaxPercent
+	Token          string                   `form:"-"`                // This doesn't seem to be used?
+	TrialEnd       *int64                   `form:"trial_end"`
 }
 
 // SetSource adds valid sources to a CustomerParams object,
@@ -45,29 +45,26 @@ type CustomerListParams struct {
 // Customer is the resource representing a Stripe customer.
 // For more details see https://stripe.com/docs/api#customers.
 type Customer struct {
-	Balance       int64                    `json:"account_balance"`
-	BusinessVatID string                   `json:"business_vat_id"`
-	Currency      Currency                 `json:"currency"`
-	Created       int64                    `json:"created"`
-	DefaultSource *PaymentSource           `json:"default_source"`
-	Deleted       bool                     `json:"deleted"`
-	Delinquent    bool                     `json:"delinquent"`
-	Desc          string                   `json:"description"`
-	Discount      *Discount                `json:"discount"`
-	Email         string                   `json:"email"`
-	ID            string                   `json:"id"`
-	Live          bool                     `json:"livemode"`
-	Meta          map[string]string        `json:"metadata"`
-	Shipping      *CustomerShippingDetails `json:"shipping"`
-	Sources       *SourceList              `json:"sources"`
-	Subs          *SubList                 `json:"subscriptions"`
+	Balance       int64                      `json:"account_balance"`
+	BusinessVatID string                     `json:"business_vat_id"`
+	Currency      Currency                   `json:"currency"`
+	Created       int64                      `json:"created"`
+	DefaultSource *Expandable[PaymentSource] `json:"default_source"`
+	Deleted       bool                       `json:"deleted"`
+	Delinquent    bool                       `json:"delinquent"`
+	Desc          string                     `json:"description"`
+	Discount      *Discount                  `json:"discount"`
+	Email         string                     `json:"email"`
+	ID            string                     `json:"id"`
+	Live          bool                       `json:"livemode"`
+	Meta          map[string]string          `json:"metadata"`
+	Shipping      *CustomerShippingDetails   `json:"shipping"`
+	Sources       *SourceList                `json:"sources"`
+	Subs          *SubList                   `json:"subscriptions"`
 }
 
 // CustomerList is a list of customers as retrieved from a list endpoint.
-type CustomerList struct {
-	ListMeta
-	Values []*Customer `json:"data"`
-}
+type CustomerList = List[*Customer]
 
 // CustomerShippingDetails is the structure containing shipping information.
 type CustomerShippingDetails struct {
@@ -80,15 +77,18 @@ type CustomerShippingDetails struct {
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (c *Customer) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		c.ID = id
+		return nil
+	}
+
 	type customer Customer
 	var cc customer
 	err := json.Unmarshal(data, &cc)
-	if err == nil {
-		*c = Customer(cc)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		c.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*c = Customer(cc)
 	return nil
 }
@@ -21,6 +21,10 @@ func Del(customerID string, params *stripe.DiscountParams) (*stripe.Discount, er
 }
 
 func (c Client) Del(customerID string, params *stripe.DiscountParams) (*stripe.Discount, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -43,6 +47,10 @@ func DelSub(subscriptionID string, params *stripe.DiscountParams) (*stripe.Disco
 }
 
 func (c Client) DelSub(subscriptionID string, params *stripe.DiscountParams) (*stripe.Discount, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
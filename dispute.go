@@ -21,7 +21,8 @@ type DisputeStatus string
 type DisputeParams struct {
 	Params   `form:"*"`
 	Evidence *DisputeEvidenceParams `form:"evidence"`
-	NoSubmit bool                   `form:"submit,invert"`
+	Submit   *bool                  `form:"submit"`
+	NoSubmit bool                   `form:"submit,invert"` // Deprecated: use Submit
 }
 
 // DisputeEvidenceParams is the set of parameters that can be used when submitting
@@ -82,12 +83,14 @@ type Dispute struct {
 	Transactions    []*Transaction    `json:"balance_transactions"`
 }
 
-// DisputeList is a list of disputes as retrieved from a list endpoint.
-type DisputeList struct {
-	ListMeta
-	Values []*Dispute `json:"data"`
+// AmountMoney returns the amount disputed in its currency.
+func (t *Dispute) AmountMoney() Money {
+	return moneyFromUint64(t.Amount, t.Currency)
 }
 
+// DisputeList is a list of disputes as retrieved from a list endpoint.
+type DisputeList = List[*Dispute]
+
 // EvidenceDetails is the structure representing more details about
 // the dispute.
 type EvidenceDetails struct {
@@ -102,33 +105,33 @@ type EvidenceDetails struct {
 // Almost all fields are strings since there structures (i.e. address)
 // do not typically get parsed by anyone and are thus presented as-received.
 type DisputeEvidence struct {
-	ActivityLog                  string `json:"access_activity_log"`
-	BillingAddress               string `json:"billing_address"`
-	CancellationPolicy           *File  `json:"cancellation_policy"`
-	CancellationPolicyDisclosure string `json:"cancellation_policy_disclosure"`
-	CancellationRebuttal         string `json:"cancellation_rebuttal"`
-	CustomerComm                 *File  `json:"customer_communication"`
-	CustomerEmail                string `json:"customer_email_address"`
-	CustomerIP                   string `json:"customer_purchase_ip"`
-	CustomerName                 string `json:"customer_name"`
-	CustomerSig                  *File  `json:"customer_signature"`
-	DuplicateCharge              string `json:"duplicate_charge_id"`
-	DuplicateChargeDoc           *File  `json:"duplicate_charge_documentation"`
-	DuplicateChargeReason        string `json:"duplicate_charge_explanation"`
-	ProductDesc                  string `json:"product_description"`
-	Receipt                      *File  `json:"receipt"`
-	RefundPolicy                 *File  `json:"refund_policy"`
-	RefundPolicyDisclosure       string `json:"refund_policy_disclosure"`
-	RefundRefusalReason          string `json:"refund_refusal_explanation"`
-	ServiceDate                  string `json:"service_date"`
-	ServiceDoc                   *File  `json:"service_documentation"`
-	ShippingAddress              string `json:"shipping_address"`
-	ShippingCarrier              string `json:"shipping_carrier"`
-	ShippingDate                 string `json:"shipping_date"`
-	ShippingDoc                  *File  `json:"shipping_documentation"`
-	ShippingTracking             string `json:"shipping_tracking_number"`
-	UncategorizedFile            *File  `json:"uncategorized_file"`
-	UncategorizedText            string `json:"uncategorized_text"`
+	ActivityLog                  string            `json:"access_activity_log"`
+	BillingAddress               string            `json:"billing_address"`
+	CancellationPolicy           *Expandable[File] `json:"cancellation_policy"`
+	CancellationPolicyDisclosure string            `json:"cancellation_policy_disclosure"`
+	CancellationRebuttal         string            `json:"cancellation_rebuttal"`
+	CustomerComm                 *Expandable[File] `json:"customer_communication"`
+	CustomerEmail                string            `json:"customer_email_address"`
+	CustomerIP                   string            `json:"customer_purchase_ip"`
+	CustomerName                 string            `json:"customer_name"`
+	CustomerSig                  *Expandable[File] `json:"customer_signature"`
+	DuplicateCharge              string            `json:"duplicate_charge_id"`
+	DuplicateChargeDoc           *Expandable[File] `json:"duplicate_charge_documentation"`
+	DuplicateChargeReason        string            `json:"duplicate_charge_explanation"`
+	ProductDesc                  string            `json:"product_description"`
+	Receipt                      *Expandable[File] `json:"receipt"`
+	RefundPolicy                 *Expandable[File] `json:"refund_policy"`
+	RefundPolicyDisclosure       string            `json:"refund_policy_disclosure"`
+	RefundRefusalReason          string            `json:"refund_refusal_explanation"`
+	ServiceDate                  string            `json:"service_date"`
+	ServiceDoc                   *Expandable[File] `json:"service_documentation"`
+	ShippingAddress              string            `json:"shipping_address"`
+	ShippingCarrier              string            `json:"shipping_carrier"`
+	ShippingDate                 string            `json:"shipping_date"`
+	ShippingDoc                  *Expandable[File] `json:"shipping_documentation"`
+	ShippingTracking             string            `json:"shipping_tracking_number"`
+	UncategorizedFile            *Expandable[File] `json:"uncategorized_file"`
+	UncategorizedText            string            `json:"uncategorized_text"`
 }
 
 // File represents a link to downloadable content.
@@ -145,16 +148,19 @@ type File struct {
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (t *Dispute) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		t.ID = id
+		return nil
+	}
+
 	type dispute Dispute
 	var dd dispute
 	err := json.Unmarshal(data, &dd)
-	if err == nil {
-		*t = Dispute(dd)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		t.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*t = Dispute(dd)
 	return nil
 }
 
@@ -162,15 +168,18 @@ func (t *Dispute) UnmarshalJSON(data []byte) error {
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (f *File) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		f.ID = id
+		return nil
+	}
+
 	type file File
 	var ff file
 	err := json.Unmarshal(data, &ff)
-	if err == nil {
-		*f = File(ff)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		f.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*f = File(ff)
 	return nil
 }
@@ -24,6 +24,10 @@ func New(params *stripe.EphemeralKeyParams) (*stripe.EphemeralKey, error) {
 // New POSTs new ephemeral keys.
 // For more details see https://stripe.com/docs/api#create_ephemeral_key.
 func (c Client) New(params *stripe.EphemeralKeyParams) (*stripe.EphemeralKey, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	if params.StripeVersion == "" {
 		return nil, fmt.Errorf("params.StripeVersion must be specified")
 	}
@@ -53,6 +57,10 @@ func Del(id string, params *stripe.EphemeralKeyParams) (*stripe.EphemeralKey, er
 // Del removes an ephemeral key.
 // For more details see https://stripe.com/docs/api#delete_ephemeral_key.
 func (c Client) Del(id string, params *stripe.EphemeralKeyParams) (*stripe.EphemeralKey, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
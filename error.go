@@ -1,6 +1,17 @@
 package stripe
 
-import "encoding/json"
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
 
 // ErrorType is the list of allowed values for the error's type.
 type ErrorType string
@@ -8,6 +19,11 @@ type ErrorType string
 // ErrorCode is the list of allowed values for the error's code.
 type ErrorCode string
 
+// DeclineCode is the list of allowed values for the decline code of a card
+// error, which is the reason given by the card issuer for declining a
+// charge. For more details see https://stripe.com/docs/declines/codes.
+type DeclineCode string
+
 const (
 	ErrorTypeAPI            ErrorType = "api_error"
 	ErrorTypeAPIConnection  ErrorType = "api_connection_error"
@@ -30,6 +46,14 @@ const (
 	ProcessingErr ErrorCode = "processing_error"
 	RateLimit     ErrorCode = "rate_limit"
 
+	AmountTooLarge        ErrorCode = "amount_too_large"
+	ChargeAlreadyCaptured ErrorCode = "charge_already_captured"
+	ChargeAlreadyRefunded ErrorCode = "charge_already_refunded"
+	IdempotencyKeyInUse   ErrorCode = "idempotency_key_in_use"
+	LockTimeout           ErrorCode = "lock_timeout"
+	ResourceAlreadyExists ErrorCode = "resource_already_exists"
+	ResourceMissing       ErrorCode = "resource_missing"
+
 	// These additional types are written purely for backward compatibility
 	// (the originals were given quite unsuitable names) and should be
 	// considered deprecated. Remove them on the next major version revision.
@@ -39,34 +63,238 @@ const (
 	InvalidRequest ErrorType = ErrorTypeInvalidRequest
 )
 
+const (
+	DeclineCodeApproveWithID                  DeclineCode = "approve_with_id"
+	DeclineCodeCallIssuer                     DeclineCode = "call_issuer"
+	DeclineCodeCardNotSupported               DeclineCode = "card_not_supported"
+	DeclineCodeCardVelocityExceeded           DeclineCode = "card_velocity_exceeded"
+	DeclineCodeCurrencyNotSupported           DeclineCode = "currency_not_supported"
+	DeclineCodeDoNotHonor                     DeclineCode = "do_not_honor"
+	DeclineCodeDoNotTryAgain                  DeclineCode = "do_not_try_again"
+	DeclineCodeDuplicateTransaction           DeclineCode = "duplicate_transaction"
+	DeclineCodeExpiredCard                    DeclineCode = "expired_card"
+	DeclineCodeFraudulent                     DeclineCode = "fraudulent"
+	DeclineCodeGenericDecline                 DeclineCode = "generic_decline"
+	DeclineCodeIncorrectCVC                   DeclineCode = "incorrect_cvc"
+	DeclineCodeIncorrectNumber                DeclineCode = "incorrect_number"
+	DeclineCodeIncorrectPIN                   DeclineCode = "incorrect_pin"
+	DeclineCodeIncorrectZip                   DeclineCode = "incorrect_zip"
+	DeclineCodeInsufficientFunds              DeclineCode = "insufficient_funds"
+	DeclineCodeInvalidAccount                 DeclineCode = "invalid_account"
+	DeclineCodeInvalidAmount                  DeclineCode = "invalid_amount"
+	DeclineCodeInvalidCVC                     DeclineCode = "invalid_cvc"
+	DeclineCodeInvalidExpiryYear              DeclineCode = "invalid_expiry_year"
+	DeclineCodeInvalidNumber                  DeclineCode = "invalid_number"
+	DeclineCodeInvalidPIN                     DeclineCode = "invalid_pin"
+	DeclineCodeIssuerNotAvailable             DeclineCode = "issuer_not_available"
+	DeclineCodeLostCard                       DeclineCode = "lost_card"
+	DeclineCodeMerchantBlacklist              DeclineCode = "merchant_blacklist"
+	DeclineCodeNewAccountInformationAvailable DeclineCode = "new_account_information_available"
+	DeclineCodeNoActionTaken                  DeclineCode = "no_action_taken"
+	DeclineCodeNotPermitted                   DeclineCode = "not_permitted"
+	DeclineCodePickupCard                     DeclineCode = "pickup_card"
+	DeclineCodePINTryExceeded                 DeclineCode = "pin_try_exceeded"
+	DeclineCodeProcessingError                DeclineCode = "processing_error"
+	DeclineCodeReenterTransaction             DeclineCode = "reenter_transaction"
+	DeclineCodeRestrictedCard                 DeclineCode = "restricted_card"
+	DeclineCodeRevocationOfAllAuthorizations  DeclineCode = "revocation_of_all_authorizations"
+	DeclineCodeRevocationOfAuthorization      DeclineCode = "revocation_of_authorization"
+	DeclineCodeSecurityViolation              DeclineCode = "security_violation"
+	DeclineCodeServiceNotAllowed              DeclineCode = "service_not_allowed"
+	DeclineCodeStolenCard                     DeclineCode = "stolen_card"
+	DeclineCodeStopPaymentOrder               DeclineCode = "stop_payment_order"
+	DeclineCodeTestModeDecline                DeclineCode = "testmode_decline"
+	DeclineCodeTransactionNotAllowed          DeclineCode = "transaction_not_allowed"
+	DeclineCodeTryAgainLater                  DeclineCode = "try_again_later"
+	DeclineCodeWithdrawalCountLimitExceeded   DeclineCode = "withdrawal_count_limit_exceeded"
+)
+
 // Error is the response returned when a call is unsuccessful.
 // For more details see  https://stripe.com/docs/api#errors.
 type Error struct {
 	ChargeID string    `json:"charge,omitempty"`
 	Code     ErrorCode `json:"code,omitempty"`
 
+	// DeclineCode is the reason given by the card issuer for declining a
+	// charge. It's only set on card errors.
+	DeclineCode DeclineCode `json:"decline_code,omitempty"`
+
+	// DocURL links to the documentation of the error's code.
+	DocURL string `json:"doc_url,omitempty"`
+
 	// Err contains an internal error with an additional level of granularity
 	// that can be used in some cases to get more detailed information about
 	// what went wrong. For example, Err may hold a ChargeError that indicates
 	// exactly what went wrong during a charge.
 	Err error `json:"-"`
 
-	HTTPStatusCode int       `json:"status,omitempty"`
-	Msg            string    `json:"message"`
-	Param          string    `json:"param,omitempty"`
-	RequestID      string    `json:"request_id,omitempty"`
-	Type           ErrorType `json:"type"`
+	HTTPStatusCode int    `json:"status,omitempty"`
+	Msg            string `json:"message"`
+	Param          string `json:"param,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+
+	// Source is the payment source involved in the error, for example the
+	// card that was declined.
+	Source *PaymentSource `json:"source,omitempty"`
+
+	Type ErrorType `json:"type"`
 }
 
-// Error serializes the error object to JSON and returns it as a string.
+// Error serializes the error object to JSON and returns it as a string. The
+// payment source is left out, so that the details of a card don't end up
+// wherever the error is logged.
 func (e *Error) Error() string {
-	ret, _ := json.Marshal(e)
+	withoutSource := *e
+	withoutSource.Source = nil
+	ret, _ := json.Marshal(&withoutSource)
 	return string(ret)
 }
 
+// decodeError decodes the error object of a response field by field, so
+// that a field of an unexpected type, like a source the library can't
+// decode, leaves that field empty instead of losing the whole error. It only
+// fails if data isn't an object.
+func decodeError(data []byte) (*Error, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, errors.New("Error object is null")
+	}
+
+	e := &Error{}
+	decode := func(name string, v interface{}) {
+		if raw, ok := fields[name]; ok {
+			// Errors are ignored on purpose: the field is left empty.
+			json.Unmarshal(raw, v)
+		}
+	}
+	decode("charge", &e.ChargeID)
+	decode("code", &e.Code)
+	decode("decline_code", &e.DeclineCode)
+	decode("doc_url", &e.DocURL)
+	decode("message", &e.Msg)
+	decode("param", &e.Param)
+	decode("type", &e.Type)
+
+	if raw, ok := fields["source"]; ok {
+		source := &PaymentSource{}
+		if err := json.Unmarshal(raw, source); err == nil {
+			e.Source = source
+		}
+	}
+
+	return e, nil
+}
+
+// Unwrap returns the type specific error held in Err, so that errors.As can
+// be used to get, for example, a *CardError out of an error returned by the
+// library.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether e matches target, which must be an *Error whose non
+// empty Type, Code and DeclineCode are the same as e's. It lets errors.Is be
+// used to check for a kind of error:
+//
+//	if errors.Is(err, &stripe.Error{Code: stripe.CardDeclined}) {
+//		...
+//	}
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t == e {
+		return true
+	}
+	if t.Type == "" && t.Code == "" && t.DeclineCode == "" {
+		return false
+	}
+	return (t.Type == "" || t.Type == e.Type) &&
+		(t.Code == "" || t.Code == e.Code) &&
+		(t.DeclineCode == "" || t.DeclineCode == e.DeclineCode)
+}
+
+// IsRetryable returns whether err, as returned by the library, is likely to
+// be transient so that the request that caused it may succeed if it's tried
+// again: connection failures, conflicts, rate limiting and server errors.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Client timeouts are wrapped in an APIConnectionError, unlike errors
+	// caused by the caller giving up.
+	var stripeErr *Error
+	if errors.As(err, &stripeErr) {
+		switch {
+		case stripeErr.Type == ErrorTypeAPIConnection:
+			return true
+		case stripeErr.Code == LockTimeout:
+			return true
+		case stripeErr.HTTPStatusCode == http.StatusConflict:
+			return true
+		case stripeErr.HTTPStatusCode == http.StatusTooManyRequests:
+			return true
+		case stripeErr.HTTPStatusCode >= http.StatusInternalServerError:
+			return true
+		}
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsCardDecline returns whether err, as returned by the library, is a card
+// being declined, in which case the reason is usually given by the error's
+// DeclineCode.
+func IsCardDecline(err error) bool {
+	var cardErr *CardError
+	if !errors.As(err, &cardErr) {
+		return false
+	}
+	return cardErr.stripeErr.Code == CardDeclined || cardErr.DeclineCode != ""
+}
+
+// ConnectionErrorCategory is the list of allowed values for the category of
+// an APIConnectionError.
+type ConnectionErrorCategory string
+
+const (
+	ConnectionErrorCategoryConnect  ConnectionErrorCategory = "connect"
+	ConnectionErrorCategoryDNS      ConnectionErrorCategory = "dns"
+	ConnectionErrorCategoryOther    ConnectionErrorCategory = "other"
+	ConnectionErrorCategoryReadBody ConnectionErrorCategory = "read_body"
+	ConnectionErrorCategoryReset    ConnectionErrorCategory = "reset"
+	ConnectionErrorCategoryTimeout  ConnectionErrorCategory = "timeout"
+	ConnectionErrorCategoryTLS      ConnectionErrorCategory = "tls"
+)
+
 // APIConnectionError is a failure to connect to the Stripe API.
+//
+// It's either reported by Stripe, or produced by the library when a request
+// fails at the network level, in which case Category tells what went wrong
+// and Err holds the error returned by the HTTP client.
 type APIConnectionError struct {
 	stripeErr *Error
+
+	Category ConnectionErrorCategory
+	Err      error
+
+	// MayHaveReachedStripe is false when the request is known not to have
+	// been sent, for example because the host couldn't be resolved, so that
+	// it's safe to retry. When it's true, Stripe may have acted on the
+	// request and its outcome should be checked before making it again,
+	// unless it's idempotent.
+	MayHaveReachedStripe bool
 }
 
 // Error serializes the error object to JSON and returns it as a string.
@@ -74,6 +302,76 @@ func (e *APIConnectionError) Error() string {
 	return e.stripeErr.Error()
 }
 
+// Unwrap returns the error returned by the HTTP client, if any.
+func (e *APIConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// newConnectionError wraps an error returned by the HTTP client in an *Error
+// holding an APIConnectionError. readingBody tells whether it happened while
+// reading a response, after the request reached Stripe.
+func newConnectionError(err error, readingBody bool) *Error {
+	stripeErr := &Error{
+		Type: ErrorTypeAPIConnection,
+		Msg:  err.Error(),
+	}
+
+	connErr := &APIConnectionError{
+		stripeErr: stripeErr,
+		Err:       err,
+	}
+	stripeErr.Err = connErr
+
+	if readingBody {
+		connErr.Category = ConnectionErrorCategoryReadBody
+		connErr.MayHaveReachedStripe = true
+		return stripeErr
+	}
+
+	// Nothing is sent before the connection is established.
+	var opErr *net.OpError
+	dialing := errors.As(err, &opErr) && opErr.Op == "dial"
+
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &dnsErr):
+		connErr.Category = ConnectionErrorCategoryDNS
+	case isTLSError(err):
+		connErr.Category = ConnectionErrorCategoryTLS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		connErr.Category = ConnectionErrorCategoryTimeout
+		connErr.MayHaveReachedStripe = !dialing
+	case dialing:
+		connErr.Category = ConnectionErrorCategoryConnect
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE),
+		errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		connErr.Category = ConnectionErrorCategoryReset
+		connErr.MayHaveReachedStripe = true
+	default:
+		connErr.Category = ConnectionErrorCategoryOther
+		connErr.MayHaveReachedStripe = true
+	}
+
+	return stripeErr
+}
+
+// isTLSError returns whether err happened while establishing a TLS session,
+// before anything was sent.
+func isTLSError(err error) bool {
+	var recordErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var verificationErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+
+	return errors.As(err, &recordErr) || errors.As(err, &alertErr) ||
+		errors.As(err, &verificationErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) ||
+		strings.Contains(err.Error(), "tls: ") || strings.Contains(err.Error(), "TLS handshake")
+}
+
 // APIError is a catch all for any errors not covered by other types (and
 // should be extremely uncommon).
 type APIError struct {
@@ -85,6 +383,12 @@ func (e *APIError) Error() string {
 	return e.stripeErr.Error()
 }
 
+// Unwrap returns the *Error holding the details of the error, so that
+// errors.As can be used to get it from the APIError.
+func (e *APIError) Unwrap() error {
+	return unwrapStripeErr(e.stripeErr)
+}
+
 // AuthenticationError is a failure to properly authenticate during a request.
 type AuthenticationError struct {
 	stripeErr *Error
@@ -95,6 +399,12 @@ func (e *AuthenticationError) Error() string {
 	return e.stripeErr.Error()
 }
 
+// Unwrap returns the *Error holding the details of the error, so that
+// errors.As can be used to get it from the AuthenticationError.
+func (e *AuthenticationError) Unwrap() error {
+	return unwrapStripeErr(e.stripeErr)
+}
+
 // PermissionError results when you attempt to make an API request
 // for which your API key doesn't have the right permissions.
 type PermissionError struct {
@@ -106,12 +416,18 @@ func (e *PermissionError) Error() string {
 	return e.stripeErr.Error()
 }
 
+// Unwrap returns the *Error holding the details of the error, so that
+// errors.As can be used to get it from the PermissionError.
+func (e *PermissionError) Unwrap() error {
+	return unwrapStripeErr(e.stripeErr)
+}
+
 // CardError are the most common type of error you should expect to handle.
 // They result when the user enters a card that can't be charged for some
 // reason.
 type CardError struct {
 	stripeErr   *Error
-	DeclineCode string `json:"decline_code,omitempty"`
+	DeclineCode DeclineCode `json:"decline_code,omitempty"`
 }
 
 // Error serializes the error object to JSON and returns it as a string.
@@ -119,6 +435,12 @@ func (e *CardError) Error() string {
 	return e.stripeErr.Error()
 }
 
+// Unwrap returns the *Error holding the details of the error, so that
+// errors.As can be used to get it from the CardError.
+func (e *CardError) Unwrap() error {
+	return unwrapStripeErr(e.stripeErr)
+}
+
 // InvalidRequestError is an error that occurs when a request contains invalid
 // parameters.
 type InvalidRequestError struct {
@@ -130,6 +452,12 @@ func (e *InvalidRequestError) Error() string {
 	return e.stripeErr.Error()
 }
 
+// Unwrap returns the *Error holding the details of the error, so that
+// errors.As can be used to get it from the InvalidRequestError.
+func (e *InvalidRequestError) Unwrap() error {
+	return unwrapStripeErr(e.stripeErr)
+}
+
 // RateLimitError occurs when the Stripe API is hit to with too many requests
 // too quickly and indicates that the current request has been rate limited.
 type RateLimitError struct {
@@ -140,3 +468,21 @@ type RateLimitError struct {
 func (e *RateLimitError) Error() string {
 	return e.stripeErr.Error()
 }
+
+// Unwrap returns the *Error holding the details of the error, so that
+// errors.As can be used to get it from the RateLimitError.
+func (e *RateLimitError) Unwrap() error {
+	return unwrapStripeErr(e.stripeErr)
+}
+
+// unwrapStripeErr returns the *Error a type specific error is unwrapped to.
+// It's a copy without Err, as *Error unwraps to the type specific error, and
+// errors.Is and errors.As would otherwise loop between the two forever.
+func unwrapStripeErr(stripeErr *Error) error {
+	if stripeErr == nil {
+		return nil
+	}
+	unwrapped := *stripeErr
+	unwrapped.Err = nil
+	return &unwrapped
+}
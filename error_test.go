@@ -1,10 +1,17 @@
 package stripe
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	assert "github.com/stretchr/testify/require"
 )
@@ -23,9 +30,9 @@ func TestErrorResponse(t *testing.T) {
 	defer ts.Close()
 
 	SetBackend("api", &BackendConfiguration{
-		APIBackend,
-		ts.URL,
-		&http.Client{},
+		Type:       APIBackend,
+		URL:        ts.URL,
+		HTTPClient: &http.Client{},
 	})
 
 	err := GetBackend(APIBackend).Call("GET", "/v1/account", "sk_test_badKey", nil, nil, nil)
@@ -36,3 +43,180 @@ func TestErrorResponse(t *testing.T) {
 	assert.Equal(t, "req_123", stripeErr.RequestID)
 	assert.Equal(t, 401, stripeErr.HTTPStatusCode)
 }
+
+func TestResponseToError_Fields(t *testing.T) {
+	c := &BackendConfiguration{URL: APIURL}
+	res := &http.Response{Header: http.Header{"Request-Id": []string{"req_123"}}, StatusCode: 402}
+
+	// The message is missing, and fields unknown to the library are ignored.
+	err := c.ResponseToError(res, []byte(`{"error":{"type":"card_error","code":"card_declined",`+
+		`"decline_code":"insufficient_funds","doc_url":"https://stripe.com/docs/error-codes/card-declined",`+
+		`"charge":"ch_123","source":{"id":"card_123","object":"card","last4":"4242"},`+
+		`"payment_intent":{"id":"pi_123"},"unknown":[1,2]}}`))
+
+	stripeErr := err.(*Error)
+	assert.Equal(t, ErrorTypeCard, stripeErr.Type)
+	assert.Equal(t, CardDeclined, stripeErr.Code)
+	assert.Equal(t, DeclineCodeInsufficientFunds, stripeErr.DeclineCode)
+	assert.Equal(t, "https://stripe.com/docs/error-codes/card-declined", stripeErr.DocURL)
+	assert.Equal(t, "ch_123", stripeErr.ChargeID)
+	assert.Equal(t, "", stripeErr.Msg)
+	assert.Equal(t, "req_123", stripeErr.RequestID)
+	assert.Equal(t, "4242", stripeErr.Source.Card.LastFour)
+
+	// The source isn't part of the error's message, as it's logged.
+	assert.NotContains(t, err.Error(), "4242")
+	assert.NotContains(t, err.Error(), "card_123")
+	assert.Contains(t, err.Error(), `"code":"card_declined"`)
+
+	var cardErr *CardError
+	assert.True(t, errors.As(err, &cardErr))
+	assert.Equal(t, DeclineCodeInsufficientFunds, cardErr.DeclineCode)
+
+	// The *Error can be found from the *CardError too.
+	var fromCardErr *Error
+	assert.True(t, errors.As(cardErr, &fromCardErr))
+	assert.Equal(t, CardDeclined, fromCardErr.Code)
+	assert.Equal(t, DeclineCodeInsufficientFunds, fromCardErr.DeclineCode)
+	assert.Equal(t, "https://stripe.com/docs/error-codes/card-declined", fromCardErr.DocURL)
+	assert.True(t, errors.Is(cardErr, &Error{Code: CardDeclined}))
+	assert.False(t, errors.As(cardErr, new(*RateLimitError)))
+	assert.True(t, errors.Is(err, &Error{Code: CardDeclined}))
+	assert.True(t, errors.Is(err, &Error{Type: ErrorTypeCard, DeclineCode: DeclineCodeInsufficientFunds}))
+	assert.False(t, errors.Is(err, &Error{DeclineCode: DeclineCodeLostCard}))
+	assert.False(t, errors.Is(err, &Error{}))
+	assert.True(t, IsCardDecline(fmt.Errorf("charging: %w", err)))
+	assert.False(t, IsRetryable(err))
+
+	// Fields of an unexpected type are left empty without losing the rest
+	// of the error.
+	err = c.ResponseToError(res, []byte(`{"error":{"type":"card_error","code":"card_declined",`+
+		`"param":["number"],"source":{"id":"card_123","object":"card","exp_month":"twelve"}}}`))
+	stripeErr = err.(*Error)
+	assert.Equal(t, ErrorTypeCard, stripeErr.Type)
+	assert.Equal(t, 402, stripeErr.HTTPStatusCode)
+	assert.Equal(t, "req_123", stripeErr.RequestID)
+	assert.Equal(t, "", stripeErr.Param)
+	assert.Nil(t, stripeErr.Source)
+	assert.True(t, errors.As(err, &cardErr))
+	assert.True(t, IsCardDecline(err))
+
+	err = c.ResponseToError(res, []byte(`{"error":{"type":"api_error","message":42}}`))
+	stripeErr = err.(*Error)
+	assert.Equal(t, ErrorTypeAPI, stripeErr.Type)
+	assert.Equal(t, 402, stripeErr.HTTPStatusCode)
+	assert.Equal(t, "", stripeErr.Msg)
+
+	// Error objects which can't be decoded are API errors holding the
+	// body.
+	err = c.ResponseToError(res, []byte(`{"error":"boom"}`))
+	stripeErr = err.(*Error)
+	assert.Equal(t, ErrorTypeAPI, stripeErr.Type)
+	assert.Equal(t, 402, stripeErr.HTTPStatusCode)
+	assert.Equal(t, "req_123", stripeErr.RequestID)
+	assert.Equal(t, `{"error":"boom"}`, stripeErr.Msg)
+	assert.True(t, errors.As(err, new(*APIError)))
+
+	// Bodies which aren't errors don't panic.
+	err = c.ResponseToError(res, []byte(`{"message":"boom"}`))
+	assert.EqualError(t, err, `{"message":"boom"}`)
+	err = c.ResponseToError(res, []byte(`<html>`))
+	assert.Error(t, err)
+}
+
+func TestIsRetryable(t *testing.T) {
+	c := &BackendConfiguration{URL: APIURL}
+	toError := func(status int, body string) error {
+		return c.ResponseToError(&http.Response{StatusCode: status, Header: http.Header{}}, []byte(body))
+	}
+
+	rateLimited := toError(429, `{"error":{"type":"rate_limit_error","message":"slow down"}}`)
+	var rateLimitErr *RateLimitError
+	assert.True(t, errors.As(rateLimited, &rateLimitErr))
+	var fromRateLimitErr *Error
+	assert.True(t, errors.As(rateLimitErr, &fromRateLimitErr))
+	assert.Equal(t, ErrorTypeRateLimit, fromRateLimitErr.Type)
+	assert.Equal(t, "slow down", fromRateLimitErr.Msg)
+	assert.True(t, IsRetryable(rateLimited))
+
+	assert.True(t, IsRetryable(toError(500, `{"error":{"type":"api_error","message":"boom"}}`)))
+	assert.True(t, IsRetryable(toError(409, `{"error":{"type":"invalid_request_error","message":"conflict"}}`)))
+	assert.True(t, IsRetryable(toError(400, `{"error":{"type":"invalid_request_error","code":"lock_timeout"}}`)))
+	assert.True(t, IsRetryable(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+
+	invalid := toError(400, `{"error":{"type":"invalid_request_error","message":"bad"}}`)
+	assert.False(t, IsRetryable(invalid))
+	assert.False(t, IsCardDecline(invalid))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(nil))
+}
+
+func TestConnectionErrors(t *testing.T) {
+	call := func(client *http.Client, url string) *APIConnectionError {
+		c := &BackendConfiguration{Type: APIBackend, URL: url, HTTPClient: client}
+		err := c.Call("POST", "/charges", "sk_test_123", nil, nil, nil)
+
+		stripeErr := err.(*Error)
+		assert.Equal(t, ErrorTypeAPIConnection, stripeErr.Type)
+		assert.True(t, IsRetryable(err))
+
+		var connErr *APIConnectionError
+		assert.True(t, errors.As(err, &connErr))
+		assert.NotNil(t, connErr.Err)
+		return connErr
+	}
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	connErr := call(&http.Client{}, closed.URL)
+	assert.Equal(t, ConnectionErrorCategoryConnect, connErr.Category)
+	assert.False(t, connErr.MayHaveReachedStripe)
+
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+	tlsServer.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	connErr = call(&http.Client{}, tlsServer.URL)
+	assert.Equal(t, ConnectionErrorCategoryTLS, connErr.Category)
+	assert.False(t, connErr.MayHaveReachedStripe)
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer slow.Close()
+	connErr = call(&http.Client{Timeout: 10 * time.Millisecond}, slow.URL)
+	assert.Equal(t, ConnectionErrorCategoryTimeout, connErr.Category)
+	assert.True(t, connErr.MayHaveReachedStripe)
+
+	hangUp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		assert.NoError(t, err)
+		conn.Close()
+	}))
+	defer hangUp.Close()
+	connErr = call(&http.Client{}, hangUp.URL)
+	assert.Equal(t, ConnectionErrorCategoryReset, connErr.Category)
+	assert.True(t, connErr.MayHaveReachedStripe)
+
+	truncated := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.Header().Set("Request-Id", "req_123")
+		w.Write([]byte(`{"id":`))
+	}))
+	defer truncated.Close()
+	connErr = call(&http.Client{}, truncated.URL)
+	assert.Equal(t, ConnectionErrorCategoryReadBody, connErr.Category)
+	assert.True(t, connErr.MayHaveReachedStripe)
+
+	dnsErr := newConnectionError(&url.Error{Op: "Post", URL: "https://api.stripe.com",
+		Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "api.stripe.com"}}}, false)
+	assert.Equal(t, ConnectionErrorCategoryDNS, dnsErr.Err.(*APIConnectionError).Category)
+	assert.False(t, dnsErr.Err.(*APIConnectionError).MayHaveReachedStripe)
+
+	// Errors caused by the caller giving up aren't wrapped.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := &BackendConfiguration{Type: APIBackend, URL: slow.URL, HTTPClient: &http.Client{}}
+	err := c.Call("GET", "/charges", "sk_test_123", nil, &Params{Context: ctx}, nil)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, errors.As(err, new(*Error)))
+}
@@ -2,21 +2,26 @@ package stripe
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
+	"sync"
 )
 
 // Event is the resource representing a Stripe event.
 // For more details see https://stripe.com/docs/api#events.
 type Event struct {
-	Account  string        `json:"account"`
-	Created  int64         `json:"created"`
-	Data     *EventData    `json:"data"`
-	ID       string        `json:"id"`
-	Live     bool          `json:"livemode"`
-	Request  *EventRequest `json:"request"`
-	Type     string        `json:"type"`
-	Webhooks uint64        `json:"pending_webhooks"`
+	Account    string        `json:"account"`
+	APIVersion string        `json:"api_version"`
+	Created    int64         `json:"created"`
+	Data       *EventData    `json:"data"`
+	ID         string        `json:"id"`
+	Live       bool          `json:"livemode"`
+	Request    *EventRequest `json:"request"`
+	Type       string        `json:"type"`
+	Webhooks   uint64        `json:"pending_webhooks"`
 }
 
 // EventRequest contains information on a request that created an event.
@@ -33,17 +38,79 @@ type EventRequest struct {
 
 // EventData is the unmarshalled object as a map.
 type EventData struct {
-	Obj  map[string]interface{}
+	Obj  map[string]interface{} `json:"-"`
 	Prev map[string]interface{} `json:"previous_attributes"`
 	Raw  json.RawMessage        `json:"object"`
 }
 
-// EventList is a list of events as retrieved from a list endpoint.
-type EventList struct {
-	ListMeta
-	Values []*Event `json:"data"`
+// AttributeChange is a single attribute of an event's object that changed,
+// as reported in its previous_attributes.
+type AttributeChange struct {
+	// Path is the attribute's keys hierarchy, as accepted by
+	// LookupObjValue.
+	Path []string
+
+	// Previous is the attribute's value before the change. It's nil when
+	// the attribute was unset.
+	Previous interface{}
+
+	// Current is the attribute's value in the event's object. It's nil when
+	// the attribute is now unset.
+	Current interface{}
+}
+
+// eventObjects maps the value of the `object` field of an event's object to a
+// function returning a new value of the type it decodes into.
+var eventObjects = map[string]func() interface{}{
+	"account":             func() interface{} { return &Account{} },
+	"application_fee":     func() interface{} { return &Fee{} },
+	"balance":             func() interface{} { return &Balance{} },
+	"balance_transaction": func() interface{} { return &Transaction{} },
+	"bank_account":        func() interface{} { return &BankAccount{} },
+	"bitcoin_receiver":    func() interface{} { return &BitcoinReceiver{} },
+	"card":                func() interface{} { return &Card{} },
+	"charge":              func() interface{} { return &Charge{} },
+	"coupon":              func() interface{} { return &Coupon{} },
+	"customer":            func() interface{} { return &Customer{} },
+	"discount":            func() interface{} { return &Discount{} },
+	"dispute":             func() interface{} { return &Dispute{} },
+	"fee_refund":          func() interface{} { return &FeeRefund{} },
+	"file_upload":         func() interface{} { return &FileUpload{} },
+	"invoice":             func() interface{} { return &Invoice{} },
+	"invoiceitem":         func() interface{} { return &InvoiceItem{} },
+	"order":               func() interface{} { return &Order{} },
+	"order_return":        func() interface{} { return &OrderReturn{} },
+	"payout":              func() interface{} { return &Payout{} },
+	"plan":                func() interface{} { return &Plan{} },
+	"product":             func() interface{} { return &Product{} },
+	"recipient":           func() interface{} { return &Recipient{} },
+	"refund":              func() interface{} { return &Refund{} },
+	"review":              func() interface{} { return &Review{} },
+	"sku":                 func() interface{} { return &SKU{} },
+	"source":              func() interface{} { return &Source{} },
+	"subscription":        func() interface{} { return &Sub{} },
+	"subscription_item":   func() interface{} { return &SubItem{} },
+	"topup":               func() interface{} { return &Topup{} },
+	"transfer":            func() interface{} { return &Transfer{} },
+	"transfer_reversal":   func() interface{} { return &Reversal{} },
+}
+
+var eventObjectsMu sync.RWMutex
+
+// RegisterEventObject registers the type that event objects with the given
+// `object` field decode into, adding to or replacing the types known to
+// EventData.Object. newObject must return a pointer to a new value of that
+// type.
+func RegisterEventObject(object string, newObject func() interface{}) {
+	eventObjectsMu.Lock()
+	defer eventObjectsMu.Unlock()
+
+	eventObjects[object] = newObject
 }
 
+// EventList is a list of events as retrieved from a list endpoint.
+type EventList = List[*Event]
+
 // EventListParams is the set of parameters that can be used when listing events.
 // For more details see https://stripe.com/docs/api#list_events.
 type EventListParams struct {
@@ -55,15 +122,144 @@ type EventListParams struct {
 }
 
 // GetObjValue returns the value from the e.Data.Obj bag based on the keys hierarchy.
+// It panics if the keys don't describe a valid path; see LookupObjValue for a
+// version that returns an error instead.
 func (e *Event) GetObjValue(keys ...string) string {
 	return getValue(e.Data.Obj, keys)
 }
 
 // GetPrevValue returns the value from the e.Data.Prev bag based on the keys hierarchy.
+// It panics if the keys don't describe a valid path; see LookupPrevValue for a
+// version that returns an error instead.
 func (e *Event) GetPrevValue(keys ...string) string {
 	return getValue(e.Data.Prev, keys)
 }
 
+// LookupObjValue returns the value from the e.Data.Obj bag based on the keys
+// hierarchy. Elements of lists are accessed with their index as key. An
+// absent or null value is returned as an empty string. It returns an error
+// when the keys don't describe a valid path.
+func (e *Event) LookupObjValue(keys ...string) (string, error) {
+	if e.Data == nil {
+		return "", fmt.Errorf("Event %s has no data", e.ID)
+	}
+	return lookupValue(e.Data.Obj, keys)
+}
+
+// LookupPrevValue returns the value from the e.Data.Prev bag based on the keys
+// hierarchy, like LookupObjValue does for e.Data.Obj.
+func (e *Event) LookupPrevValue(keys ...string) (string, error) {
+	if e.Data == nil {
+		return "", fmt.Errorf("Event %s has no data", e.ID)
+	}
+	return lookupValue(e.Data.Prev, keys)
+}
+
+// ObjectType returns the `object` field of the event's object, like "charge"
+// or "invoice".
+func (e *EventData) ObjectType() string {
+	object, _ := e.Obj["object"].(string)
+	return object
+}
+
+// Object decodes the event's object into the type registered for its
+// `object` field, so that a "charge" is returned as a *Charge, an "invoice"
+// as an *Invoice, and so on. It returns an error for objects of an unknown
+// type.
+func (e *EventData) Object() (interface{}, error) {
+	obj, err := e.newObject()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(e.Raw, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// PreviousObject decodes the event's previous_attributes into the same type
+// as Object. Only the attributes that changed are set on the returned value.
+// It returns nil when the event has no previous attributes.
+func (e *EventData) PreviousObject() (interface{}, error) {
+	if e.Prev == nil {
+		return nil, nil
+	}
+
+	obj, err := e.newObject()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(e.Prev)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Changes lists the attributes of the event's object that changed, as
+// reported by its previous_attributes, sorted by path. Nested maps are
+// descended into so that every change is for a single attribute, while lists
+// are compared as a whole.
+func (e *EventData) Changes() []AttributeChange {
+	var changes []AttributeChange
+	collectChanges(&changes, nil, e.Prev, e.Obj)
+
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i].Path, changes[j].Path
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return changes
+}
+
+func (e *EventData) newObject() (interface{}, error) {
+	object := e.ObjectType()
+
+	eventObjectsMu.RLock()
+	newObject, ok := eventObjects[object]
+	eventObjectsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("Unknown event object type: %q", object)
+	}
+	return newObject(), nil
+}
+
+// collectChanges appends the changes between prev and cur, both found at
+// path, to changes.
+func collectChanges(changes *[]AttributeChange, path []string, prev, cur map[string]interface{}) {
+	for key, prevValue := range prev {
+		keyPath := append(append([]string{}, path...), key)
+		curValue := cur[key]
+
+		prevMap, prevIsMap := prevValue.(map[string]interface{})
+		curMap, curIsMap := curValue.(map[string]interface{})
+		if prevIsMap && (curIsMap || curValue == nil) {
+			collectChanges(changes, keyPath, prevMap, curMap)
+			continue
+		}
+
+		if reflect.DeepEqual(prevValue, curValue) {
+			continue
+		}
+
+		*changes = append(*changes, AttributeChange{
+			Path:     keyPath,
+			Previous: prevValue,
+			Current:  curValue,
+		})
+	}
+}
+
 // UnmarshalJSON handles deserialization of the EventData.
 // This custom unmarshaling exists so that we can keep both the map and raw data.
 func (e *EventData) UnmarshalJSON(data []byte) error {
@@ -78,8 +274,22 @@ func (e *EventData) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(e.Raw, &e.Obj)
 }
 
-// getValue returns the value from the m map based on the keys.
+// getValue returns the value from the m map based on the keys, panicking if
+// the keys don't describe a valid path.
 func getValue(m map[string]interface{}, keys []string) string {
+	value, err := lookupValue(m, keys)
+	if err != nil {
+		panic(err.Error())
+	}
+	return value
+}
+
+// lookupValue returns the value from the m map based on the keys.
+func lookupValue(m map[string]interface{}, keys []string) (string, error) {
+	if len(keys) == 0 {
+		return "", errors.New("At least one key is needed to look up a value")
+	}
+
 	node := m[keys[0]]
 
 	for i := 1; i < len(keys); i++ {
@@ -89,9 +299,14 @@ func getValue(m map[string]interface{}, keys []string) string {
 		if ok {
 			intKey, err := strconv.Atoi(key)
 			if err != nil {
-				panic(fmt.Sprintf(
+				return "", fmt.Errorf(
 					"Cannot access nested slice element with non-integer key: %s",
-					key))
+					key)
+			}
+			if intKey < 0 || intKey >= len(sliceNode) {
+				return "", fmt.Errorf(
+					"Cannot access nested slice element out of range with key: %s",
+					key)
 			}
 			node = sliceNode[intKey]
 			continue
@@ -103,13 +318,13 @@ func getValue(m map[string]interface{}, keys []string) string {
 			continue
 		}
 
-		panic(fmt.Sprintf(
-			"Cannot descend into non-map non-slice object with key: %s", key))
+		return "", fmt.Errorf(
+			"Cannot descend into non-map non-slice object with key: %s", key)
 	}
 
 	if node == nil {
-		return ""
+		return "", nil
 	}
 
-	return fmt.Sprintf("%v", node)
+	return fmt.Sprintf("%v", node), nil
 }
@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"encoding/json"
 	"testing"
 
 	assert "github.com/stretchr/testify/require"
@@ -53,3 +54,105 @@ func TestGetObjValue(t *testing.T) {
 		event.GetObjValue("top_level_key", "bad_key")
 	})
 }
+
+func TestLookupObjValue(t *testing.T) {
+	event := &Event{
+		Data: &EventData{
+			Obj: map[string]interface{}{
+				"top_level_key": "top_level",
+				"slice":         []interface{}{"index-0"},
+			},
+		},
+	}
+
+	value, err := event.LookupObjValue("top_level_key")
+	assert.NoError(t, err)
+	assert.Equal(t, "top_level", value)
+
+	value, err = event.LookupObjValue("bad_key")
+	assert.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	_, err = event.LookupObjValue("slice", "string_key")
+	assert.EqualError(t, err, "Cannot access nested slice element with non-integer key: string_key")
+
+	_, err = event.LookupObjValue("slice", "1")
+	assert.EqualError(t, err, "Cannot access nested slice element out of range with key: 1")
+
+	_, err = event.LookupObjValue("top_level_key", "bad_key")
+	assert.EqualError(t, err, "Cannot descend into non-map non-slice object with key: bad_key")
+
+	_, err = event.LookupPrevValue()
+	assert.Error(t, err)
+
+	_, err = (&Event{ID: "evt_123"}).LookupObjValue("top_level_key")
+	assert.EqualError(t, err, "Event evt_123 has no data")
+}
+
+func TestEventDataObject(t *testing.T) {
+	var event Event
+	err := json.Unmarshal([]byte(`{
+		"id": "evt_123",
+		"type": "charge.updated",
+		"data": {
+			"object": {
+				"id": "ch_123",
+				"object": "charge",
+				"amount": 100,
+				"description": "new",
+				"metadata": {"order": "2", "unchanged": "x"}
+			},
+			"previous_attributes": {
+				"description": "old",
+				"metadata": {"order": "1", "removed": "y"}
+			}
+		}
+	}`), &event)
+	assert.NoError(t, err)
+	assert.Equal(t, "charge", event.Data.ObjectType())
+
+	obj, err := event.Data.Object()
+	assert.NoError(t, err)
+	charge, ok := obj.(*Charge)
+	assert.True(t, ok)
+	assert.Equal(t, "ch_123", charge.ID)
+	assert.Equal(t, uint64(100), charge.Amount)
+
+	prev, err := event.Data.PreviousObject()
+	assert.NoError(t, err)
+	prevCharge, ok := prev.(*Charge)
+	assert.True(t, ok)
+	assert.Equal(t, "old", prevCharge.Desc)
+	assert.Equal(t, "1", prevCharge.Meta["order"])
+
+	assert.Equal(t, []AttributeChange{
+		{Path: []string{"description"}, Previous: "old", Current: "new"},
+		{Path: []string{"metadata", "order"}, Previous: "1", Current: "2"},
+		{Path: []string{"metadata", "removed"}, Previous: "y", Current: nil},
+	}, event.Data.Changes())
+}
+
+func TestEventDataObjectUnknownType(t *testing.T) {
+	data := &EventData{
+		Obj: map[string]interface{}{"object": "unknown_thing"},
+		Raw: []byte(`{"object": "unknown_thing"}`),
+	}
+
+	_, err := data.Object()
+	assert.EqualError(t, err, `Unknown event object type: "unknown_thing"`)
+
+	RegisterEventObject("unknown_thing", func() interface{} { return &map[string]interface{}{} })
+	defer func() {
+		eventObjectsMu.Lock()
+		delete(eventObjects, "unknown_thing")
+		eventObjectsMu.Unlock()
+	}()
+
+	obj, err := data.Object()
+	assert.NoError(t, err)
+	assert.Equal(t, &map[string]interface{}{"object": "unknown_thing"}, obj)
+
+	prev, err := data.PreviousObject()
+	assert.NoError(t, err)
+	assert.Nil(t, prev)
+}
@@ -8,10 +8,7 @@ type ExchangeRate struct {
 }
 
 // ExchangeRateList is a list of exchange rates as retrieved from a list endpoint.
-type ExchangeRateList struct {
-	ListMeta
-	Values []*ExchangeRate `json:"data"`
-}
+type ExchangeRateList = List[*ExchangeRate]
 
 // ExchangeRateListParams are the parameters allowed during ExchangeRate listing.
 type ExchangeRateListParams struct {
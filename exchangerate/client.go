@@ -32,25 +32,18 @@ func List(params *stripe.ExchangeRateListParams) *Iter {
 func (c Client) List(params *stripe.ExchangeRateListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.ExchangeRate, stripe.ListMeta, error) {
 		list := &stripe.ExchangeRateList{}
 		err := c.B.Call("GET", "/exchange_rates", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -58,13 +51,13 @@ func (c Client) List(params *stripe.ExchangeRateListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.ExchangeRate]
 }
 
 // ExchangeRate returns the most recent ExchangeRate
 // visited by a call to Next.
 func (i *Iter) ExchangeRate() *stripe.ExchangeRate {
-	return i.Current().(*stripe.ExchangeRate)
+	return i.Current()
 }
 
 func getC() Client {
@@ -0,0 +1,188 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Expandable is a reference to another object that Stripe returns either as
+// the object's ID, or as the full object when it was expanded with
+// Params.Expand. A nil *Expandable is an absent reference.
+type Expandable[T any] struct {
+	id  string
+	obj *T
+}
+
+// NewExpandableID returns a reference to the object with the given ID which
+// isn't expanded.
+func NewExpandableID[T any](id string) *Expandable[T] {
+	return &Expandable[T]{id: id}
+}
+
+// NewExpanded returns a reference to an expanded object with the given ID.
+func NewExpanded[T any](id string, obj *T) *Expandable[T] {
+	return &Expandable[T]{id: id, obj: obj}
+}
+
+// ID returns the referenced object's ID, whether it was expanded or not.
+func (e *Expandable[T]) ID() string {
+	if e == nil {
+		return ""
+	}
+	return e.id
+}
+
+// IsExpanded returns whether the full object is available through Object.
+func (e *Expandable[T]) IsExpanded() bool {
+	return e != nil && e.obj != nil
+}
+
+// Object returns the referenced object if it was expanded, or nil otherwise.
+func (e *Expandable[T]) Object() *T {
+	if e == nil {
+		return nil
+	}
+	return e.obj
+}
+
+// Fetch returns the referenced object, retrieving it from Stripe with the
+// package level backends and Key if it wasn't expanded. Use FetchWith to
+// retrieve it with the backends and key of a client instead.
+//
+// Fetch isn't safe for concurrent use on the same reference, as it keeps the
+// retrieved object.
+func (e *Expandable[T]) Fetch(ctx context.Context) (*T, error) {
+	return e.FetchWith(ctx, nil, Key)
+}
+
+// FetchWith returns the referenced object, retrieving it from Stripe through
+// the given backends and with key if it wasn't expanded. The package level
+// backends are used when backends is nil. The retrieved object is kept so
+// that subsequent calls don't make another request.
+//
+// Only objects that can be retrieved by their ID alone can be fetched; for
+// others, like cards, FetchWith returns an error. Like Fetch, it isn't safe
+// for concurrent use on the same reference.
+func (e *Expandable[T]) FetchWith(ctx context.Context, backends *Backends, key string) (*T, error) {
+	if e == nil {
+		return nil, nil
+	}
+	if e.obj != nil {
+		return e.obj, nil
+	}
+	if e.id == "" {
+		return nil, fmt.Errorf("Cannot fetch a reference without an ID")
+	}
+
+	var zero T
+	endpoint, ok := fetchEndpoints[reflect.TypeOf(zero)]
+	if !ok {
+		return nil, fmt.Errorf("Cannot fetch a %T by its ID alone", zero)
+	}
+
+	var backend Backend
+	switch {
+	case backends == nil:
+		backend = GetBackend(endpoint.backend)
+	case endpoint.backend == UploadsBackend:
+		backend = backends.Uploads
+	default:
+		backend = backends.API
+	}
+
+	obj := new(T)
+	err := backend.Call("GET", endpoint.path+e.id, key, nil, &Params{Context: ctx}, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	e.obj = obj
+	return obj, nil
+}
+
+// UnmarshalJSON handles deserialization of a reference which is either an ID
+// or a full object.
+func (e *Expandable[T]) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		*e = Expandable[T]{id: id}
+		return nil
+	}
+
+	obj := new(T)
+	if err := json.Unmarshal(data, obj); err != nil {
+		return err
+	}
+
+	var ref struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+
+	*e = Expandable[T]{id: ref.ID, obj: obj}
+	return nil
+}
+
+// MarshalJSON serializes a reference as its object if it was expanded, or as
+// its ID otherwise.
+func (e *Expandable[T]) MarshalJSON() ([]byte, error) {
+	if e.obj != nil {
+		return json.Marshal(e.obj)
+	}
+	return json.Marshal(e.id)
+}
+
+// parseID returns the ID of an object that wasn't expanded, which Stripe sends
+// as a JSON string instead of the object. ok is false when data isn't a
+// string.
+func parseID(data []byte) (id string, ok bool) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '"' {
+		return "", false
+	}
+
+	if err := json.Unmarshal(data, &id); err != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// fetchEndpoint is where an object is retrieved by appending its ID to path.
+type fetchEndpoint struct {
+	backend SupportedBackend
+	path    string
+}
+
+// fetchEndpoints are the endpoints Expandable.Fetch retrieves objects from,
+// by type.
+var fetchEndpoints = map[reflect.Type]fetchEndpoint{
+	reflect.TypeOf(Account{}):         {APIBackend, "/accounts/"},
+	reflect.TypeOf(BitcoinReceiver{}): {APIBackend, "/bitcoin/receivers/"},
+	reflect.TypeOf(Charge{}):          {APIBackend, "/charges/"},
+	reflect.TypeOf(Coupon{}):          {APIBackend, "/coupons/"},
+	reflect.TypeOf(Customer{}):        {APIBackend, "/customers/"},
+	reflect.TypeOf(Dispute{}):         {APIBackend, "/disputes/"},
+	reflect.TypeOf(Fee{}):             {APIBackend, "/application_fees/"},
+	reflect.TypeOf(File{}):            {UploadsBackend, "/files/"},
+	reflect.TypeOf(FileUpload{}):      {UploadsBackend, "/files/"},
+	reflect.TypeOf(Invoice{}):         {APIBackend, "/invoices/"},
+	reflect.TypeOf(InvoiceItem{}):     {APIBackend, "/invoiceitems/"},
+	reflect.TypeOf(Order{}):           {APIBackend, "/orders/"},
+	reflect.TypeOf(OrderReturn{}):     {APIBackend, "/order_returns/"},
+	reflect.TypeOf(Payout{}):          {APIBackend, "/payouts/"},
+	reflect.TypeOf(Plan{}):            {APIBackend, "/plans/"},
+	reflect.TypeOf(Product{}):         {APIBackend, "/products/"},
+	reflect.TypeOf(Recipient{}):       {APIBackend, "/recipients/"},
+	reflect.TypeOf(Refund{}):          {APIBackend, "/refunds/"},
+	reflect.TypeOf(Review{}):          {APIBackend, "/reviews/"},
+	reflect.TypeOf(SKU{}):             {APIBackend, "/skus/"},
+	reflect.TypeOf(Source{}):          {APIBackend, "/sources/"},
+	reflect.TypeOf(Sub{}):             {APIBackend, "/subscriptions/"},
+	reflect.TypeOf(Topup{}):           {APIBackend, "/topups/"},
+	reflect.TypeOf(Transaction{}):     {APIBackend, "/balance/history/"},
+	reflect.TypeOf(Transfer{}):        {APIBackend, "/transfers/"},
+}
@@ -0,0 +1,115 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/form"
+)
+
+// fetchBackend is a Backend answering every call with a charge, recording the
+// paths it's called with.
+type fetchBackend struct {
+	paths []string
+	keys  []string
+}
+
+func (b *fetchBackend) Call(method, path, key string, body *form.Values, params *Params, v interface{}) error {
+	b.paths = append(b.paths, path)
+	b.keys = append(b.keys, key)
+	return json.Unmarshal([]byte(`{"id":"ch_123","amount":100}`), v)
+}
+
+func (b *fetchBackend) CallMultipart(method, path, key, boundary string, body io.Reader, params *Params, v interface{}) error {
+	return nil
+}
+
+func TestExpandableUnmarshalJSON(t *testing.T) {
+	var refund Refund
+	err := json.Unmarshal([]byte(`{"id":"re_123","charge":"ch_123"}`), &refund)
+	assert.NoError(t, err)
+	assert.Equal(t, "ch_123", refund.Charge.ID())
+	assert.False(t, refund.Charge.IsExpanded())
+	assert.Nil(t, refund.Charge.Object())
+
+	err = json.Unmarshal([]byte(`{"id":"re_123","charge":{"id":"ch_123","amount":100}}`), &refund)
+	assert.NoError(t, err)
+	assert.Equal(t, "ch_123", refund.Charge.ID())
+	assert.True(t, refund.Charge.IsExpanded())
+	assert.Equal(t, uint64(100), refund.Charge.Object().Amount)
+
+	refund = Refund{}
+	err = json.Unmarshal([]byte(`{"id":"re_123","charge":null}`), &refund)
+	assert.NoError(t, err)
+	assert.Nil(t, refund.Charge)
+	assert.Equal(t, "", refund.Charge.ID())
+	assert.False(t, refund.Charge.IsExpanded())
+}
+
+func TestExpandableUnmarshalJSONError(t *testing.T) {
+	var refund Refund
+	err := json.Unmarshal([]byte(`{"id":"re_123","charge":{"id":"ch_123","amount":"lots"}}`), &refund)
+	assert.Error(t, err)
+
+	var charge Charge
+	err = json.Unmarshal([]byte(`{"id":"ch_123","amount":"lots"}`), &charge)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(`"ch_123"`), &charge)
+	assert.NoError(t, err)
+	assert.Equal(t, "ch_123", charge.ID)
+}
+
+func TestExpandableMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(NewExpandableID[Charge]("ch_123"))
+	assert.NoError(t, err)
+	assert.Equal(t, `"ch_123"`, string(data))
+
+	data, err = json.Marshal(NewExpanded("ch_123", &Charge{ID: "ch_123", Amount: 100}))
+	assert.NoError(t, err)
+
+	var ref Expandable[Charge]
+	assert.NoError(t, json.Unmarshal(data, &ref))
+	assert.Equal(t, "ch_123", ref.ID())
+	assert.Equal(t, uint64(100), ref.Object().Amount)
+}
+
+func TestExpandableFetch(t *testing.T) {
+	backend := &fetchBackend{}
+	previous := GetBackend(APIBackend)
+	SetBackend(APIBackend, backend)
+	defer SetBackend(APIBackend, previous)
+
+	ref := NewExpandableID[Charge]("ch_123")
+	charge, err := ref.Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), charge.Amount)
+	assert.True(t, ref.IsExpanded())
+
+	// The fetched charge is kept.
+	_, err = ref.Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/charges/ch_123"}, backend.paths)
+
+	_, err = NewExpandableID[Card]("card_123").Fetch(context.Background())
+	assert.EqualError(t, err, "Cannot fetch a stripe.Card by its ID alone")
+}
+
+func TestExpandableFetchWith(t *testing.T) {
+	global := &fetchBackend{}
+	previous := GetBackend(APIBackend)
+	SetBackend(APIBackend, global)
+	defer SetBackend(APIBackend, previous)
+
+	backend := &fetchBackend{}
+	charge, err := NewExpandableID[Charge]("ch_123").FetchWith(context.Background(),
+		&Backends{API: backend, Uploads: &fetchBackend{}}, "sk_test_123")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), charge.Amount)
+	assert.Equal(t, []string{"/charges/ch_123"}, backend.paths)
+	assert.Equal(t, []string{"sk_test_123"}, backend.keys)
+	assert.Empty(t, global.paths)
+}
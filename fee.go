@@ -21,40 +21,50 @@ type FeeListParams struct {
 // Fee is the resource representing a Stripe application fee.
 // For more details see https://stripe.com/docs/api#application_fees.
 type Fee struct {
-	Account                *Account       `json:"account"`
-	Amount                 uint64         `json:"amount"`
-	AmountRefunded         uint64         `json:"amount_refunded"`
-	App                    string         `json:"application"`
-	Charge                 *Charge        `json:"charge"`
-	Created                int64          `json:"created"`
-	Currency               Currency       `json:"currency"`
-	ID                     string         `json:"id"`
-	Live                   bool           `json:"livemode"`
-	OriginatingTransaction *Charge        `json:"originating_transaction"`
-	Refunded               bool           `json:"refunded"`
-	Refunds                *FeeRefundList `json:"refunds"`
-	Tx                     *Transaction   `json:"balance_transaction"`
+	Account                *Expandable[Account]     `json:"account"`
+	Amount                 uint64                   `json:"amount"`
+	AmountRefunded         uint64                   `json:"amount_refunded"`
+	App                    string                   `json:"application"`
+	Charge                 *Expandable[Charge]      `json:"charge"`
+	Created                int64                    `json:"created"`
+	Currency               Currency                 `json:"currency"`
+	ID                     string                   `json:"id"`
+	Live                   bool                     `json:"livemode"`
+	OriginatingTransaction *Expandable[Charge]      `json:"originating_transaction"`
+	Refunded               bool                     `json:"refunded"`
+	Refunds                *FeeRefundList           `json:"refunds"`
+	Tx                     *Expandable[Transaction] `json:"balance_transaction"`
 }
 
-// FeeList is a list of fees as retrieved from a list endpoint.
-type FeeList struct {
-	ListMeta
-	Values []*Fee `json:"data"`
+// AmountMoney returns the amount of the application fee in its currency.
+func (f *Fee) AmountMoney() Money {
+	return moneyFromUint64(f.Amount, f.Currency)
+}
+
+// AmountRefundedMoney returns the amount refunded from the application fee in its currency.
+func (f *Fee) AmountRefundedMoney() Money {
+	return moneyFromUint64(f.AmountRefunded, f.Currency)
 }
 
+// FeeList is a list of fees as retrieved from a list endpoint.
+type FeeList = List[*Fee]
+
 // UnmarshalJSON handles deserialization of a Fee.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (f *Fee) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		f.ID = id
+		return nil
+	}
+
 	type appfee Fee
 	var ff appfee
 	err := json.Unmarshal(data, &ff)
-	if err == nil {
-		*f = Fee(ff)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		f.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*f = Fee(ff)
 	return nil
 }
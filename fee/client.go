@@ -21,6 +21,10 @@ func Get(id string, params *stripe.FeeParams) (*stripe.Fee, error) {
 }
 
 func (c Client) Get(id string, params *stripe.FeeParams) (*stripe.Fee, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -45,25 +49,18 @@ func List(params *stripe.FeeListParams) *Iter {
 func (c Client) List(params *stripe.FeeListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Fee, stripe.ListMeta, error) {
 		list := &stripe.FeeList{}
 		err := c.B.Call("GET", "/application_fees", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -71,13 +68,13 @@ func (c Client) List(params *stripe.FeeListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Fee]
 }
 
 // Fee returns the most recent Fee
 // visited by a call to Next.
 func (i *Iter) Fee() *stripe.Fee {
-	return i.Current().(*stripe.Fee)
+	return i.Current()
 }
 
 func getC() Client {
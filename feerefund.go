@@ -22,34 +22,39 @@ type FeeRefundListParams struct {
 // FeeRefund is the resource representing a Stripe fee refund.
 // For more details see https://stripe.com/docs/api#fee_refunds.
 type FeeRefund struct {
-	Amount   uint64            `json:"amount"`
-	Created  int64             `json:"created"`
-	Currency Currency          `json:"currency"`
-	Fee      string            `json:"fee"`
-	ID       string            `json:"id"`
-	Meta     map[string]string `json:"metadata"`
-	Tx       *Transaction      `json:"balance_transaction"`
+	Amount   uint64                   `json:"amount"`
+	Created  int64                    `json:"created"`
+	Currency Currency                 `json:"currency"`
+	Fee      string                   `json:"fee"`
+	ID       string                   `json:"id"`
+	Meta     map[string]string        `json:"metadata"`
+	Tx       *Expandable[Transaction] `json:"balance_transaction"`
 }
 
-// FeeRefundList is a list object for fee refunds.
-type FeeRefundList struct {
-	ListMeta
-	Values []*FeeRefund `json:"data"`
+// AmountMoney returns the amount of the application fee refund in its currency.
+func (f *FeeRefund) AmountMoney() Money {
+	return moneyFromUint64(f.Amount, f.Currency)
 }
 
+// FeeRefundList is a list object for fee refunds.
+type FeeRefundList = List[*FeeRefund]
+
 // UnmarshalJSON handles deserialization of a FeeRefund.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (f *FeeRefund) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		f.ID = id
+		return nil
+	}
+
 	type feerefund FeeRefund
 	var ff feerefund
 	err := json.Unmarshal(data, &ff)
-	if err == nil {
-		*f = FeeRefund(ff)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		f.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*f = FeeRefund(ff)
 	return nil
 }
@@ -21,6 +21,10 @@ func New(params *stripe.FeeRefundParams) (*stripe.FeeRefund, error) {
 }
 
 func (c Client) New(params *stripe.FeeRefundParams) (*stripe.FeeRefund, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	if params == nil {
 		return nil, fmt.Errorf("params cannot be nil")
 	}
@@ -44,6 +48,10 @@ func Get(id string, params *stripe.FeeRefundParams) (*stripe.FeeRefund, error) {
 }
 
 func (c Client) Get(id string, params *stripe.FeeRefundParams) (*stripe.FeeRefund, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	if params == nil {
 		return nil, fmt.Errorf("params cannot be nil")
 	}
@@ -67,6 +75,10 @@ func Update(id string, params *stripe.FeeRefundParams) (*stripe.FeeRefund, error
 }
 
 func (c Client) Update(id string, params *stripe.FeeRefundParams) (*stripe.FeeRefund, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	if params == nil {
 		return nil, fmt.Errorf("params cannot be nil")
 	}
@@ -92,22 +104,15 @@ func List(params *stripe.FeeRefundListParams) *Iter {
 func (c Client) List(params *stripe.FeeRefundListParams) *Iter {
 	body := &form.Values{}
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	form.AppendTo(body, params)
 	lp = &params.ListParams
-	p = params.ToParams()
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.FeeRefund, stripe.ListMeta, error) {
 		list := &stripe.FeeRefundList{}
 		err := c.B.Call("GET", fmt.Sprintf("/application_fees/%v/refunds", params.Fee), c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -115,13 +120,13 @@ func (c Client) List(params *stripe.FeeRefundListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.FeeRefund]
 }
 
 // FeeRefund returns the most recent FeeRefund
 // visited by a call to Next.
 func (i *Iter) FeeRefund() *stripe.FeeRefund {
-	return i.Current().(*stripe.FeeRefund)
+	return i.Current()
 }
 
 func getC() Client {
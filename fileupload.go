@@ -1,13 +1,29 @@
 package stripe
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path/filepath"
 )
 
+// fileUploadSniffLen is how many bytes at the start of a file are used to
+// detect its content type.
+const fileUploadSniffLen = 512
+
+// fileUploadTypes are the content types of the files Stripe accepts for each
+// purpose. Files uploaded for other purposes aren't validated.
+var fileUploadTypes = map[FileUploadPurpose][]string{
+	"dispute_evidence":  {"application/pdf", "image/jpeg", "image/png"},
+	"identity_document": {"application/pdf", "image/jpeg", "image/png"},
+}
+
 // FileUploadParams is the set of parameters that can be used when creating a
 // file upload.
 // For more details see https://stripe.com/docs/api#create_file_upload.
@@ -25,6 +41,11 @@ type FileUploadParams struct {
 	Filename string
 
 	Purpose FileUploadPurpose
+
+	// Progress is optionally called as the file is being uploaded with the
+	// number of bytes of the file sent so far, and its total size or -1 if
+	// it isn't known.
+	Progress func(sent, total int64) `form:"-"`
 }
 
 // FileUploadListParams is the set of parameters that can be used when listing
@@ -52,14 +73,13 @@ type FileUpload struct {
 }
 
 // FileUploadList is a list of file uploads as retrieved from a list endpoint.
-type FileUploadList struct {
-	ListMeta
-	Values []*FileUpload `json:"data"`
-}
+type FileUploadList = List[*FileUpload]
 
 // AppendDetails adds the file upload details to an io.ReadWriter. It returns
 // the boundary string for a multipart/form-data request and an error (if one
 // exists).
+//
+// It buffers the whole file in body; prefer NewBody, which streams it.
 func (f *FileUploadParams) AppendDetails(body io.ReadWriter) (string, error) {
 	writer := multipart.NewWriter(body)
 	var err error
@@ -103,19 +123,209 @@ func (f *FileUploadParams) AppendDetails(body io.ReadWriter) (string, error) {
 	return writer.Boundary(), nil
 }
 
+// FileUploadBody is a multipart/form-data request body that streams a file
+// upload as it's read, without buffering the file in memory. It's returned by
+// FileUploadParams.NewBody and must be closed once the request is done.
+type FileUploadBody struct {
+	*io.PipeReader
+
+	boundary string
+	length   int64
+}
+
+// Boundary returns the boundary string of the multipart body.
+func (b *FileUploadBody) Boundary() string {
+	return b.boundary
+}
+
+// ContentLength returns the length of the body in bytes, or -1 if the file's
+// size isn't known in advance.
+func (b *FileUploadBody) ContentLength() int64 {
+	return b.length
+}
+
+// NewBody returns a body streaming the file upload as it's sent.
+//
+// The file's content type is detected and checked against the types Stripe
+// accepts for the upload's Purpose before anything is sent. The body's length
+// is known when the file is an *os.File or a FileReader with a Len method,
+// like *bytes.Reader. The upload stops with the params' context's error if
+// it's done while the file is being sent.
+func (f *FileUploadParams) NewBody() (*FileUploadBody, error) {
+	file, filename, size, err := f.source()
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, fileUploadSniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	if err := f.validateType(head); err != nil {
+		return nil, err
+	}
+
+	body := &FileUploadBody{
+		boundary: multipart.NewWriter(ioutil.Discard).Boundary(),
+		length:   -1,
+	}
+
+	if size >= 0 {
+		counter := &countingWriter{}
+		err := f.writeMultipart(counter, body.boundary, filename, bytes.NewReader(nil), -1)
+		if err != nil {
+			return nil, err
+		}
+		body.length = counter.n + size
+	}
+
+	pr, pw := io.Pipe()
+	body.PipeReader = pr
+
+	go func() {
+		content := io.MultiReader(bytes.NewReader(head), file)
+		pw.CloseWithError(f.writeMultipart(pw, body.boundary, filename, content, size))
+	}()
+
+	return body, nil
+}
+
+// source returns the file to upload, its name and its size, which is -1 if it
+// isn't known.
+func (f *FileUploadParams) source() (io.Reader, string, int64, error) {
+	// Support both FileReader/Filename and File with
+	// the former being the newer preferred version
+	if f.FileReader != nil && f.Filename != "" {
+		size := int64(-1)
+		if r, ok := f.FileReader.(interface {
+			Len() int
+		}); ok {
+			size = int64(r.Len())
+		}
+		return f.FileReader, f.Filename, size, nil
+	}
+
+	if f.File != nil {
+		size := int64(-1)
+		if info, err := f.File.Stat(); err == nil && info.Mode().IsRegular() {
+			if offset, err := f.File.Seek(0, io.SeekCurrent); err == nil {
+				size = info.Size() - offset
+			}
+		}
+		return f.File, f.File.Name(), size, nil
+	}
+
+	return nil, "", 0, fmt.Errorf("Either FileReader and Filename, or File must be set to upload a file")
+}
+
+// validateType checks that a file starting with head is of a type accepted
+// for the upload's purpose.
+func (f *FileUploadParams) validateType(head []byte) error {
+	allowed, ok := fileUploadTypes[f.Purpose]
+	if !ok {
+		return nil
+	}
+
+	contentType := http.DetectContentType(head)
+	for _, t := range allowed {
+		if contentType == t {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Cannot upload a file of type %v for purpose %v, allowed types are %v",
+		contentType, f.Purpose, allowed)
+}
+
+// writeMultipart writes the multipart body of the upload of content to w. size
+// is the expected size of content, or -1 if it isn't known, and is only used
+// to report progress.
+func (f *FileUploadParams) writeMultipart(w io.Writer, boundary, filename string, content io.Reader, size int64) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	if len(f.Purpose) > 0 {
+		if err := writer.WriteField("purpose", string(f.Purpose)); err != nil {
+			return err
+		}
+	}
+
+	part, err := writer.CreateFormFile("file", filepath.Base(filename))
+	if err != nil {
+		return err
+	}
+
+	ctx := f.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	buf := make([]byte, 32*1024)
+	var sent int64
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if _, err := part.Write(buf[:n]); err != nil {
+				return err
+			}
+
+			sent += int64(n)
+			if f.Progress != nil {
+				f.Progress(sent, size)
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	// The body's announced length would be wrong otherwise.
+	if size >= 0 && sent != size {
+		return fmt.Errorf("File size changed during upload, expected %v bytes but read %v", size, sent)
+	}
+
+	return writer.Close()
+}
+
+// countingWriter counts the bytes written to it and discards them.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
 // UnmarshalJSON handles deserialization of a FileUpload.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (f *FileUpload) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		f.ID = id
+		return nil
+	}
+
 	type file FileUpload
 	var ff file
 	err := json.Unmarshal(data, &ff)
-	if err == nil {
-		*f = FileUpload(ff)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		f.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*f = FileUpload(ff)
 	return nil
 }
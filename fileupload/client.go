@@ -2,7 +2,6 @@
 package fileupload
 
 import (
-	"bytes"
 	"fmt"
 
 	stripe "github.com/stripe/stripe-go"
@@ -27,18 +26,22 @@ func New(params *stripe.FileUploadParams) (*stripe.FileUpload, error) {
 }
 
 func (c Client) New(params *stripe.FileUploadParams) (*stripe.FileUpload, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	if params == nil {
 		return nil, fmt.Errorf("params cannot be nil, and params.Purpose and params.File must be set")
 	}
 
-	body := &bytes.Buffer{}
-	boundary, err := params.AppendDetails(body)
+	body, err := params.NewBody()
 	if err != nil {
 		return nil, err
 	}
+	defer body.Close()
 
 	upload := &stripe.FileUpload{}
-	err = c.B.CallMultipart("POST", "/files", c.Key, boundary, body, &params.Params, upload)
+	err = c.B.CallMultipart("POST", "/files", c.Key, body.Boundary(), body, &params.Params, upload)
 
 	return upload, err
 }
@@ -51,6 +54,10 @@ func Get(id string, params *stripe.FileUploadParams) (*stripe.FileUpload, error)
 }
 
 func (c Client) Get(id string, params *stripe.FileUploadParams) (*stripe.FileUpload, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -75,25 +82,18 @@ func List(params *stripe.FileUploadListParams) *Iter {
 func (c Client) List(params *stripe.FileUploadListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.FileUpload, stripe.ListMeta, error) {
 		list := &stripe.FileUploadList{}
 		err := c.B.Call("GET", "/files", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -101,12 +101,12 @@ func (c Client) List(params *stripe.FileUploadListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.FileUpload]
 }
 
 // FileUpload returns the most recent FileUpload visited by a call to Next.
 func (i *Iter) FileUpload() *stripe.FileUpload {
-	return i.Current().(*stripe.FileUpload)
+	return i.Current()
 }
 
 func getC() Client {
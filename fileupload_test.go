@@ -0,0 +1,156 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+var testPDF = []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n%%EOF\n")
+
+func TestFileUploadParams_NewBody(t *testing.T) {
+	var progress [][2]int64
+	params := &FileUploadParams{
+		Purpose:    "dispute_evidence",
+		FileReader: bytes.NewReader(testPDF),
+		Filename:   "/tmp/evidence.pdf",
+		Progress: func(sent, total int64) {
+			progress = append(progress, [2]int64{sent, total})
+		},
+	}
+
+	body, err := params.NewBody()
+	assert.NoError(t, err)
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), body.ContentLength())
+	assert.Equal(t, [][2]int64{{int64(len(testPDF)), int64(len(testPDF))}}, progress)
+
+	reader := multipart.NewReader(bytes.NewReader(data), body.Boundary())
+
+	part, err := reader.NextPart()
+	assert.NoError(t, err)
+	assert.Equal(t, "purpose", part.FormName())
+	value, err := ioutil.ReadAll(part)
+	assert.NoError(t, err)
+	assert.Equal(t, "dispute_evidence", string(value))
+
+	part, err = reader.NextPart()
+	assert.NoError(t, err)
+	assert.Equal(t, "file", part.FormName())
+	assert.Equal(t, "evidence.pdf", part.FileName())
+	value, err = ioutil.ReadAll(part)
+	assert.NoError(t, err)
+	assert.Equal(t, testPDF, value)
+
+	_, err = reader.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestFileUploadParams_NewBodyUnknownLength(t *testing.T) {
+	f, err := ioutil.TempFile("", "upload")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	_, err = f.Write(testPDF)
+	assert.NoError(t, err)
+	_, err = f.Seek(0, io.SeekStart)
+	assert.NoError(t, err)
+
+	// A file's size is known from its metadata.
+	body, err := (&FileUploadParams{Purpose: "identity_document", File: f}).NewBody()
+	assert.NoError(t, err)
+	data, err := ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), body.ContentLength())
+
+	// A plain reader's isn't.
+	body, err = (&FileUploadParams{
+		FileReader: io.MultiReader(bytes.NewReader(testPDF)),
+		Filename:   "evidence.pdf",
+	}).NewBody()
+	assert.NoError(t, err)
+	_, err = ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), body.ContentLength())
+}
+
+func TestFileUploadParams_NewBodyInvalidType(t *testing.T) {
+	_, err := (&FileUploadParams{
+		Purpose:    "dispute_evidence",
+		FileReader: bytes.NewReader([]byte("just some text")),
+		Filename:   "evidence.txt",
+	}).NewBody()
+	assert.EqualError(t, err, "Cannot upload a file of type text/plain; charset=utf-8 for purpose "+
+		"dispute_evidence, allowed types are [application/pdf image/jpeg image/png]")
+
+	_, err = (&FileUploadParams{Purpose: "dispute_evidence"}).NewBody()
+	assert.Error(t, err)
+}
+
+func TestFileUploadParams_NewBodyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	params := &FileUploadParams{
+		Purpose:    "dispute_evidence",
+		FileReader: bytes.NewReader(testPDF),
+		Filename:   "evidence.pdf",
+	}
+	params.Context = ctx
+
+	body, err := params.NewBody()
+	assert.NoError(t, err)
+
+	_, err = ioutil.ReadAll(body)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestCallMultipart_StreamsFileUpload(t *testing.T) {
+	var contentLength int64
+	var file []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+
+		f, _, err := r.FormFile("file")
+		assert.NoError(t, err)
+		file, err = ioutil.ReadAll(f)
+		assert.NoError(t, err)
+
+		w.Write([]byte(`{"id":"file_123"}`))
+	}))
+	defer ts.Close()
+
+	c := &BackendConfiguration{
+		Type:       UploadsBackend,
+		URL:        ts.URL,
+		HTTPClient: &http.Client{},
+	}
+
+	params := &FileUploadParams{
+		Purpose:    "dispute_evidence",
+		FileReader: bytes.NewReader(testPDF),
+		Filename:   "evidence.pdf",
+	}
+	body, err := params.NewBody()
+	assert.NoError(t, err)
+	defer body.Close()
+
+	upload := &FileUpload{}
+	err = c.CallMultipart("POST", "/files", "sk_test_123", body.Boundary(), body, &params.Params, upload)
+	assert.NoError(t, err)
+	assert.Equal(t, "file_123", upload.ID)
+	assert.Equal(t, body.ContentLength(), contentLength)
+	assert.Equal(t, testPDF, file)
+}
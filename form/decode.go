@@ -0,0 +1,459 @@
+package form
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Unmarshaler is the interface implemented by types that can decode
+// themselves from a collection of form values.
+//
+// Like Appender, it's needed by the few types whose encoding isn't fully
+// described by their form tags. Implementations usually handle the values
+// they encode themselves, and decode the rest by calling UnmarshalPrefixed
+// with a pointer converted to a type without the UnmarshalForm method.
+type Unmarshaler interface {
+	// UnmarshalForm is invoked by the form package on any types found to
+	// implement Unmarshaler instead of decoding them from their form tags.
+	// keyParts are the parts of the key the type was encoded under.
+	UnmarshalForm(values url.Values, keyParts []string) error
+}
+
+// decodeNode is a key part in a tree of parsed form keys. For example,
+// "metadata[foo]=bar" is the "foo" child of the "metadata" child of the root
+// node, with the value "bar".
+type decodeNode struct {
+	values   []string
+	children map[string]*decodeNode
+}
+
+// child returns the child named name, creating it if needed.
+func (n *decodeNode) child(name string) *decodeNode {
+	if n.children == nil {
+		n.children = make(map[string]*decodeNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &decodeNode{}
+		n.children[name] = c
+	}
+	return c
+}
+
+// isLeaf reports whether the node has values but no children, which is what
+// scalar values are encoded as.
+func (n *decodeNode) isLeaf() bool {
+	return len(n.values) > 0 && len(n.children) == 0
+}
+
+// ---
+
+// Unmarshal decodes form values, like those of a request body parsed with
+// url.ParseQuery, into the struct pointed to by v based off the form tags
+// that it defines. It's the inverse of AppendTo.
+//
+// Values whose shape doesn't fit the field they're keyed under, like a single
+// value for a struct or nested keys for a scalar, are skipped, so that fields
+// sharing a key, like a product ID and the parameters of a new product, are
+// decoded from the form of the value they were encoded with. Values of the
+// right shape that can't be parsed, like "abc" for an integer, are errors.
+// The `empty`, `invert` and `zero` fields are only set when no other field
+// decoded a value for their key.
+//
+// Decoding into a map[string]interface{} keeps all the values as strings,
+// with nested keys as nested maps and the values of keys ending in "[]" as
+// slices.
+func Unmarshal(values url.Values, v interface{}) error {
+	return UnmarshalPrefixed(values, v, nil)
+}
+
+// UnmarshalPrefixed is the same as Unmarshal, but it decodes the values
+// under a prefix made of a slice of key parts.
+func UnmarshalPrefixed(values url.Values, v interface{}, keyParts []string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("Cannot unmarshal form values into non-pointer or nil %T", v)
+	}
+
+	n := parseValues(values)
+	for _, part := range keyParts {
+		n = n.children[part]
+		if n == nil {
+			return nil
+		}
+	}
+
+	d := &decoder{values: values}
+	_, err := d.decode(n, rv.Elem(), keyParts, nil)
+	return err
+}
+
+// parseKey splits a key like "a[b][c]" into its parts. Keys that aren't well
+// formed are a single part.
+func parseKey(key string) []string {
+	i := strings.IndexByte(key, '[')
+	if i <= 0 || !strings.HasSuffix(key, "]") {
+		return []string{key}
+	}
+
+	parts := []string{key[:i]}
+	for rest := key[i:]; rest != ""; {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return []string{key}
+		}
+		parts = append(parts, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return parts
+}
+
+// parseValues builds a tree of all the keys of values.
+func parseValues(values url.Values) *decodeNode {
+	root := &decodeNode{}
+	for key, vals := range values {
+		n := root
+		for _, part := range parseKey(key) {
+			n = n.child(part)
+		}
+		n.values = append(n.values, vals...)
+	}
+	return root
+}
+
+// ---
+
+var unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+var int64Type = reflect.TypeOf(int64(0))
+
+type decoder struct {
+	values url.Values
+}
+
+// decode decodes n into v, and reports whether it set anything.
+func (d *decoder) decode(n *decodeNode, v reflect.Value, keyParts []string, options *formOptions) (bool, error) {
+	if v.Kind() != reflect.Ptr && v.CanAddr() && v.Addr().Type().Implements(unmarshalerType) {
+		if err := v.Addr().Interface().(Unmarshaler).UnmarshalForm(d.values, keyParts); err != nil {
+			return false, err
+		}
+		return !v.IsZero(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		return d.decodeArrayOrSlice(n, v, keyParts, options)
+
+	case reflect.Interface:
+		return d.decodeInterface(n, v)
+
+	case reflect.Map:
+		return d.decodeMap(n, v, keyParts)
+
+	case reflect.Ptr:
+		return d.decodePtr(n, v, keyParts, options)
+
+	case reflect.Struct:
+		return d.decodeStruct(n, v, keyParts)
+	}
+
+	if !n.isLeaf() {
+		return false, nil
+	}
+	return d.decodeScalar(n.values[0], v, keyParts, options)
+}
+
+func (d *decoder) decodeArrayOrSlice(n *decodeNode, v reflect.Value, keyParts []string, options *formOptions) (bool, error) {
+	// Indexed arrays are keyed with integers, and others with an empty key
+	// part repeated for every item, which only works for scalar items.
+	var indexes []int
+	var items []*decodeNode
+	if options != nil && options.IndexedArray {
+		for name := range n.children {
+			if i, err := strconv.Atoi(name); err == nil && i >= 0 {
+				indexes = append(indexes, i)
+			}
+		}
+		sort.Ints(indexes)
+
+		for _, i := range indexes {
+			items = append(items, n.children[strconv.Itoa(i)])
+		}
+	} else if c, ok := n.children[""]; ok {
+		for i, val := range c.values {
+			indexes = append(indexes, i)
+			items = append(items, &decodeNode{values: []string{val}})
+		}
+	}
+
+	if len(items) == 0 {
+		return false, nil
+	}
+
+	// Zero items aren't encoded, so the items of a slice are packed, but
+	// those of a fixed size array are kept at their index.
+	if v.Kind() == reflect.Slice {
+		v.Set(reflect.MakeSlice(v.Type(), len(items), len(items)))
+		for i := range indexes {
+			indexes[i] = i
+		}
+	} else if last := indexes[len(indexes)-1]; last >= v.Len() {
+		return false, fmt.Errorf("Cannot decode %v into %v: index out of range",
+			FormatKey(append(keyParts, strconv.Itoa(last))), v.Type())
+	}
+
+	for i, item := range items {
+		itemKeyParts := append(keyParts, "")
+		if options != nil && options.IndexedArray {
+			itemKeyParts = append(keyParts, strconv.Itoa(indexes[i]))
+		}
+		if _, err := d.decode(item, v.Index(indexes[i]), itemKeyParts, nil); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// decodeInterface decodes values into an empty interface as strings, the
+// values of keys ending in "[]" as slices of them, and nested keys as maps.
+func (d *decoder) decodeInterface(n *decodeNode, v reflect.Value) (bool, error) {
+	if v.NumMethod() != 0 {
+		return false, nil
+	}
+
+	val := decodeUntyped(n)
+	if val == nil {
+		return false, nil
+	}
+	v.Set(reflect.ValueOf(val))
+	return true, nil
+}
+
+func decodeUntyped(n *decodeNode) interface{} {
+	if len(n.children) == 0 {
+		if len(n.values) == 0 {
+			return nil
+		}
+		return n.values[0]
+	}
+
+	if list, ok := n.children[""]; ok && len(n.children) == 1 && list.isLeaf() {
+		items := make([]interface{}, len(list.values))
+		for i, val := range list.values {
+			items[i] = val
+		}
+		return items
+	}
+
+	m := make(map[string]interface{}, len(n.children))
+	for name, c := range n.children {
+		if val := decodeUntyped(c); val != nil {
+			m[name] = val
+		}
+	}
+	return m
+}
+
+func (d *decoder) decodeMap(n *decodeNode, v reflect.Value, keyParts []string) (bool, error) {
+	t := v.Type()
+	if t.Key().Kind() != reflect.String || len(n.children) == 0 {
+		return false, nil
+	}
+
+	set := false
+	for name, c := range n.children {
+		elem := reflect.New(t.Elem()).Elem()
+		elemSet, err := d.decode(c, elem, append(keyParts, name), nil)
+		if err != nil {
+			return false, err
+		}
+		if elemSet {
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(t))
+			}
+			v.SetMapIndex(reflect.ValueOf(name).Convert(t.Key()), elem)
+			set = true
+		}
+	}
+	return set, nil
+}
+
+func (d *decoder) decodePtr(n *decodeNode, v reflect.Value, keyParts []string, options *formOptions) (bool, error) {
+	elemType := v.Type().Elem()
+
+	// Decode values set with Clear and Now back into pointers returned by
+	// them so that they're encoded the same way.
+	if n.isLeaf() {
+		switch {
+		case n.values[0] == "" && elemType.Kind() != reflect.String && isScalarKind(elemType.Kind()):
+			v.Set(reflect.ValueOf(clearPointer(elemType)))
+			return true, nil
+		case n.values[0] == "now" && elemType == int64Type:
+			v.Set(reflect.ValueOf(Now()))
+			return true, nil
+		}
+	}
+
+	elem := v
+	if v.IsNil() {
+		elem = reflect.New(elemType)
+	}
+
+	set, err := d.decode(n, elem.Elem(), keyParts, options)
+	if err != nil {
+		return false, err
+	}
+
+	// Scalars are always set if their value was decoded, but only set
+	// structs and other containers if something was decoded into them so
+	// that pointers to them aren't allocated needlessly.
+	if set && v.IsNil() {
+		v.Set(elem)
+	}
+	return set, nil
+}
+
+func (d *decoder) decodeScalar(val string, v reflect.Value, keyParts []string, options *formOptions) (bool, error) {
+	if v.Kind() == reflect.Bool && options != nil {
+		switch {
+		case options.Empty:
+			v.SetBool(val == "")
+		case options.Invert:
+			v.SetBool(val == strconv.FormatBool(false))
+		case options.Zero:
+			v.SetBool(val == "0")
+		}
+		return v.Bool(), nil
+	}
+
+	var err error
+	switch v.Kind() {
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(val)
+		if err == nil {
+			v.SetBool(b)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(val, v.Type().Bits())
+		if err == nil {
+			v.SetFloat(f)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(val, 10, v.Type().Bits())
+		if err == nil {
+			v.SetInt(i)
+		}
+
+	case reflect.String:
+		v.SetString(val)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		u, err = strconv.ParseUint(val, 10, v.Type().Bits())
+		if err == nil {
+			v.SetUint(u)
+		}
+
+	default:
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("Cannot decode %v into %v: %v",
+			FormatKey(keyParts), v.Type(), err)
+	}
+	return true, nil
+}
+
+func (d *decoder) decodeStruct(n *decodeNode, v reflect.Value, keyParts []string) (bool, error) {
+	se := getCachedOrBuildStructEncoder(v.Type())
+
+	set := false
+
+	// consumed are the keys a field other than an `empty`, `invert` or `zero`
+	// one decoded a value from which it would encode again.
+	consumed := make(map[string]bool)
+
+	decodeField := func(f *field) error {
+		fieldV := v.Field(f.index)
+
+		// Like when encoding, the fields of a struct tagged with the
+		// wildcard are named at the same level as the current struct's.
+		if f.formName == "*" {
+			kind := fieldV.Kind()
+			if kind == reflect.Ptr {
+				kind = fieldV.Type().Elem().Kind()
+			}
+			if kind != reflect.Struct {
+				return nil
+			}
+
+			fieldSet, err := d.decode(n, fieldV, keyParts, nil)
+			set = set || fieldSet
+			return err
+		}
+
+		c, ok := n.children[f.formName]
+		if !ok || f.formName == "" || !fieldV.CanSet() {
+			return nil
+		}
+
+		fieldSet, err := d.decode(c, fieldV, append(keyParts, f.formName), f.options)
+		if err != nil {
+			return err
+		}
+		if fieldSet {
+			set = true
+			if f.isPtr || !fieldV.IsZero() {
+				consumed[f.formName] = true
+			}
+		}
+		return nil
+	}
+
+	for _, f := range se.fields {
+		if isOptionField(f) {
+			continue
+		}
+		if err := decodeField(f); err != nil {
+			return false, err
+		}
+	}
+
+	for _, f := range se.fields {
+		if !isOptionField(f) || consumed[f.formName] {
+			continue
+		}
+		if err := decodeField(f); err != nil {
+			return false, err
+		}
+	}
+
+	return set, nil
+}
+
+// isOptionField reports whether a field only encodes a special value, like
+// a `zero` field.
+func isOptionField(f *field) bool {
+	return f.options != nil && (f.options.Empty || f.options.Invert || f.options.Zero)
+}
+
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.String,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
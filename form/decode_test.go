@@ -0,0 +1,288 @@
+package form
+
+import (
+	"math/rand"
+	"net/url"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// roundTripStruct has the kinds of fields found in parameters, including
+// fields sharing a key, for checking that decoding and encoding again yields
+// the same values.
+type roundTripStruct struct {
+	Active    *bool `form:"active"`
+	NotActive bool  `form:"active,invert"`
+
+	Amount     int64 `form:"amount"`
+	AmountZero bool  `form:"amount,zero"`
+
+	Amounts [2]int64 `form:"amounts,indexed"`
+
+	Description      *string `form:"description"`
+	DescriptionEmpty bool    `form:"description,empty"`
+
+	Float64 float64 `form:"float64"`
+
+	Items []*roundTripItem `form:"items,indexed"`
+
+	Metadata map[string]string `form:"metadata"`
+
+	Product   *roundTripItem `form:"product"`
+	ProductID *string        `form:"product"`
+
+	Units *uint64 `form:"units"`
+
+	Slice []string `form:"slice"`
+
+	TrialEnd *int64 `form:"trial_end"`
+
+	Flat roundTripItem `form:"*"`
+}
+
+type roundTripItem struct {
+	Name     string `form:"name"`
+	Quantity *int64 `form:"quantity"`
+}
+
+func randomString(r *rand.Rand) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz_"
+	b := make([]byte, 1+r.Intn(8))
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// randomItem returns an item with at least a name, as items of indexed arrays
+// that are encoded as nothing shift the indexes of the following ones.
+func randomItem(r *rand.Rand) *roundTripItem {
+	item := &roundTripItem{Name: randomString(r)}
+	if r.Intn(2) == 0 {
+		quantity := r.Int63n(1000) - 500
+		item.Quantity = &quantity
+	}
+	return item
+}
+
+// Generate implements quick.Generator. Fields sharing a key are never both
+// set, as what they'd be encoded as is ambiguous.
+func (roundTripStruct) Generate(r *rand.Rand, size int) reflect.Value {
+	s := roundTripStruct{}
+
+	switch r.Intn(3) {
+	case 0:
+		active := r.Intn(2) == 0
+		s.Active = &active
+	case 1:
+		s.NotActive = true
+	}
+
+	switch r.Intn(3) {
+	case 0:
+		s.Amount = r.Int63n(1000) - 500
+	case 1:
+		s.AmountZero = true
+	}
+
+	s.Amounts = [2]int64{r.Int63n(100), r.Int63n(100)}
+
+	switch r.Intn(3) {
+	case 0:
+		description := randomString(r)
+		s.Description = &description
+	case 1:
+		s.DescriptionEmpty = true
+	}
+
+	s.Float64 = r.NormFloat64() * 1000
+
+	for i := r.Intn(size + 1); i > 0; i-- {
+		s.Items = append(s.Items, randomItem(r))
+	}
+
+	for i := r.Intn(size + 1); i > 0; i-- {
+		if s.Metadata == nil {
+			s.Metadata = make(map[string]string)
+		}
+		s.Metadata[randomString(r)] = randomString(r)
+	}
+
+	switch r.Intn(3) {
+	case 0:
+		s.Product = randomItem(r)
+	case 1:
+		productID := randomString(r)
+		s.ProductID = &productID
+	}
+
+	switch r.Intn(3) {
+	case 0:
+		quantity := uint64(r.Int63n(100))
+		s.Units = &quantity
+	case 1:
+		s.Units = Clear[uint64]()
+	}
+
+	for i := r.Intn(size + 1); i > 0; i-- {
+		s.Slice = append(s.Slice, randomString(r))
+	}
+
+	switch r.Intn(3) {
+	case 0:
+		trialEnd := r.Int63()
+		s.TrialEnd = &trialEnd
+	case 1:
+		s.TrialEnd = Now()
+	}
+
+	s.Flat = *randomItem(r)
+
+	return reflect.ValueOf(s)
+}
+
+func TestUnmarshal(t *testing.T) {
+	values, err := url.ParseQuery("bool=true&int64_ptr=0&float64=1.5&uint8=7&string=foo&" +
+		"slice[]=a&slice[]=b&slice_indexed[1]=d&slice_indexed[0]=c&array_indexed[1]=x&" +
+		"map[foo][bar]=baz&map[qux]=&substruct[subsubstruct][string]=sub&" +
+		"subsubstruct[string]=flat&emptied=&inverted=false&zeroed=0&ignored=123")
+	assert.NoError(t, err)
+
+	var data testStruct
+	assert.NoError(t, Unmarshal(values, &data))
+
+	assert.True(t, data.Bool)
+	assert.Equal(t, int64(0), *data.Int64Ptr)
+	assert.Equal(t, 1.5, data.Float64)
+	assert.Equal(t, uint8(7), data.Uuint8)
+	assert.Equal(t, "foo", data.String)
+	assert.Equal(t, []string{"a", "b"}, data.Slice)
+	assert.Equal(t, []string{"c", "d"}, data.SliceIndexed)
+	assert.Equal(t, [3]string{"", "x", ""}, data.ArrayIndexed)
+	assert.Equal(t, map[string]interface{}{
+		"foo": map[string]interface{}{"bar": "baz"},
+		"qux": "",
+	}, data.Map)
+	assert.Equal(t, "sub", data.SubStruct.SubSubStruct.String)
+	assert.Equal(t, "flat", data.SubStructFlat.SubSubStruct.String)
+	assert.Equal(t, "flat", data.SubStructFlatPtr.SubSubStruct.String)
+	assert.True(t, data.Emptied)
+	assert.True(t, data.Inverted)
+	assert.True(t, data.Zeroed)
+	assert.Equal(t, "", data.Ignored)
+
+	// Pointers are only allocated for values found in the form.
+	assert.Nil(t, data.BoolPtr)
+	assert.Nil(t, data.SubStructPtr)
+	assert.Nil(t, data.SlicePtr)
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	var data testStruct
+	err := Unmarshal(url.Values{"int8": {"1000"}}, &data)
+	assert.EqualError(t, err,
+		`Cannot decode int8 into int8: strconv.ParseInt: parsing "1000": value out of range`)
+
+	err = Unmarshal(url.Values{"array_indexed[3]": {"x"}}, &data)
+	assert.EqualError(t, err, "Cannot decode array_indexed[3] into [3]string: index out of range")
+
+	err = Unmarshal(url.Values{}, data)
+	assert.Error(t, err)
+}
+
+func TestUnmarshal_Specials(t *testing.T) {
+	var data testStruct
+	values := url.Values{"float64_ptr": {""}, "int64_ptr": {"now"}, "string_ptr": {""}}
+	assert.NoError(t, Unmarshal(values, &data))
+
+	value, ok := specialValue(reflect.ValueOf(data.Float64Ptr))
+	assert.True(t, ok)
+	assert.Equal(t, "", value)
+	value, ok = specialValue(reflect.ValueOf(data.Int64Ptr))
+	assert.True(t, ok)
+	assert.Equal(t, "now", value)
+	assert.Equal(t, "", *data.StringPtr)
+	_, ok = specialValue(reflect.ValueOf(data.StringPtr))
+	assert.False(t, ok)
+}
+
+func TestUnmarshal_SharedKeys(t *testing.T) {
+	var data roundTripStruct
+	assert.NoError(t, Unmarshal(url.Values{"product": {"prod_123"}, "active": {"false"}}, &data))
+	assert.Equal(t, "prod_123", *data.ProductID)
+	assert.Nil(t, data.Product)
+	assert.False(t, *data.Active)
+	assert.False(t, data.NotActive)
+
+	data = roundTripStruct{}
+	assert.NoError(t, Unmarshal(url.Values{"product[name]": {"T-shirt"}, "amount": {"0"}}, &data))
+	assert.Equal(t, "T-shirt", data.Product.Name)
+	assert.Nil(t, data.ProductID)
+	assert.Equal(t, int64(0), data.Amount)
+	assert.True(t, data.AmountZero)
+}
+
+func TestUnmarshal_Untyped(t *testing.T) {
+	values, err := url.ParseQuery("expand[]=customer&expand[]=invoice&" +
+		"items[0][plan]=gold&metadata[foo]=bar&a[b=malformed")
+	assert.NoError(t, err)
+
+	var data map[string]interface{}
+	assert.NoError(t, Unmarshal(values, &data))
+	assert.Equal(t, map[string]interface{}{
+		"expand":   []interface{}{"customer", "invoice"},
+		"items":    map[string]interface{}{"0": map[string]interface{}{"plan": "gold"}},
+		"metadata": map[string]interface{}{"foo": "bar"},
+		"a[b":      "malformed",
+	}, data)
+}
+
+type testUnmarshaler struct {
+	Code   string `form:"-"`
+	String string `form:"string"`
+}
+
+func (u *testUnmarshaler) UnmarshalForm(values url.Values, keyParts []string) error {
+	u.Code = values.Get(FormatKey(append(keyParts, "code")))
+
+	type unmarshaler testUnmarshaler
+	return UnmarshalPrefixed(values, (*unmarshaler)(u), keyParts)
+}
+
+func TestUnmarshal_Unmarshaler(t *testing.T) {
+	var data struct {
+		Unmarshaler *testUnmarshaler `form:"unmarshaler"`
+	}
+	values := url.Values{"unmarshaler[code]": {"123"}, "unmarshaler[string]": {"foo"}}
+	assert.NoError(t, Unmarshal(values, &data))
+	assert.Equal(t, &testUnmarshaler{Code: "123", String: "foo"}, data.Unmarshaler)
+
+	var u testUnmarshaler
+	assert.NoError(t, UnmarshalPrefixed(values, &u, []string{"unmarshaler"}))
+	assert.Equal(t, testUnmarshaler{Code: "123", String: "foo"}, u)
+}
+
+func TestUnmarshal_RoundTrip(t *testing.T) {
+	roundTrip := func(s roundTripStruct) bool {
+		form := &Values{}
+		AppendTo(form, &s)
+
+		var decoded roundTripStruct
+		if err := Unmarshal(form.ToValues(), &decoded); err != nil {
+			t.Log(err)
+			return false
+		}
+
+		again := &Values{}
+		AppendTo(again, &decoded)
+		if !reflect.DeepEqual(form.ToValues(), again.ToValues()) {
+			t.Logf("%v != %v", form.Encode(), again.Encode())
+			return false
+		}
+		return true
+	}
+	assert.NoError(t, quick.Check(roundTrip, &quick.Config{MaxCount: 500}))
+}
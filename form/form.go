@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -147,6 +148,80 @@ func FormatKey(parts []string) string {
 	return key
 }
 
+// Scalar is the set of types whose parameters can be cleared with Clear.
+type Scalar interface {
+	~bool | ~float32 | ~float64 |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~string
+}
+
+// specials maps the addresses of the pointers returned by Clear and Now to the
+// value they're encoded as. A pointer's entry is deleted when it's garbage
+// collected, before its address can be reused.
+var specials sync.Map // map[uintptr]string
+
+// paddedTypes caches the types allocated by newSpecial, by the type they pad.
+var paddedTypes sync.Map // map[reflect.Type]reflect.Type
+
+// Clear returns a pointer to T that's encoded as an empty string, which Stripe
+// interprets as unsetting a parameter. Every call returns a new pointer, which
+// is identified by its address: it's encoded as what it points to instead if
+// a value other than the zero value is written through it.
+func Clear[T Scalar]() *T {
+	return clearPointer(reflect.TypeOf((*T)(nil)).Elem()).(*T)
+}
+
+// clearPointer returns a pointer to t like Clear does.
+func clearPointer(t reflect.Type) interface{} {
+	return newSpecial(t, "")
+}
+
+// Now returns a pointer that's encoded as "now", which Stripe interprets as
+// the time a request is made for timestamp parameters that support it. Like
+// with Clear, every call returns a new pointer, and writing a timestamp
+// through it makes it encoded as that timestamp.
+func Now() *int64 {
+	return newSpecial(reflect.TypeOf(int64(0)), "now").(*int64)
+}
+
+// newSpecial returns a new pointer to t that's encoded as value while it
+// points to the zero value.
+func newSpecial(t reflect.Type, value string) interface{} {
+	// The pointer is to the first field of a struct padded past the sizes
+	// served by the tiny allocator, as the finalizers of objects it
+	// allocates may never run.
+	padded, ok := paddedTypes.Load(t)
+	if !ok {
+		padded, _ = paddedTypes.LoadOrStore(t, reflect.StructOf([]reflect.StructField{
+			{Name: "Value", Type: t},
+			{Name: "Padding", Type: reflect.TypeOf([16]byte{})},
+		}))
+	}
+
+	v := reflect.New(padded.(reflect.Type))
+	addr := v.Pointer()
+	specials.Store(addr, value)
+	runtime.SetFinalizer(v.Interface(), func(interface{}) {
+		specials.Delete(addr)
+	})
+	return v.Elem().Field(0).Addr().Interface()
+}
+
+// specialValue returns the value a pointer is encoded as if it's one
+// returned by Clear or Now that still points to the zero value.
+func specialValue(v reflect.Value) (string, bool) {
+	if !isScalarKind(v.Type().Elem().Kind()) || !v.Elem().IsZero() {
+		return "", false
+	}
+
+	value, ok := specials.Load(v.Pointer())
+	if !ok {
+		return "", false
+	}
+	return value.(string), true
+}
+
 // ---
 
 func boolEncoder(values *Values, v reflect.Value, keyParts []string, encodeZero bool, options *formOptions) {
@@ -204,6 +279,10 @@ func buildPtrEncoder(t reflect.Type) encoderFunc {
 		if v.IsNil() {
 			return
 		}
+		if value, ok := specialValue(v); ok {
+			values.Add(FormatKey(keyParts), value)
+			return
+		}
 		elemF(values, v.Elem(), keyParts, true, options)
 	}
 }
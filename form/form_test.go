@@ -2,8 +2,10 @@ package form
 
 import (
 	"net/url"
+	"runtime"
 	"sync"
 	"testing"
+	"time"
 
 	assert "github.com/stretchr/testify/require"
 )
@@ -351,6 +353,70 @@ func TestAppendTo_ZeroValues(t *testing.T) {
 	assert.Equal(t, &Values{}, form)
 }
 
+func TestAppendTo_Specials(t *testing.T) {
+	form := &Values{}
+	zero := int64(0)
+	data := &testStruct{
+		Float64Ptr: Clear[float64](),
+		Int64Ptr:   Now(),
+		IntPtr:     new(int),
+		StringPtr:  Clear[string](),
+		Map:        map[string]interface{}{"cleared": Clear[int64](), "zero": &zero},
+	}
+	AppendTo(form, data)
+	assert.Equal(t, []string{""}, form.Get("float64_ptr"))
+	assert.Equal(t, []string{"now"}, form.Get("int64_ptr"))
+	assert.Equal(t, []string{"0"}, form.Get("int_ptr"))
+	assert.Equal(t, []string{""}, form.Get("string_ptr"))
+	assert.Equal(t, []string{""}, form.Get("map[cleared]"))
+	assert.Equal(t, []string{"0"}, form.Get("map[zero]"))
+
+}
+
+func TestAppendTo_SpecialsWrittenThrough(t *testing.T) {
+	cleared := Clear[float64]()
+	*cleared = 1.5
+	now := Now()
+	*now = 1234567890
+
+	// Writes through a pointer don't leak to those returned to others.
+	assert.Equal(t, 0.0, *Clear[float64]())
+	assert.Equal(t, int64(0), *Now())
+
+	form := &Values{}
+	AppendTo(form, &testStruct{Float64Ptr: cleared, Int64Ptr: now})
+	assert.Equal(t, []string{"1.5000"}, form.Get("float64_ptr"))
+	assert.Equal(t, []string{"1234567890"}, form.Get("int64_ptr"))
+
+	form = &Values{}
+	AppendTo(form, &testStruct{Float64Ptr: Clear[float64](), Int64Ptr: Now()})
+	assert.Equal(t, []string{""}, form.Get("float64_ptr"))
+	assert.Equal(t, []string{"now"}, form.Get("int64_ptr"))
+}
+
+func TestSpecials_Collected(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		Now()
+	}
+
+	// Finalizers run in their own goroutine, some time after a collection.
+	count := 0
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+
+		count = 0
+		specials.Range(func(key, value interface{}) bool {
+			count++
+			return true
+		})
+		if count < 100 {
+			return
+		}
+	}
+	t.Errorf("Expected garbage collected pointers to be unregistered, got %v registered", count)
+}
+
 func TestAppendToPrefixed(t *testing.T) {
 	form := &Values{}
 	data := &testStruct{String: "foo"}
@@ -15,29 +15,30 @@ type InvoiceBilling string
 type InvoiceParams struct {
 	Params         `form:"*"`
 	Billing        InvoiceBilling `form:"billing"`
-	Closed         bool           `form:"closed"`
+	Closed         *bool          `form:"closed"`
 	Customer       string         `form:"customer"`
 	DaysUntilDue   uint64         `form:"days_until_due"`
 	Desc           string         `form:"description"`
 	DueDate        int64          `form:"due_date"`
-	Fee            uint64         `form:"application_fee"`
-	FeeZero        bool           `form:"application_fee,zero"`
+	Fee            *uint64        `form:"application_fee"`
+	FeeZero        bool           `form:"application_fee,zero"` // Deprecated: use Fee
 	Forgive        bool           `form:"forgiven"`
-	NoClosed       bool           `form:"closed,invert"`
+	NoClosed       bool           `form:"closed,invert"` // Deprecated: use Closed
 	Paid           bool           `form:"paid"`
 	Statement      string         `form:"statement_descriptor"`
 	Sub            string         `form:"subscription"`
-	TaxPercent     float64        `form:"tax_percent"`
-	TaxPercentZero bool           `form:"tax_percent,zero"`
+	TaxPercent     *float64       `form:"tax_percent"`
+	TaxPercentZero bool           `form:"tax_percent,zero"` // Deprecated: use TaxPercent
 
 	// These are all for exclusive use by GetNext.
 
 	SubItems         []*SubItemsParams `form:"subscription_items,indexed"`
-	SubNoProrate     bool              `form:"subscription_prorate,invert"`
+	SubProrate       *bool             `form:"subscription_prorate"`
+	SubNoProrate     bool              `form:"subscription_prorate,invert"` // Deprecated: use SubProrate
 	SubPlan          string            `form:"subscription_plan"`
 	SubProrationDate int64             `form:"subscription_proration_date"`
-	SubQuantity      uint64            `form:"subscription_quantity"`
-	SubQuantityZero  bool              `form:"subscription_quantity,zero"`
+	SubQuantity      *uint64           `form:"subscription_quantity"`
+	SubQuantityZero  bool              `form:"subscription_quantity,zero"` // Deprecated: use SubQuantity
 	SubTrialEnd      int64             `form:"subscription_trial_end"`
 }
 
@@ -69,47 +70,59 @@ type InvoiceLineListParams struct {
 // Invoice is the resource representing a Stripe invoice.
 // For more details see https://stripe.com/docs/api#invoice_object.
 type Invoice struct {
-	Amount        int64             `json:"amount_due"`
-	Attempted     bool              `json:"attempted"`
-	Attempts      uint64            `json:"attempt_count"`
-	Billing       InvoiceBilling    `json:"billing"`
-	Charge        *Charge           `json:"charge"`
-	Closed        bool              `json:"closed"`
-	Currency      Currency          `json:"currency"`
-	Customer      *Customer         `json:"customer"`
-	Date          int64             `json:"date"`
-	Desc          string            `json:"description"`
-	Discount      *Discount         `json:"discount"`
-	DueDate       int64             `json:"due_date"`
-	End           int64             `json:"period_end"`
-	EndBalance    int64             `json:"ending_balance"`
-	Fee           uint64            `json:"application_fee"`
-	Forgive       bool              `json:"forgiven"`
-	ID            string            `json:"id"`
-	Lines         *InvoiceLineList  `json:"lines"`
-	Live          bool              `json:"livemode"`
-	Meta          map[string]string `json:"metadata"`
-	NextAttempt   int64             `json:"next_payment_attempt"`
-	Number        string            `json:"number"`
-	Paid          bool              `json:"paid"`
-	ReceiptNumber string            `json:"receipt_number"`
-	Start         int64             `json:"period_start"`
-	StartBalance  int64             `json:"starting_balance"`
-	Statement     string            `json:"statement_descriptor"`
-	Sub           string            `json:"subscription"`
-	Subtotal      int64             `json:"subtotal"`
-	Tax           int64             `json:"tax"`
-	TaxPercent    float64           `json:"tax_percent"`
-	Total         int64             `json:"total"`
-	Webhook       int64             `json:"webhooks_delivered_at"`
+	Amount        int64                 `json:"amount_due"`
+	Attempted     bool                  `json:"attempted"`
+	Attempts      uint64                `json:"attempt_count"`
+	Billing       InvoiceBilling        `json:"billing"`
+	Charge        *Expandable[Charge]   `json:"charge"`
+	Closed        bool                  `json:"closed"`
+	Currency      Currency              `json:"currency"`
+	Customer      *Expandable[Customer] `json:"customer"`
+	Date          int64                 `json:"date"`
+	Desc          string                `json:"description"`
+	Discount      *Discount             `json:"discount"`
+	DueDate       int64                 `json:"due_date"`
+	End           int64                 `json:"period_end"`
+	EndBalance    int64                 `json:"ending_balance"`
+	Fee           uint64                `json:"application_fee"`
+	Forgive       bool                  `json:"forgiven"`
+	ID            string                `json:"id"`
+	Lines         *InvoiceLineList      `json:"lines"`
+	Live          bool                  `json:"livemode"`
+	Meta          map[string]string     `json:"metadata"`
+	NextAttempt   int64                 `json:"next_payment_attempt"`
+	Number        string                `json:"number"`
+	Paid          bool                  `json:"paid"`
+	ReceiptNumber string                `json:"receipt_number"`
+	Start         int64                 `json:"period_start"`
+	StartBalance  int64                 `json:"starting_balance"`
+	Statement     string                `json:"statement_descriptor"`
+	Sub           string                `json:"subscription"`
+	Subtotal      int64                 `json:"subtotal"`
+	Tax           int64                 `json:"tax"`
+	TaxPercent    float64               `json:"tax_percent"`
+	Total         int64                 `json:"total"`
+	Webhook       int64                 `json:"webhooks_delivered_at"`
 }
 
-// InvoiceList is a list of invoices as retrieved from a list endpoint.
-type InvoiceList struct {
-	ListMeta
-	Values []*Invoice `json:"data"`
+// AmountMoney returns the amount due of the invoice in its currency.
+func (i *Invoice) AmountMoney() Money {
+	return Money{Amount: i.Amount, Currency: i.Currency}
+}
+
+// SubtotalMoney returns the subtotal of the invoice in its currency.
+func (i *Invoice) SubtotalMoney() Money {
+	return Money{Amount: i.Subtotal, Currency: i.Currency}
+}
+
+// TotalMoney returns the total of the invoice in its currency.
+func (i *Invoice) TotalMoney() Money {
+	return Money{Amount: i.Total, Currency: i.Currency}
 }
 
+// InvoiceList is a list of invoices as retrieved from a list endpoint.
+type InvoiceList = List[*Invoice]
+
 // InvoiceLine is the resource representing a Stripe invoice line item.
 // For more details see https://stripe.com/docs/api#invoice_line_item_object.
 type InvoiceLine struct {
@@ -135,10 +148,7 @@ type Period struct {
 }
 
 // InvoiceLineList is a list object for invoice line items.
-type InvoiceLineList struct {
-	ListMeta
-	Values []*InvoiceLine `json:"data"`
-}
+type InvoiceLineList = List[*InvoiceLine]
 
 // InvoicePayParams is the set of parameters that can be used when
 // paying invoices. For more details, see:
@@ -152,15 +162,18 @@ type InvoicePayParams struct {
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (i *Invoice) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		i.ID = id
+		return nil
+	}
+
 	type invoice Invoice
 	var ii invoice
 	err := json.Unmarshal(data, &ii)
-	if err == nil {
-		*i = Invoice(ii)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		i.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*i = Invoice(ii)
 	return nil
 }
@@ -26,6 +26,10 @@ func New(params *stripe.InvoiceParams) (*stripe.Invoice, error) {
 }
 
 func (c Client) New(params *stripe.InvoiceParams) (*stripe.Invoice, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -42,6 +46,10 @@ func Get(id string, params *stripe.InvoiceParams) (*stripe.Invoice, error) {
 }
 
 func (c Client) Get(id string, params *stripe.InvoiceParams) (*stripe.Invoice, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -64,6 +72,10 @@ func Pay(id string, params *stripe.InvoicePayParams) (*stripe.Invoice, error) {
 }
 
 func (c Client) Pay(id string, params *stripe.InvoicePayParams) (*stripe.Invoice, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -86,6 +98,10 @@ func Update(id string, params *stripe.InvoiceParams) (*stripe.Invoice, error) {
 }
 
 func (c Client) Update(id string, params *stripe.InvoiceParams) (*stripe.Invoice, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -108,6 +124,10 @@ func GetNext(params *stripe.InvoiceParams) (*stripe.Invoice, error) {
 }
 
 func (c Client) GetNext(params *stripe.InvoiceParams) (*stripe.Invoice, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -126,25 +146,18 @@ func List(params *stripe.InvoiceListParams) *Iter {
 func (c Client) List(params *stripe.InvoiceListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Invoice, stripe.ListMeta, error) {
 		list := &stripe.InvoiceList{}
 		err := c.B.Call("GET", "/invoices", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -157,19 +170,13 @@ func ListLines(params *stripe.InvoiceLineListParams) *LineIter {
 func (c Client) ListLines(params *stripe.InvoiceLineListParams) *LineIter {
 	body := &form.Values{}
 	var lp *stripe.ListParams = &params.ListParams
-	var p *stripe.Params = params.ToParams()
 	form.AppendTo(body, params)
 
-	return &LineIter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &LineIter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.InvoiceLine, stripe.ListMeta, error) {
 		list := &stripe.InvoiceLineList{}
 		err := c.B.Call("GET", fmt.Sprintf("/invoices/%v/lines", params.ID), c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -177,26 +184,26 @@ func (c Client) ListLines(params *stripe.InvoiceLineListParams) *LineIter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Invoice]
 }
 
 // Invoice returns the most recent Invoice
 // visited by a call to Next.
 func (i *Iter) Invoice() *stripe.Invoice {
-	return i.Current().(*stripe.Invoice)
+	return i.Current()
 }
 
 // LineIter is an iterator for lists of InvoiceLines.
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type LineIter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.InvoiceLine]
 }
 
 // InvoiceLine returns the most recent InvoiceLine
 // visited by a call to Next.
 func (i *LineIter) InvoiceLine() *stripe.InvoiceLine {
-	return i.Current().(*stripe.InvoiceLine)
+	return i.Current()
 }
 
 func getC() Client {
@@ -6,14 +6,14 @@ import "encoding/json"
 // For more details see https://stripe.com/docs/api#create_invoiceitem and https://stripe.com/docs/api#update_invoiceitem.
 type InvoiceItemParams struct {
 	Params         `form:"*"`
-	Amount         int64    `form:"amount"`
-	AmountZero     bool     `form:"amount,zero"`
+	Amount         *int64   `form:"amount"`
+	AmountZero     bool     `form:"amount,zero"` // Deprecated: use Amount
 	Currency       Currency `form:"currency"`
-	Customer       string   `form:"customer"`
+	Customer       string   `form:"customer" validate:"required"`
 	Desc           string   `form:"description"`
-	Discountable   bool     `form:"discountable"`
+	Discountable   *bool    `form:"discountable"`
 	Invoice        string   `form:"invoice"`
-	NoDiscountable bool     `form:"discountable,invert"`
+	NoDiscountable bool     `form:"discountable,invert"` // Deprecated: use Discountable
 	Sub            string   `form:"subscription"`
 }
 
@@ -29,43 +29,48 @@ type InvoiceItemListParams struct {
 // InvoiceItem is the resource represneting a Stripe invoice item.
 // For more details see https://stripe.com/docs/api#invoiceitems.
 type InvoiceItem struct {
-	Amount       int64             `json:"amount"`
-	Currency     Currency          `json:"currency"`
-	Customer     *Customer         `json:"customer"`
-	Date         int64             `json:"date"`
-	Deleted      bool              `json:"deleted"`
-	Desc         string            `json:"description"`
-	Discountable bool              `json:"discountable"`
-	ID           string            `json:"id"`
-	Invoice      *Invoice          `json:"invoice"`
-	Live         bool              `json:"livemode"`
-	Meta         map[string]string `json:"metadata"`
-	Period       *Period           `json:"period"`
-	Plan         *Plan             `json:"plan"`
-	Proration    bool              `json:"proration"`
-	Quantity     int64             `json:"quantity"`
-	Sub          string            `json:"subscription"`
+	Amount       int64                 `json:"amount"`
+	Currency     Currency              `json:"currency"`
+	Customer     *Expandable[Customer] `json:"customer"`
+	Date         int64                 `json:"date"`
+	Deleted      bool                  `json:"deleted"`
+	Desc         string                `json:"description"`
+	Discountable bool                  `json:"discountable"`
+	ID           string                `json:"id"`
+	Invoice      *Expandable[Invoice]  `json:"invoice"`
+	Live         bool                  `json:"livemode"`
+	Meta         map[string]string     `json:"metadata"`
+	Period       *Period               `json:"period"`
+	Plan         *Plan                 `json:"plan"`
+	Proration    bool                  `json:"proration"`
+	Quantity     int64                 `json:"quantity"`
+	Sub          string                `json:"subscription"`
 }
 
-// InvoiceItemList is a list of invoice items as retrieved from a list endpoint.
-type InvoiceItemList struct {
-	ListMeta
-	Values []*InvoiceItem `json:"data"`
+// AmountMoney returns the amount of the invoice item in its currency.
+func (i *InvoiceItem) AmountMoney() Money {
+	return Money{Amount: i.Amount, Currency: i.Currency}
 }
 
+// InvoiceItemList is a list of invoice items as retrieved from a list endpoint.
+type InvoiceItemList = List[*InvoiceItem]
+
 // UnmarshalJSON handles deserialization of an InvoiceItem.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (i *InvoiceItem) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		i.ID = id
+		return nil
+	}
+
 	type invoiceitem InvoiceItem
 	var ii invoiceitem
 	err := json.Unmarshal(data, &ii)
-	if err == nil {
-		*i = InvoiceItem(ii)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		i.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*i = InvoiceItem(ii)
 	return nil
 }
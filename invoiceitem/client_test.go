@@ -32,7 +32,7 @@ func TestInvoiceItemList(t *testing.T) {
 
 func TestInvoiceItemNew(t *testing.T) {
 	item, err := New(&stripe.InvoiceItemParams{
-		Amount:   123,
+		Amount:   stripe.Int64(123),
 		Currency: currency.USD,
 		Customer: "cus_123",
 	})
@@ -1,13 +1,29 @@
 package stripe
 
 import (
+	"context"
+	"errors"
+	"iter"
 	"reflect"
 
 	"github.com/stripe/stripe-go/form"
 )
 
-// Query is the function used to get a page listing.
-type Query func(*form.Values) ([]interface{}, ListMeta, error)
+// ErrIterLimit is returned by Iter.All when a list has more items than the
+// limit it was called with.
+var ErrIterLimit = errors.New("stripe: list has more items than the requested limit")
+
+// List is a single page of a list API response. It's what list endpoints
+// return before the page is handed over to an Iter.
+type List[T any] struct {
+	ListMeta
+	Values []T `json:"data"`
+}
+
+// Query is the function used to get a page listing. It's called with the
+// params for the request, which carry the context the page should be fetched
+// with, and the query string for the page.
+type Query[T any] func(*Params, *form.Values) ([]T, ListMeta, error)
 
 // Iter provides a convenient interface
 // for iterating over the elements
@@ -17,19 +33,33 @@ type Query func(*form.Values) ([]interface{}, ListMeta, error)
 // fetching pages of items as needed.
 // Iterators are not thread-safe, so they should not be consumed
 // across multiple goroutines.
-type Iter struct {
-	cur    interface{}
+type Iter[T any] struct {
+	cur    T
 	err    error
 	meta   ListMeta
 	params ListParams
 	qs     *form.Values
-	query  Query
-	values []interface{}
+	query  Query[T]
+	values []T
+
+	// pages receives pages fetched in the background when prefetching is
+	// enabled through ListParams.Prefetch. It's nil otherwise.
+	pages    <-chan page[T]
+	fetchCtx context.Context
+	stop     context.CancelFunc
+}
+
+// page is a single page fetched by a prefetching Iter.
+type page[T any] struct {
+	values []T
+	meta   ListMeta
+	err    error
 }
 
-// GetIter returns a new Iter for a given query and its options.
-func GetIter(params *ListParams, qs *form.Values, query Query) *Iter {
-	iter := &Iter{}
+// GetIter returns a new Iter for a given query and its options. The first
+// page is fetched immediately using the context from params, if any.
+func GetIter[T any](params *ListParams, qs *form.Values, query Query[T]) *Iter[T] {
+	iter := &Iter[T]{}
 	iter.query = query
 
 	p := params
@@ -44,12 +74,78 @@ func GetIter(params *ListParams, qs *form.Values, query Query) *Iter {
 	}
 	iter.qs = q
 
-	iter.getPage()
+	iter.getPage(iter.params.Context)
+
+	if iter.params.Prefetch > 0 && !iter.params.Single && iter.err == nil && iter.meta.More {
+		iter.startPrefetch()
+	}
+
 	return iter
 }
 
-func (it *Iter) getPage() {
-	it.values, it.meta, it.err = it.query(it.qs)
+// startPrefetch starts fetching the pages following the current one in a
+// background goroutine, buffering up to ListParams.Prefetch of them.
+func (it *Iter[T]) startPrefetch() {
+	parent := it.params.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+
+	pages := make(chan page[T], it.params.Prefetch)
+	it.pages = pages
+	it.fetchCtx = ctx
+	it.stop = cancel
+
+	// From here on the goroutine owns the query string; the consumer only
+	// reads the pages it sends.
+	params := it.params
+	qs := it.qs
+	last := it.values
+
+	go func() {
+		defer close(pages)
+
+		var cursor string
+		for {
+			if len(last) > 0 {
+				cursor = listItemID(last[len(last)-1])
+			}
+
+			// determine if we're moving forward or backwards in paging
+			if params.End != "" {
+				qs.Set(endbefore, cursor)
+			} else {
+				qs.Set(startafter, cursor)
+			}
+
+			p := params.ToParams()
+			p.Context = ctx
+
+			values, meta, err := it.query(p, qs)
+			if params.End != "" {
+				reverse(values)
+			}
+
+			select {
+			case pages <- page[T]{values, meta, err}:
+			case <-ctx.Done():
+				return
+			}
+
+			if err != nil || !meta.More {
+				return
+			}
+			last = values
+		}
+	}()
+}
+
+func (it *Iter[T]) getPage(ctx context.Context) {
+	p := it.params.ToParams()
+	p.Context = ctx
+
+	it.values, it.meta, it.err = it.query(p, it.qs)
 	if it.params.End != "" {
 		// We are moving backward,
 		// but items arrive in forward order.
@@ -62,8 +158,26 @@ func (it *Iter) getPage() {
 // through the Current method.
 // It returns false when the iterator stops
 // at the end of the list.
-func (it *Iter) Next() bool {
-	if len(it.values) == 0 && it.meta.More && !it.params.Single {
+//
+// Any page fetched by Next uses the context from the ListParams the
+// iterator was created with. Use NextContext to provide one per page.
+func (it *Iter[T]) Next() bool {
+	return it.NextContext(it.params.Context)
+}
+
+// NextContext is like Next, but fetches the next page, if one is needed,
+// with the given context. It returns false and sets the iterator's error
+// if ctx is done before a page that's needed could be fetched.
+func (it *Iter[T]) NextContext(ctx context.Context) bool {
+	if len(it.values) == 0 && it.pages != nil {
+		return it.nextPrefetched(ctx)
+	}
+	if len(it.values) == 0 && it.meta.More && !it.params.Single && it.stop == nil {
+		if ctx != nil && ctx.Err() != nil {
+			it.err = ctx.Err()
+			return false
+		}
+
 		// determine if we're moving forward or backwards in paging
 		if it.params.End != "" {
 			it.params.End = listItemID(it.cur)
@@ -72,7 +186,7 @@ func (it *Iter) Next() bool {
 			it.params.Start = listItemID(it.cur)
 			it.qs.Set(startafter, it.params.Start)
 		}
-		it.getPage()
+		it.getPage(ctx)
 	}
 	if len(it.values) == 0 {
 		return false
@@ -82,9 +196,51 @@ func (it *Iter) Next() bool {
 	return true
 }
 
+// nextPrefetched advances to the first item of the next page fetched in the
+// background, waiting for it to arrive if necessary.
+func (it *Iter[T]) nextPrefetched(ctx context.Context) bool {
+	var done <-chan struct{}
+	if ctx != nil {
+		done = ctx.Done()
+	}
+
+	select {
+	case p, ok := <-it.pages:
+		if !ok {
+			// The goroutine also gives up when the context the iterator
+			// was created with is done, in which case that's the error.
+			if it.err == nil {
+				it.err = it.fetchCtx.Err()
+			}
+			it.Stop()
+			return false
+		}
+		it.values, it.meta, it.err = p.values, p.meta, p.err
+
+	case <-done:
+		it.err = ctx.Err()
+		it.Stop()
+		return false
+	}
+
+	return it.NextContext(ctx)
+}
+
+// Stop ends any prefetching of pages in the background, including canceling
+// a page request in flight. The iterator still produces the rest of the
+// current page but no further pages. Iterators that prefetch should be
+// stopped when they're abandoned before reaching the end of the list so
+// that their goroutine is released; Stop is a no-op otherwise.
+func (it *Iter[T]) Stop() {
+	if it.stop != nil {
+		it.stop()
+	}
+	it.pages = nil
+}
+
 // Current returns the most recent item
 // visited by a call to Next.
-func (it *Iter) Current() interface{} {
+func (it *Iter[T]) Current() T {
 	return it.cur
 }
 
@@ -92,20 +248,63 @@ func (it *Iter) Current() interface{} {
 // that caused the Iter to stop.
 // It must be inspected
 // after Next returns false.
-func (it *Iter) Err() error {
+func (it *Iter[T]) Err() error {
 	return it.err
 }
 
 // Meta returns the list metadata.
-func (it *Iter) Meta() *ListMeta {
+func (it *Iter[T]) Meta() *ListMeta {
 	return &it.meta
 }
 
+// All consumes the rest of the iterator and returns its items, fetching
+// pages with ctx as needed. If limit is positive and the list holds more
+// than limit items, All stops there and returns the first limit items
+// along with ErrIterLimit.
+func (it *Iter[T]) All(ctx context.Context, limit int) ([]T, error) {
+	defer it.Stop()
+
+	var all []T
+	for it.NextContext(ctx) {
+		if limit > 0 && len(all) == limit {
+			return all, ErrIterLimit
+		}
+		all = append(all, it.Current())
+	}
+	return all, it.Err()
+}
+
+// Seq2 returns an iterator over the rest of the list for use with range,
+// fetching pages with ctx as needed. Each item is yielded with a nil error.
+// If the list stops because of an error, it's yielded last along with the
+// zero value of T.
+//
+//	for c, err := range charge.List(params).Seq2(ctx) {
+//		if err != nil {
+//			// handle
+//		}
+//	}
+func (it *Iter[T]) Seq2(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer it.Stop()
+
+		for it.NextContext(ctx) {
+			if !yield(it.Current(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
 func listItemID(x interface{}) string {
 	return reflect.ValueOf(x).Elem().FieldByName("ID").String()
 }
 
-func reverse(a []interface{}) {
+func reverse[T any](a []T) {
 	for i := 0; i < len(a)/2; i++ {
 		a[i], a[len(a)-i-1] = a[len(a)-i-1], a[i]
 	}
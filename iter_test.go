@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -111,6 +112,192 @@ func TestIterReversedTwoPages(t *testing.T) {
 	assert.NoError(t, gerr)
 }
 
+func TestIterTyped(t *testing.T) {
+	pages := [][]*item{{{"1"}, {"2"}}, {{"3"}}}
+	var starts []string
+	it := GetIter(nil, nil, func(p *Params, b *form.Values) ([]*item, ListMeta, error) {
+		starts = append(starts, b.Get(startafter)...)
+		page := pages[0]
+		pages = pages[1:]
+		return page, ListMeta{More: len(pages) > 0}, nil
+	})
+
+	var g []string
+	for it.Next() {
+		g = append(g, it.Current().ID)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, g)
+	assert.Equal(t, []string{"2"}, starts)
+}
+
+func TestIterSingle(t *testing.T) {
+	tq := testQuery{{[]interface{}{&item{"x"}}, ListMeta{0, true, ""}, nil}}
+	want := []interface{}{&item{"x"}}
+	g, gerr := collect(GetIter(&ListParams{Single: true}, nil, tq.query))
+	assert.Equal(t, 0, len(tq))
+	assert.Equal(t, want, g)
+	assert.NoError(t, gerr)
+}
+
+func TestIterNextContext(t *testing.T) {
+	var ctxs []context.Context
+	it := GetIter(nil, nil, func(p *Params, b *form.Values) ([]*item, ListMeta, error) {
+		ctxs = append(ctxs, p.Context)
+		return []*item{{"x"}}, ListMeta{More: len(ctxs) < 2}, nil
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "page")
+	assert.True(t, it.NextContext(ctx))
+	assert.True(t, it.NextContext(ctx))
+	assert.False(t, it.NextContext(ctx))
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []context.Context{nil, ctx}, ctxs)
+}
+
+func TestIterNextContextCanceled(t *testing.T) {
+	tq := testQuery{
+		{[]interface{}{&item{"x"}}, ListMeta{0, true, ""}, nil},
+		{[]interface{}{2}, ListMeta{0, false, ""}, nil},
+	}
+	it := GetIter(nil, nil, tq.query)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The first item is already fetched, so only the second page is affected.
+	assert.True(t, it.NextContext(ctx))
+	assert.False(t, it.NextContext(ctx))
+	assert.Equal(t, context.Canceled, it.Err())
+	assert.Equal(t, 1, len(tq))
+}
+
+func TestIterAll(t *testing.T) {
+	tq := testQuery{
+		{[]interface{}{&item{"x"}}, ListMeta{0, true, ""}, nil},
+		{[]interface{}{2}, ListMeta{0, false, ""}, nil},
+	}
+	g, err := GetIter(nil, nil, tq.query).All(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{&item{"x"}, 2}, g)
+}
+
+func TestIterAllLimit(t *testing.T) {
+	tq := testQuery{{[]interface{}{1, 2, 3}, ListMeta{}, nil}}
+	g, err := GetIter(nil, nil, tq.query).All(context.Background(), 2)
+	assert.Equal(t, ErrIterLimit, err)
+	assert.Equal(t, []interface{}{1, 2}, g)
+
+	tq = testQuery{{[]interface{}{1, 2}, ListMeta{}, nil}}
+	g, err = GetIter(nil, nil, tq.query).All(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, g)
+}
+
+func TestIterSeq2(t *testing.T) {
+	tq := testQuery{
+		{[]interface{}{&item{"x"}}, ListMeta{0, true, ""}, nil},
+		{[]interface{}{2}, ListMeta{0, false, ""}, errTest},
+	}
+
+	var g []interface{}
+	var gerr error
+	for v, err := range GetIter(nil, nil, tq.query).Seq2(context.Background()) {
+		if err != nil {
+			gerr = err
+			break
+		}
+		g = append(g, v)
+	}
+	assert.Equal(t, []interface{}{&item{"x"}, 2}, g)
+	assert.Equal(t, errTest, gerr)
+}
+
+func TestIterPrefetch(t *testing.T) {
+	pages := [][]*item{{{"1"}, {"2"}}, {{"3"}}, {{"4"}, {"5"}}}
+	var starts []string
+	it := GetIter(&ListParams{Prefetch: 1}, nil, func(p *Params, b *form.Values) ([]*item, ListMeta, error) {
+		starts = append(starts, b.Get(startafter)...)
+		page := pages[0]
+		pages = pages[1:]
+		return page, ListMeta{More: len(pages) > 0}, nil
+	})
+
+	var g []string
+	for it.Next() {
+		g = append(g, it.Current().ID)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, g)
+	assert.Equal(t, []string{"2", "3"}, starts)
+}
+
+func TestIterPrefetchErr(t *testing.T) {
+	tq := testQuery{
+		{[]interface{}{&item{"x"}}, ListMeta{0, true, ""}, nil},
+		{[]interface{}{&item{"y"}}, ListMeta{0, true, ""}, errTest},
+	}
+	want := []interface{}{&item{"x"}, &item{"y"}}
+	g, gerr := collect(GetIter(&ListParams{Prefetch: 2}, nil, tq.query))
+	assert.Equal(t, 0, len(tq))
+	assert.Equal(t, want, g)
+	assert.Equal(t, errTest, gerr)
+}
+
+func TestIterPrefetchReversed(t *testing.T) {
+	tq := testQuery{
+		{[]interface{}{&item{"3"}, &item{"4"}}, ListMeta{0, true, ""}, nil},
+		{[]interface{}{1, 2}, ListMeta{}, nil},
+	}
+	want := []interface{}{&item{"4"}, &item{"3"}, 2, 1}
+	g, gerr := collect(GetIter(&ListParams{End: "x", Prefetch: 1}, nil, tq.query))
+	assert.Equal(t, 0, len(tq))
+	assert.Equal(t, want, g)
+	assert.NoError(t, gerr)
+}
+
+func TestIterPrefetchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fetched := make(chan struct{})
+	it := GetIter(&ListParams{Context: ctx, Prefetch: 1}, nil, func(p *Params, b *form.Values) ([]*item, ListMeta, error) {
+		if b.Get(startafter) == nil {
+			return []*item{{"1"}}, ListMeta{More: true}, nil
+		}
+
+		// Block the background fetch until the iterator is canceled.
+		close(fetched)
+		<-p.Context.Done()
+		return nil, ListMeta{}, p.Context.Err()
+	})
+
+	assert.True(t, it.Next())
+	<-fetched
+	cancel()
+	assert.False(t, it.Next())
+	assert.Equal(t, context.Canceled, it.Err())
+}
+
+func TestIterPrefetchStop(t *testing.T) {
+	var fetches int
+	it := GetIter(&ListParams{Prefetch: 1}, nil, func(p *Params, b *form.Values) ([]*item, ListMeta, error) {
+		fetches++
+		if fetches > 1 {
+			<-p.Context.Done()
+			return nil, ListMeta{}, p.Context.Err()
+		}
+		return []*item{{"1"}, {"2"}}, ListMeta{More: true}, nil
+	})
+
+	assert.True(t, it.Next())
+	it.Stop()
+
+	// The rest of the current page is still available.
+	assert.True(t, it.Next())
+	assert.Equal(t, "2", it.Current().ID)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
 func TestReverse(t *testing.T) {
 	var cases = [][]interface{}{
 		{},
@@ -140,19 +327,21 @@ type item struct {
 	ID string
 }
 
+type ctxKey struct{}
+
 type testQuery []struct {
 	v []interface{}
 	m ListMeta
 	e error
 }
 
-func (tq *testQuery) query(*form.Values) ([]interface{}, ListMeta, error) {
+func (tq *testQuery) query(*Params, *form.Values) ([]interface{}, ListMeta, error) {
 	x := (*tq)[0]
 	*tq = (*tq)[1:]
 	return x.v, x.m, x.e
 }
 
-func collect(it *Iter) ([]interface{}, error) {
+func collect(it *Iter[interface{}]) ([]interface{}, error) {
 	var g []interface{}
 	for it.Next() {
 		g = append(g, it.Current())
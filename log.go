@@ -0,0 +1,137 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// StructuredLogger is a logger accepting leveled records made of a message
+// and key-value pairs. It's implemented by *slog.Logger, so a backend can log
+// through the standard library's structured logging with:
+//
+//	backend.Logger = slog.Default()
+type StructuredLogger interface {
+	Enabled(ctx context.Context, level slog.Level) bool
+	Log(ctx context.Context, level slog.Level, msg string, args ...interface{})
+}
+
+// logEnabled returns whether the backend logs records of the given level,
+// so that costly fields are only computed when needed.
+func (s *BackendConfiguration) logEnabled(ctx context.Context, level slog.Level) bool {
+	if s.Logger != nil {
+		return s.Logger.Enabled(ctx, level)
+	}
+	return LogLevel >= printfLogLevel(level)
+}
+
+// log emits a record through the backend's Logger. Backends without one fall
+// back to the package level Logger, filtered by LogLevel.
+func (s *BackendConfiguration) log(ctx context.Context, level slog.Level, msg string, args ...interface{}) {
+	LogTo(ctx, s.Logger, level, msg, args...)
+}
+
+// LogTo emits a record through logger like backends do: when logger is nil,
+// the record is printed by the package level Logger, filtered by LogLevel.
+// It's used by the other packages of the library that log, like webhook.
+func LogTo(ctx context.Context, logger StructuredLogger, level slog.Level, msg string, args ...interface{}) {
+	if logger != nil {
+		logger.Log(ctx, level, msg, args...)
+		return
+	}
+
+	if LogLevel < printfLogLevel(level) {
+		return
+	}
+	Logger.Printf("%s\n", formatLogRecord(msg, args))
+}
+
+// printfLogLevel returns the LogLevel from which records of the given level
+// are printed by the package level Logger.
+func printfLogLevel(level slog.Level) int {
+	switch {
+	case level >= slog.LevelWarn:
+		return 1
+	case level >= slog.LevelInfo:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// formatLogRecord formats a record for a Printfer as its message followed by
+// its key-value pairs.
+func formatLogRecord(msg string, args []interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			fmt.Fprintf(&b, " %v", args[i])
+			break
+		}
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+
+	return b.String()
+}
+
+const redacted = "[REDACTED]"
+
+var (
+	// redactedAPIKey matches secret and restricted API keys.
+	redactedAPIKey = regexp.MustCompile(`\b([sr]k_(?:live|test)_)[0-9A-Za-z]+`)
+
+	// redactedJSONField and redactedFormField match the values of fields
+	// holding card verification codes and bank account numbers in JSON and
+	// form encoded bodies respectively.
+	redactedJSONField = regexp.MustCompile(`("(?:cvc|account_number)"\s*:\s*)"[^"]*"`)
+	redactedFormField = regexp.MustCompile(`((?:^|&)(?:[^=&]*(?:%5B|\[))?(?:cvc|account_number)(?:%5D|\])?=)[^&]*`)
+
+	// redactedCardNumber matches sequences of 13 to 19 digits, optionally
+	// separated by spaces or dashes, which are candidate card numbers.
+	redactedCardNumber = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+)
+
+// redact removes card numbers, card verification codes, bank account numbers
+// and API keys from a request or response body so that it can be logged.
+func redact(body string) string {
+	body = redactedAPIKey.ReplaceAllString(body, "${1}"+redacted)
+	body = redactedJSONField.ReplaceAllString(body, `${1}"`+redacted+`"`)
+	body = redactedFormField.ReplaceAllString(body, "${1}"+redacted)
+
+	return redactedCardNumber.ReplaceAllStringFunc(body, func(s string) string {
+		if !luhnValid(s) {
+			return s
+		}
+		return redacted
+	})
+}
+
+// luhnValid returns whether the digits in s pass the Luhn checksum that all
+// card numbers satisfy, ignoring any other character.
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
@@ -0,0 +1,82 @@
+package stripe
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/form"
+)
+
+func TestRedact(t *testing.T) {
+	assert.Equal(t,
+		"card%5Bnumber%5D=[REDACTED]&card%5Bcvc%5D=[REDACTED]&amount=2000",
+		redact("card%5Bnumber%5D=4242424242424242&card%5Bcvc%5D=123&amount=2000"))
+
+	assert.Equal(t,
+		`{"number": "[REDACTED]", "cvc": "[REDACTED]", "account_number": "[REDACTED]"}`,
+		redact(`{"number": "4242 4242 4242 4242", "cvc": "123", "account_number": "000123456789"}`))
+
+	assert.Equal(t,
+		"bank_account%5Baccount_number%5D=[REDACTED]&key=sk_test_[REDACTED]",
+		redact("bank_account%5Baccount_number%5D=000123456789&key=sk_test_123abcDEF"))
+
+	// Long numbers that aren't card numbers are left alone.
+	assert.Equal(t, `{"created": 1234567890123}`, redact(`{"created": 1234567890123}`))
+}
+
+func TestFormatLogRecord(t *testing.T) {
+	assert.Equal(t, "Requesting Stripe method=GET attempt=0",
+		formatLogRecord("Requesting Stripe", []interface{}{"method", "GET", "attempt", 0}))
+	assert.Equal(t, "Message odd", formatLogRecord("Message", []interface{}{"odd"}))
+}
+
+func TestBackendLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_123")
+		w.Write([]byte(`{"id":"card_123","number":"4242424242424242"}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	c := &BackendConfiguration{
+		Type:       APIBackend,
+		URL:        ts.URL,
+		HTTPClient: &http.Client{},
+		Logger: slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		})),
+	}
+
+	body := &form.Values{}
+	body.Add("card[number]", "4242424242424242")
+	err := c.Call("POST", "/tokens", "sk_test_123", body, &Params{IdempotencyKey: "key_123"}, nil)
+	assert.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "4242424242424242")
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	assert.Len(t, records, 4)
+	assert.Equal(t, "Stripe request", records[0]["msg"])
+	assert.Equal(t, "Requesting Stripe", records[1]["msg"])
+	assert.Equal(t, "POST", records[1]["method"])
+	assert.Equal(t, "/tokens", records[1]["path"])
+	assert.Equal(t, "key_123", records[1]["idempotency_key"])
+	assert.Equal(t, float64(0), records[1]["attempt"])
+	assert.Equal(t, "Request to Stripe completed", records[2]["msg"])
+	assert.Equal(t, float64(200), records[2]["status"])
+	assert.Equal(t, "req_123", records[2]["request_id"])
+	assert.Contains(t, records[2], "duration")
+	assert.Equal(t, "Stripe response", records[3]["msg"])
+}
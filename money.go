@@ -0,0 +1,189 @@
+package stripe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// ErrCurrencyMismatch is the error returned when combining or comparing
+// amounts of money in different currencies.
+var ErrCurrencyMismatch = errors.New("stripe: currencies don't match")
+
+// ErrMoneyOverflow is the error returned when the result of arithmetic on
+// amounts of money doesn't fit in an int64.
+var ErrMoneyOverflow = errors.New("stripe: amount of money out of range")
+
+// Money is an amount of money in a currency. Like everywhere in the API,
+// Amount is in the currency's smallest unit, like cents for USD, or in the
+// major unit for zero-decimal currencies like JPY.
+//
+// Arithmetic and comparisons on Money return ErrCurrencyMismatch rather
+// than mixing currencies.
+type Money struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// NewMoney returns an amount of money in a currency.
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// String returns the amount followed by its currency, like "1050 usd". Use
+// the currency package to format amounts for display.
+func (m Money) String() string {
+	return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+}
+
+// SameCurrency returns whether two amounts of money are in the same currency.
+// Currencies are compared without regard to case.
+func (m Money) SameCurrency(o Money) bool {
+	return strings.EqualFold(string(m.Currency), string(o.Currency))
+}
+
+// IsZero returns whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative returns whether the amount is less than zero, like for a refund
+// or a debit.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Add returns the sum of two amounts in the same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+
+	sum := m.Amount + o.Amount
+	if (o.Amount > 0 && sum < m.Amount) || (o.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// Sub returns the difference of two amounts in the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+
+	diff := m.Amount - o.Amount
+	if (o.Amount > 0 && diff > m.Amount) || (o.Amount < 0 && diff < m.Amount) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: diff, Currency: m.Currency}, nil
+}
+
+// Cmp compares two amounts in the same currency, returning -1 if m is less
+// than o, 0 if they're equal and +1 if m is greater than o.
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Equal returns whether two amounts in the same currency are equal.
+func (m Money) Equal(o Money) (bool, error) {
+	cmp, err := m.Cmp(o)
+	return cmp == 0, err
+}
+
+// Split splits the amount into n parts as equal as possible. The parts add
+// up to the amount, with the smallest units left over given one each to the
+// first parts, so that 1000 split into 3 is 334, 333 and 333.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("Cannot split money into %d parts", n)
+	}
+
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// Allocate splits the amount into parts proportional to ratios, like 70 and
+// 30 for a 70/30 revenue share. The parts add up to the amount, with the
+// smallest units left over after rounding down given one each to the first
+// parts.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, errors.New("Cannot allocate money without ratios")
+	}
+
+	total := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("Cannot allocate money with negative ratio %d", ratio)
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, errors.New("Cannot allocate money with ratios adding up to zero")
+	}
+
+	// The parts are computed on the absolute amount, which doesn't fit in an
+	// int64 for math.MinInt64, so that the leftover units are positive.
+	amount := big.NewInt(m.Amount)
+	amount.Abs(amount)
+
+	parts := make([]Money, len(ratios))
+	left := new(big.Int).Set(amount)
+	shares := make([]*big.Int, len(ratios))
+	for i, ratio := range ratios {
+		shares[i] = new(big.Int).Mul(amount, big.NewInt(ratio))
+		shares[i].Quo(shares[i], total)
+		left.Sub(left, shares[i])
+	}
+
+	one := big.NewInt(1)
+	for i := 0; left.Sign() > 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].Add(shares[i], one)
+		left.Sub(left, one)
+	}
+
+	for i, share := range shares {
+		if m.Amount < 0 {
+			share.Neg(share)
+		}
+		parts[i] = Money{Amount: share.Int64(), Currency: m.Currency}
+	}
+	return parts, nil
+}
+
+// checkCurrency returns an error if o isn't in the same currency as m.
+func (m Money) checkCurrency(o Money) error {
+	if !m.SameCurrency(o) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
+
+// moneyFromUint64 returns the money for an amount of one of the resources
+// with unsigned amounts. Amounts are never big enough to overflow an int64,
+// but they're capped anyway.
+func moneyFromUint64(amount uint64, currency Currency) Money {
+	if amount > math.MaxInt64 {
+		amount = math.MaxInt64
+	}
+	return Money{Amount: int64(amount), Currency: currency}
+}
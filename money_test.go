@@ -0,0 +1,113 @@
+package stripe
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestMoney_AddSub(t *testing.T) {
+	sum, err := NewMoney(1050, "usd").Add(NewMoney(-50, "USD"))
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(1000, "usd"), sum)
+
+	diff, err := NewMoney(1000, "usd").Sub(NewMoney(1050, "usd"))
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(-50, "usd"), diff)
+	assert.True(t, diff.IsNegative())
+
+	_, err = NewMoney(1000, "usd").Add(NewMoney(1000, "eur"))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+	assert.EqualError(t, err, "stripe: currencies don't match: usd and eur")
+
+	_, err = NewMoney(math.MaxInt64, "usd").Add(NewMoney(1, "usd"))
+	assert.Equal(t, ErrMoneyOverflow, err)
+
+	_, err = NewMoney(math.MinInt64, "usd").Sub(NewMoney(1, "usd"))
+	assert.Equal(t, ErrMoneyOverflow, err)
+}
+
+func TestMoney_Cmp(t *testing.T) {
+	cmp, err := NewMoney(1000, "usd").Cmp(NewMoney(1050, "usd"))
+	assert.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	cmp, err = NewMoney(1050, "usd").Cmp(NewMoney(1000, "usd"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	equal, err := NewMoney(1000, "usd").Equal(NewMoney(1000, "usd"))
+	assert.NoError(t, err)
+	assert.True(t, equal)
+
+	_, err = NewMoney(1000, "usd").Cmp(NewMoney(1000, "jpy"))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	_, err = NewMoney(0, "usd").Equal(NewMoney(0, "jpy"))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+}
+
+func TestMoney_Split(t *testing.T) {
+	parts, err := NewMoney(1000, "usd").Split(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{NewMoney(334, "usd"), NewMoney(333, "usd"), NewMoney(333, "usd")}, parts)
+
+	parts, err = NewMoney(-1000, "usd").Split(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{NewMoney(-334, "usd"), NewMoney(-333, "usd"), NewMoney(-333, "usd")}, parts)
+
+	_, err = NewMoney(1000, "usd").Split(0)
+	assert.Error(t, err)
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	parts, err := NewMoney(1001, "usd").Allocate(70, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{NewMoney(701, "usd"), NewMoney(300, "usd")}, parts)
+
+	// Parts with a zero ratio never get leftover units.
+	parts, err = NewMoney(5, "usd").Allocate(0, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{NewMoney(0, "usd"), NewMoney(3, "usd"), NewMoney(2, "usd")}, parts)
+
+	// The parts always add up to the amount, even for extreme amounts.
+	for _, amount := range []int64{math.MaxInt64, math.MinInt64, 1, -7} {
+		parts, err = NewMoney(amount, "usd").Allocate(math.MaxInt64, 3, 1)
+		assert.NoError(t, err)
+
+		sum := NewMoney(0, "usd")
+		for _, part := range parts {
+			sum, err = sum.Add(part)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, amount, sum.Amount)
+	}
+
+	_, err = NewMoney(1000, "usd").Allocate()
+	assert.Error(t, err)
+
+	_, err = NewMoney(1000, "usd").Allocate(0, 0)
+	assert.Error(t, err)
+
+	_, err = NewMoney(1000, "usd").Allocate(1, -1)
+	assert.Error(t, err)
+}
+
+func TestMoney_Accessors(t *testing.T) {
+	charge := &Charge{Amount: 1000, AmountRefunded: 250, Currency: "usd"}
+	assert.Equal(t, NewMoney(1000, "usd"), charge.AmountMoney())
+	assert.Equal(t, NewMoney(250, "usd"), charge.AmountRefundedMoney())
+
+	tx := &Transaction{Amount: 1000, Fee: 59, Net: 941, Currency: "usd"}
+	net, err := tx.AmountMoney().Sub(tx.FeeMoney())
+	assert.NoError(t, err)
+	assert.Equal(t, tx.NetMoney(), net)
+
+	amount := &Amount{Value: 1000, Currency: "eur"}
+	assert.Equal(t, NewMoney(1000, "eur"), amount.Money())
+
+	assert.Equal(t, NewMoney(math.MaxInt64, "usd"), moneyFromUint64(math.MaxUint64, "usd"))
+	assert.Equal(t, "1000 usd", charge.AmountMoney().String())
+}
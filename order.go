@@ -106,12 +106,14 @@ type Order struct {
 	Updated                int64             `json:"updated"`
 }
 
-// OrderList is a list of orders as retrieved from a list endpoint.
-type OrderList struct {
-	ListMeta
-	Values []*Order `json:"data"`
+// AmountMoney returns the amount of the order in its currency.
+func (o *Order) AmountMoney() Money {
+	return Money{Amount: o.Amount, Currency: o.Currency}
 }
 
+// OrderList is a list of orders as retrieved from a list endpoint.
+type OrderList = List[*Order]
+
 // OrderListParams is the set of parameters that can be used when
 // listing orders. For more details, see:
 // https://stripe.com/docs/api#list_orders.
@@ -156,17 +158,18 @@ func (op *OrderPayParams) SetSource(sp interface{}) error {
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (o *Order) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		o.ID = id
+		return nil
+	}
+
 	type order Order
 	var oo order
 	err := json.Unmarshal(data, &oo)
-	if err == nil {
-		*o = Order(oo)
-		{
-		}
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		o.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*o = Order(oo)
 	return nil
 }
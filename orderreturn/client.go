@@ -19,25 +19,18 @@ func List(params *stripe.OrderReturnListParams) *Iter {
 func (c Client) List(params *stripe.OrderReturnListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.OrderReturn, stripe.ListMeta, error) {
 		list := &stripe.OrderReturnList{}
 		err := c.B.Call("GET", "/order_returns", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -45,13 +38,13 @@ func (c Client) List(params *stripe.OrderReturnListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.OrderReturn]
 }
 
 // OrderReturn returns the most recent OrderReturn
 // visited by a call to Next.
 func (i *Iter) OrderReturn() *stripe.OrderReturn {
-	return i.Current().(*stripe.OrderReturn)
+	return i.Current()
 }
 
 func getC() Client {
@@ -24,6 +24,11 @@ type Params struct {
 	// Please use StripeAccount instead.
 	Account string `form:"-"` // Passed as header
 
+	// APIVersion overrides the version of the Stripe API requested for this
+	// request only. Responses are decoded into the library's structs, which
+	// model APIVersion, so other versions should be used with care.
+	APIVersion string `form:"-"` // Passed as header
+
 	// Context used for request. It may carry deadlines, cancelation signals,
 	// and other request-scoped values across API boundaries and between
 	// processes.
@@ -40,7 +45,7 @@ type Params struct {
 	// Headers may be used to provide extra header lines on the HTTP request.
 	Headers http.Header `form:"-"`
 
-	IdempotencyKey string            `form:"-"` // Passed as header
+	IdempotencyKey string            `form:"-" validate:"max=255"` // Passed as header
 	Meta           map[string]string `form:"metadata"`
 
 	// StripeAccount may contain the ID of a connected account. By including
@@ -66,6 +71,18 @@ func (v ExtraValues) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// formValuesWithout returns a copy of values without key, for UnmarshalForm
+// implementations which decode a key themselves.
+func formValuesWithout(values url.Values, key string) url.Values {
+	rest := make(url.Values, len(values))
+	for k, vs := range values {
+		if k != key {
+			rest[k] = vs
+		}
+	}
+	return rest
+}
+
 // ListParams is the structure that contains the common properties
 // of any *ListParams structure.
 type ListParams struct {
@@ -79,11 +96,22 @@ type ListParams struct {
 	// key or query the state of the API.
 	Context context.Context `form:"-"`
 
+	// APIVersion overrides the version of the Stripe API requested when
+	// listing. See Params.APIVersion.
+	APIVersion string `form:"-"` // Passed as header
+
 	End     string   `form:"ending_before"`
 	Exp     []string `form:"expand"`
 	Filters Filters  `form:"*"`
 	Limit   int      `form:"limit"`
 
+	// Prefetch enables fetching the next pages of a list in the background
+	// while the current one is being consumed, which speeds up walking long
+	// lists. It's the maximum number of pages buffered ahead of the iterator.
+	// An iterator that prefetches and is abandoned before reaching the end
+	// of the list should be stopped with its Stop method.
+	Prefetch int `form:"-"` // Not an API parameter
+
 	// Single specifies whether this is a single page iterator. By default,
 	// listing through an iterator will automatically grab additional pages as
 	// the query progresses. To change this behavior and just load a single
@@ -211,7 +239,92 @@ func (p *ListParams) Expand(f string) {
 // ListParams is only used to build a set of parameters.
 func (p *ListParams) ToParams() *Params {
 	return &Params{
+		APIVersion:    p.APIVersion,
 		Context:       p.Context,
 		StripeAccount: p.StripeAccount,
 	}
 }
+
+// Bool returns a pointer to v, to set an optional boolean parameter, which is
+// sent even if it's false.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// BoolValue returns the value v points to, or false if it's nil.
+func BoolValue(v *bool) bool {
+	if v == nil {
+		return false
+	}
+	return *v
+}
+
+// Float64 returns a pointer to v, to set an optional float parameter, which
+// is sent even if it's zero.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// Float64Value returns the value v points to, or 0 if it's nil.
+func Float64Value(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// Int64 returns a pointer to v, to set an optional integer parameter, which
+// is sent even if it's zero.
+func Int64(v int64) *int64 {
+	return &v
+}
+
+// Int64Value returns the value v points to, or 0 if it's nil.
+func Int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// String returns a pointer to v, to set an optional string parameter, which
+// is sent even if it's empty. An empty string unsets the parameter.
+func String(v string) *string {
+	return &v
+}
+
+// StringValue returns the value v points to, or "" if it's nil.
+func StringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// Uint64 returns a pointer to v, to set an optional unsigned integer
+// parameter, which is sent even if it's zero.
+func Uint64(v uint64) *uint64 {
+	return &v
+}
+
+// Uint64Value returns the value v points to, or 0 if it's nil.
+func Uint64Value(v *uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// Clear returns the value of an optional parameter that unsets it, like
+// stripe.Clear[float64]() for SubParams.TaxPercent. Every call returns a new
+// pointer, so writing through it doesn't affect other parameters.
+func Clear[T form.Scalar]() *T {
+	return form.Clear[T]()
+}
+
+// Now returns the value of an optional timestamp parameter that stands for
+// the time the request is made, like SubParams.TrialEnd. Every call returns a
+// new pointer, so writing through it doesn't affect other parameters.
+func Now() *int64 {
+	return form.Now()
+}
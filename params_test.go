@@ -2,6 +2,8 @@ package stripe_test
 
 import (
 	"context"
+	"net/url"
+	"reflect"
 	"testing"
 
 	assert "github.com/stretchr/testify/require"
@@ -264,3 +266,90 @@ func valuesFromArray(arr [][2]string) *form.Values {
 	}
 	return body
 }
+
+func TestParams_Unmarshal_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params interface{}
+	}{
+		{"charge with card", &stripe.ChargeParams{
+			Params:   stripe.Params{Meta: map[string]string{"order_id": "6735"}},
+			Amount:   1000,
+			Capture:  stripe.Bool(false),
+			Currency: stripe.Currency("usd"),
+			Shipping: &stripe.ShippingDetails{Address: stripe.Address{Line1: "1 Main St"}, Name: "Jenny"},
+			Source: &stripe.SourceParams{Card: &stripe.CardParams{
+				Number: "4242424242424242", Month: "10", Year: "20", CVC: "123",
+			}},
+		}},
+		{"charge with token", &stripe.ChargeParams{
+			Amount:      1000,
+			Currency:    stripe.Currency("usd"),
+			Destination: &stripe.DestinationParams{Account: "acct_123", Amount: 500},
+			Source:      &stripe.SourceParams{Token: "tok_visa"},
+		}},
+		{"subscription", &stripe.SubParams{
+			BillingCycleAnchorUnchanged: true,
+			Coupon:                      stripe.String(""),
+			Items: []*stripe.SubItemsParams{
+				{Plan: "gold", Quantity: stripe.Uint64(0)},
+				{ID: "si_123", Deleted: true},
+			},
+			TaxPercent: stripe.Clear[float64](),
+			TrialEnd:   stripe.Now(),
+		}},
+		{"plan", &stripe.PlanParams{
+			Amount:    stripe.Uint64(0),
+			Currency:  stripe.Currency("usd"),
+			ProductID: stripe.String("prod_123"),
+			Tiers: []*stripe.PlanTierParams{
+				{Amount: 500, UpTo: 10},
+				{Amount: 400, UpToInf: true},
+			},
+		}},
+		{"account", &stripe.AccountParams{
+			DebitNegativeBal: stripe.Bool(true),
+			ExternalAccount:  &stripe.AccountExternalAccountParams{Token: "btok_123"},
+			LegalEntity: &stripe.LegalEntity{
+				Verification: stripe.IdentityVerification{Document: &stripe.IdentityDocument{ID: "file_123"}},
+			},
+			PayoutSchedule: &stripe.PayoutScheduleParams{MinimumDelay: true, Interval: "daily"},
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := &form.Values{}
+			form.AppendTo(body, tc.params)
+
+			decoded := reflect.New(reflect.TypeOf(tc.params).Elem()).Interface()
+			assert.NoError(t, form.Unmarshal(body.ToValues(), decoded))
+
+			again := &form.Values{}
+			form.AppendTo(again, decoded)
+			assert.Equal(t, body.ToValues(), again.ToValues())
+		})
+	}
+}
+
+func TestParams_Unmarshal(t *testing.T) {
+	values, err := url.ParseQuery("amount=2000&currency=usd&capture=false&" +
+		"source[object]=card&source[number]=4242424242424242&metadata[order_id]=6735")
+	assert.NoError(t, err)
+
+	params := &stripe.ChargeParams{}
+	assert.NoError(t, form.Unmarshal(values, params))
+	assert.Equal(t, uint64(2000), params.Amount)
+	assert.Equal(t, stripe.Currency("usd"), params.Currency)
+	assert.False(t, *params.Capture)
+	assert.False(t, params.NoCapture)
+	assert.Equal(t, "4242424242424242", params.Source.Card.Number)
+	assert.Equal(t, "6735", params.Meta["order_id"])
+
+	sub := &stripe.SubParams{}
+	assert.NoError(t, form.Unmarshal(url.Values{"billing_cycle_anchor": {"unchanged"}}, sub))
+	assert.True(t, sub.BillingCycleAnchorUnchanged)
+	assert.Nil(t, sub.BillingCycleAnchor)
+
+	err = form.Unmarshal(url.Values{"amount": {"-1"}}, params)
+	assert.Error(t, err)
+}
@@ -3,6 +3,8 @@ package stripe
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/stripe/stripe-go/form"
 )
@@ -20,6 +22,25 @@ func (p *SourceParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// UnmarshalForm implements form.Unmarshaler for the card encoded by the custom
+// AppendTo, which is decoded into Card, while a token is decoded into Token.
+func (p *SourceParams) UnmarshalForm(values url.Values, keyParts []string) error {
+	sourceKeyParts := append(keyParts, cardSource)
+	sourceKey := form.FormatKey(sourceKeyParts)
+	if token := values.Get(sourceKey); token != "" {
+		p.Token = token
+		return nil
+	}
+
+	for key := range values {
+		if strings.HasPrefix(key, sourceKey+"[") {
+			p.Card = &CardParams{}
+			return form.UnmarshalPrefixed(values, p.Card, sourceKeyParts)
+		}
+	}
+	return nil
+}
+
 // CustomerSourceParams are used to manipulate a given Stripe
 // Customer object's payment sources.
 // For more details see https://stripe.com/docs/api#sources
@@ -109,10 +130,7 @@ type PaymentSource struct {
 }
 
 // SourceList is a list object for cards.
-type SourceList struct {
-	ListMeta
-	Values []*PaymentSource `json:"data"`
-}
+type SourceList = List[*PaymentSource]
 
 // SourceListParams are used to enumerate the payment sources that are attached
 // to a Customer.
@@ -125,32 +143,32 @@ type SourceListParams struct {
 // This custom unmarshaling is needed because the specific
 // type of payment instrument it refers to is specified in the JSON
 func (s *PaymentSource) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		s.ID = id
+		return nil
+	}
+
 	type source PaymentSource
 	var ss source
 	err := json.Unmarshal(data, &ss)
-	if err == nil {
-		*s = PaymentSource(ss)
-
-		switch s.Type {
-		case PaymentSourceBankAccount:
-			err = json.Unmarshal(data, &s.BankAccount)
-		case PaymentSourceBitcoinReceiver:
-			err = json.Unmarshal(data, &s.BitcoinReceiver)
-		case PaymentSourceCard:
-			err = json.Unmarshal(data, &s.Card)
-		case PaymentSourceObject:
-			err = json.Unmarshal(data, &s.SourceObject)
-		}
+	if err != nil {
+		return err
+	}
 
-		if err != nil {
-			return err
-		}
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		s.ID = string(data[1 : len(data)-1])
+	*s = PaymentSource(ss)
+
+	switch s.Type {
+	case PaymentSourceBankAccount:
+		err = json.Unmarshal(data, &s.BankAccount)
+	case PaymentSourceBitcoinReceiver:
+		err = json.Unmarshal(data, &s.BitcoinReceiver)
+	case PaymentSourceCard:
+		err = json.Unmarshal(data, &s.Card)
+	case PaymentSourceObject:
+		err = json.Unmarshal(data, &s.SourceObject)
 	}
 
-	return nil
+	return err
 }
 
 // MarshalJSON handles serialization of a PaymentSource.
@@ -171,7 +189,8 @@ func (s *PaymentSource) MarshalJSON() ([]byte, error) {
 	case PaymentSourceCard:
 		var customerID *string
 		if s.Card.Customer != nil {
-			customerID = &s.Card.Customer.ID
+			id := s.Card.Customer.ID()
+			customerID = &id
 		}
 
 		target = struct {
@@ -194,7 +213,8 @@ func (s *PaymentSource) MarshalJSON() ([]byte, error) {
 	case PaymentSourceBankAccount:
 		var customerID *string
 		if s.BankAccount.Customer != nil {
-			customerID = &s.BankAccount.Customer.ID
+			id := s.BankAccount.Customer.ID()
+			customerID = &id
 		}
 
 		target = struct {
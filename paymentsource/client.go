@@ -22,6 +22,10 @@ func New(params *stripe.CustomerSourceParams) (*stripe.PaymentSource, error) {
 }
 
 func (s Client) New(params *stripe.CustomerSourceParams) (*stripe.PaymentSource, error) {
+	if err := stripe.CheckParams(s.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -44,6 +48,10 @@ func Get(id string, params *stripe.CustomerSourceParams) (*stripe.PaymentSource,
 }
 
 func (s Client) Get(id string, params *stripe.CustomerSourceParams) (*stripe.PaymentSource, error) {
+	if err := stripe.CheckParams(s.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -72,6 +80,10 @@ func Update(id string, params *stripe.CustomerSourceParams) (*stripe.PaymentSour
 }
 
 func (s Client) Update(id string, params *stripe.CustomerSourceParams) (*stripe.PaymentSource, error) {
+	if err := stripe.CheckParams(s.B, params, false); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -94,6 +106,10 @@ func Del(id string, params *stripe.CustomerSourceParams) (*stripe.PaymentSource,
 }
 
 func (s Client) Del(id string, params *stripe.CustomerSourceParams) (*stripe.PaymentSource, error) {
+	if err := stripe.CheckParams(s.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -124,10 +140,9 @@ func List(params *stripe.SourceListParams) *Iter {
 func (s Client) List(params *stripe.SourceListParams) *Iter {
 	body := &form.Values{}
 	var lp *stripe.ListParams = &params.ListParams
-	var p *stripe.Params = params.ToParams()
 	form.AppendTo(body, params)
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.PaymentSource, stripe.ListMeta, error) {
 		list := &stripe.SourceList{}
 		var err error
 
@@ -137,12 +152,7 @@ func (s Client) List(params *stripe.SourceListParams) *Iter {
 			err = errors.New("Invalid source params: customer needs to be set")
 		}
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -153,6 +163,10 @@ func Verify(id string, params *stripe.SourceVerifyParams) (*stripe.PaymentSource
 }
 
 func (s Client) Verify(id string, params *stripe.SourceVerifyParams) (*stripe.PaymentSource, error) {
+	if err := stripe.CheckParams(s.B, params, false); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -174,13 +188,13 @@ func (s Client) Verify(id string, params *stripe.SourceVerifyParams) (*stripe.Pa
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.PaymentSource]
 }
 
 // PaymentSource returns the most recent PaymentSource
 // visited by a call to Next.
 func (i *Iter) PaymentSource() *stripe.PaymentSource {
-	return i.Current().(*stripe.PaymentSource)
+	return i.Current()
 }
 
 func getC() Client {
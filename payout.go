@@ -58,8 +58,8 @@ type PayoutDestination struct {
 // For more details see https://stripe.com/docs/api#create_payout and https://stripe.com/docs/api#update_payout.
 type PayoutParams struct {
 	Params              `form:"*"`
-	Amount              int64            `form:"amount"`
-	Currency            Currency         `form:"currency"`
+	Amount              int64            `form:"amount" validate:"required,min=0"`
+	Currency            Currency         `form:"currency" validate:"required"`
 	Destination         string           `form:"destination"`
 	Method              PayoutMethodType `form:"method"`
 	SourceType          PayoutSourceType `form:"source_type"`
@@ -81,48 +81,53 @@ type PayoutListParams struct {
 // Payout is the resource representing a Stripe payout.
 // For more details see https://stripe.com/docs/api#payouts.
 type Payout struct {
-	Amount                    int64             `json:"amount"`
-	ArrivalDate               int64             `json:"arrival_date"`
-	Automatic                 bool              `json:"automatic"`
-	BalanceTransaction        *Transaction      `json:"balance_transaction"`
-	Bank                      *BankAccount      `json:"bank_account"`
-	Card                      *Card             `json:"card"`
-	Created                   int64             `json:"created"`
-	Currency                  Currency          `json:"currency"`
-	Destination               PayoutDestination `json:"destination"`
-	FailCode                  PayoutFailureCode `json:"failure_code"`
-	FailMessage               string            `json:"failure_message"`
-	FailureBalanceTransaction *Transaction      `json:"failure_balance_transaction"`
-	ID                        string            `json:"id"`
-	Live                      bool              `json:"livemode"`
-	Meta                      map[string]string `json:"metadata"`
-	Method                    PayoutMethodType  `json:"method"`
-	SourceType                PayoutSourceType  `json:"source_type"`
-	StatementDescriptor       string            `json:"statement_descriptor"`
-	Status                    PayoutStatus      `json:"status"`
-	Type                      PayoutType        `json:"type"`
+	Amount                    int64                    `json:"amount"`
+	ArrivalDate               int64                    `json:"arrival_date"`
+	Automatic                 bool                     `json:"automatic"`
+	BalanceTransaction        *Expandable[Transaction] `json:"balance_transaction"`
+	Bank                      *BankAccount             `json:"bank_account"`
+	Card                      *Card                    `json:"card"`
+	Created                   int64                    `json:"created"`
+	Currency                  Currency                 `json:"currency"`
+	Destination               PayoutDestination        `json:"destination"`
+	FailCode                  PayoutFailureCode        `json:"failure_code"`
+	FailMessage               string                   `json:"failure_message"`
+	FailureBalanceTransaction *Expandable[Transaction] `json:"failure_balance_transaction"`
+	ID                        string                   `json:"id"`
+	Live                      bool                     `json:"livemode"`
+	Meta                      map[string]string        `json:"metadata"`
+	Method                    PayoutMethodType         `json:"method"`
+	SourceType                PayoutSourceType         `json:"source_type"`
+	StatementDescriptor       string                   `json:"statement_descriptor"`
+	Status                    PayoutStatus             `json:"status"`
+	Type                      PayoutType               `json:"type"`
 }
 
-// PayoutList is a list of payouts as retrieved from a list endpoint.
-type PayoutList struct {
-	ListMeta
-	Values []*Payout `json:"data"`
+// AmountMoney returns the amount of the payout in its currency.
+func (p *Payout) AmountMoney() Money {
+	return Money{Amount: p.Amount, Currency: p.Currency}
 }
 
+// PayoutList is a list of payouts as retrieved from a list endpoint.
+type PayoutList = List[*Payout]
+
 // UnmarshalJSON handles deserialization of a Payout.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (t *Payout) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		t.ID = id
+		return nil
+	}
+
 	type payout Payout
 	var tb payout
 	err := json.Unmarshal(data, &tb)
-	if err == nil {
-		*t = Payout(tb)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		t.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*t = Payout(tb)
 	return nil
 }
 
@@ -130,28 +135,28 @@ func (t *Payout) UnmarshalJSON(data []byte) error {
 // This custom unmarshaling is needed because the specific
 // type of destination it refers to is specified in the JSON
 func (d *PayoutDestination) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		d.ID = id
+		return nil
+	}
+
 	type dest PayoutDestination
 	var dd dest
 	err := json.Unmarshal(data, &dd)
-	if err == nil {
-		*d = PayoutDestination(dd)
-
-		switch d.Type {
-		case PayoutDestinationBankAccount:
-			err = json.Unmarshal(data, &d.BankAccount)
-		case PayoutDestinationCard:
-			err = json.Unmarshal(data, &d.Card)
-		}
-
-		if err != nil {
-			return err
-		}
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		d.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
-	return nil
+	*d = PayoutDestination(dd)
+
+	switch d.Type {
+	case PayoutDestinationBankAccount:
+		err = json.Unmarshal(data, &d.BankAccount)
+	case PayoutDestinationCard:
+		err = json.Unmarshal(data, &d.Card)
+	}
+
+	return err
 }
 
 // MarshalJSON handles serialization of a PayoutDestination.
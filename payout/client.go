@@ -48,6 +48,10 @@ func New(params *stripe.PayoutParams) (*stripe.Payout, error) {
 }
 
 func (c Client) New(params *stripe.PayoutParams) (*stripe.Payout, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -64,6 +68,10 @@ func Get(id string, params *stripe.PayoutParams) (*stripe.Payout, error) {
 }
 
 func (c Client) Get(id string, params *stripe.PayoutParams) (*stripe.Payout, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -86,6 +94,10 @@ func Update(id string, params *stripe.PayoutParams) (*stripe.Payout, error) {
 }
 
 func (c Client) Update(id string, params *stripe.PayoutParams) (*stripe.Payout, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -108,6 +120,10 @@ func Cancel(id string, params *stripe.PayoutParams) (*stripe.Payout, error) {
 }
 
 func (c Client) Cancel(id string, params *stripe.PayoutParams) (*stripe.Payout, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -132,25 +148,18 @@ func List(params *stripe.PayoutListParams) *Iter {
 func (c Client) List(params *stripe.PayoutListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Payout, stripe.ListMeta, error) {
 		list := &stripe.PayoutList{}
 		err := c.B.Call("GET", "/payouts", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -158,13 +167,13 @@ func (c Client) List(params *stripe.PayoutListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Payout]
 }
 
 // Payout returns the most recent Payout
 // visited by a call to Next.
 func (i *Iter) Payout() *stripe.Payout {
-	return i.Current().(*stripe.Payout)
+	return i.Current()
 }
 
 func getC() Client {
@@ -1,6 +1,8 @@
 package stripe
 
 import (
+	"fmt"
+	"net/url"
 	"strconv"
 
 	"github.com/stripe/stripe-go/form"
@@ -59,12 +61,14 @@ type Plan struct {
 	UsageType      string              `json:"usage_type"`
 }
 
-// PlanList is a list of plans as returned from a list endpoint.
-type PlanList struct {
-	ListMeta
-	Values []*Plan `json:"data"`
+// AmountMoney returns the amount the plan charges per interval in its currency.
+func (p *Plan) AmountMoney() Money {
+	return moneyFromUint64(p.Amount, p.Currency)
 }
 
+// PlanList is a list of plans as returned from a list endpoint.
+type PlanList = List[*Plan]
+
 // PlanListParams is the set of parameters that can be used when listing plans.
 // For more details see https://stripe.com/docs/api#list_plans.
 type PlanListParams struct {
@@ -77,12 +81,12 @@ type PlanListParams struct {
 // For more details see https://stripe.com/docs/api#create_plan and https://stripe.com/docs/api#update_plan.
 type PlanParams struct {
 	Params         `form:"*"`
-	Amount         uint64                    `form:"amount"`
-	AmountZero     bool                      `form:"amount,zero"`
+	Amount         *uint64                   `form:"amount"`
+	AmountZero     bool                      `form:"amount,zero"` // Deprecated: use Amount
 	BillingScheme  string                    `form:"billing_scheme"`
-	Currency       Currency                  `form:"currency"`
+	Currency       Currency                  `form:"currency" validate:"required"`
 	ID             string                    `form:"id"`
-	Interval       PlanInterval              `form:"interval"`
+	Interval       PlanInterval              `form:"interval" validate:"required,oneof=day week month year"`
 	IntervalCount  uint64                    `form:"interval_count"`
 	Nickname       string                    `form:"nickname"`
 	Product        *PlanProductParams        `form:"product"`
@@ -94,6 +98,15 @@ type PlanParams struct {
 	UsageType      string                    `form:"usage_type"`
 }
 
+// Validate implements Validator, checking that only one of Product and
+// ProductID is set as they're both the plan's product.
+func (p *PlanParams) Validate() error {
+	if p.Product != nil && p.ProductID != nil {
+		return newValidationError("product", "Invalid product: Product and ProductID can't both be set.")
+	}
+	return nil
+}
+
 // PlanTier configures tiered pricing
 type PlanTier struct {
 	Amount uint64 `json:"amount"`
@@ -129,6 +142,26 @@ func (p *PlanTierParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// UnmarshalForm implements form.Unmarshaler for the up_to value encoded by the
+// custom AppendTo.
+func (p *PlanTierParams) UnmarshalForm(values url.Values, keyParts []string) error {
+	switch upTo := values.Get(form.FormatKey(append(keyParts, "up_to"))); upTo {
+	case "":
+	case "inf":
+		p.UpToInf = true
+	default:
+		var err error
+		p.UpTo, err = strconv.ParseUint(upTo, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Cannot decode %v into uint64: %v",
+				form.FormatKey(append(keyParts, "up_to")), err)
+		}
+	}
+
+	type planTierParams PlanTierParams
+	return form.UnmarshalPrefixed(values, (*planTierParams)(p), keyParts)
+}
+
 // PlanProductParams is the set of parameters that can be used when creating a product inside a plan
 // This can only be used on plan creation and won't work on plan update.
 // For more details see https://stripe.com/docs/api#create_plan-product and https://stripe.com/docs/api#update_plan-product
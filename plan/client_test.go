@@ -31,7 +31,7 @@ func TestPlanList(t *testing.T) {
 
 func TestPlanNew(t *testing.T) {
 	plan, err := New(&stripe.PlanParams{
-		Amount:   1,
+		Amount:   stripe.Uint64(1),
 		Currency: "usd",
 		ID:       "sapphire-elite",
 		Interval: "month",
@@ -48,7 +48,7 @@ func TestPlanNew(t *testing.T) {
 func TestPlanNewWithProductID(t *testing.T) {
 	productId := "prod_12345abc"
 	plan, err := New(&stripe.PlanParams{
-		Amount:    1,
+		Amount:    stripe.Uint64(1),
 		Currency:  "usd",
 		ID:        "sapphire-elite",
 		Interval:  "month",
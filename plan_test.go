@@ -52,9 +52,10 @@ func TestPlanParams_AppendTo(t *testing.T) {
 		want   interface{}
 	}{
 		{"amount", &PlanParams{}, ""},
-		{"amount", &PlanParams{Amount: 0, AmountZero: false}, ""},
-		{"amount", &PlanParams{Amount: 0, AmountZero: true}, strconv.FormatUint(0, 10)},
-		{"amount", &PlanParams{Amount: 123}, strconv.FormatUint(123, 10)},
+		{"amount", &PlanParams{AmountZero: false}, ""},
+		{"amount", &PlanParams{AmountZero: true}, strconv.FormatUint(0, 10)},
+		{"amount", &PlanParams{Amount: Uint64(0)}, strconv.FormatUint(0, 10)},
+		{"amount", &PlanParams{Amount: Uint64(123)}, strconv.FormatUint(123, 10)},
 		{"currency", &PlanParams{Currency: "USD"}, "USD"},
 		{"id", &PlanParams{ID: "sapphire-elite"}, "sapphire-elite"},
 		{"interval_count", &PlanParams{IntervalCount: 3}, strconv.FormatUint(3, 10)},
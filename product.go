@@ -69,10 +69,7 @@ type Product struct {
 }
 
 // ProductList is a list of products as retrieved from a list endpoint.
-type ProductList struct {
-	ListMeta
-	Values []*Product `json:"data"`
-}
+type ProductList = List[*Product]
 
 // ProductListParams is the set of parameters that can be used when
 // listing products. For more details, see:
@@ -89,14 +86,18 @@ type ProductListParams struct {
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (p *Product) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		p.ID = id
+		return nil
+	}
+
 	type product Product
 	var pr product
 	err := json.Unmarshal(data, &pr)
-	if err == nil {
-		*p = Product(pr)
-	} else {
-		p.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*p = Product(pr)
 	return nil
 }
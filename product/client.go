@@ -20,6 +20,10 @@ func New(params *stripe.ProductParams) (*stripe.Product, error) {
 // New POSTs a new product.
 // For more details see https://stripe.com/docs/api#create_product.
 func (c Client) New(params *stripe.ProductParams) (*stripe.Product, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -44,6 +48,10 @@ func Update(id string, params *stripe.ProductParams) (*stripe.Product, error) {
 // Update updates a product's properties.
 // For more details see https://stripe.com/docs/api#update_product.
 func (c Client) Update(id string, params *stripe.ProductParams) (*stripe.Product, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -66,6 +74,10 @@ func Get(id string, params *stripe.ProductParams) (*stripe.Product, error) {
 }
 
 func (c Client) Get(id string, params *stripe.ProductParams) (*stripe.Product, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -90,25 +102,18 @@ func List(params *stripe.ProductListParams) *Iter {
 func (c Client) List(params *stripe.ProductListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Product, stripe.ListMeta, error) {
 		list := &stripe.ProductList{}
 		err := c.B.Call("GET", "/products", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -116,13 +121,13 @@ func (c Client) List(params *stripe.ProductListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Product]
 }
 
 // Product returns the most recent Product
 // visited by a call to Next.
 func (i *Iter) Product() *stripe.Product {
-	return i.Current().(*stripe.Product)
+	return i.Current()
 }
 
 // Delete deletes a product
@@ -134,6 +139,10 @@ func Del(id string, params *stripe.ProductParams) (*stripe.Product, error) {
 // Delete deletes a product.
 // For more details see https://stripe.com/docs/api#delete_product.
 func (c Client) Del(id string, params *stripe.ProductParams) (*stripe.Product, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -0,0 +1,301 @@
+package stripe
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default rates of a RateLimiter created with NewRateLimiter, in requests
+// per second. They match the limits Stripe applies to live and test mode
+// requests.
+const (
+	DefaultLiveRate = 100
+	DefaultTestRate = 25
+)
+
+// rateLimitDecrease is the factor a rate is multiplied by when Stripe
+// responds with 429 Too Many Requests, and rateLimitIncrease the fraction of
+// the configured rate it recovers by with every other response.
+const (
+	rateLimitDecrease = 0.5
+	rateLimitIncrease = 0.1
+
+	// rateLimitMinFraction is the smallest fraction of the configured rate
+	// a rate decreases to.
+	rateLimitMinFraction = 0.05
+)
+
+// RequestRate is the pace of a class of requests.
+type RequestRate struct {
+	// Rate is the number of requests per second allowed. Requests aren't
+	// paced when it's zero.
+	Rate float64
+
+	// Burst is the number of requests that can be made at once before
+	// being paced. It defaults to Rate, and to at least one.
+	Burst int
+}
+
+// RateLimiter paces the requests made by backends sharing it, so that they
+// stay within Stripe's rate limits instead of failing with a RateLimitError.
+// It's a token bucket for each class of requests: reads (GET requests) and
+// writes, made with live mode or test mode keys.
+//
+// When Stripe responds with 429 Too Many Requests, which happens when other
+// processes use the same account, the rate of the class of the request is
+// halved. It then recovers gradually with every successful response.
+//
+// A RateLimiter is safe for concurrent use. Its limits must not be changed
+// once it's in use.
+type RateLimiter struct {
+	LiveRead  RequestRate
+	LiveWrite RequestRate
+	TestRead  RequestRate
+	TestWrite RequestRate
+
+	mu      sync.Mutex
+	buckets map[rateLimitClass]*tokenBucket
+
+	// now returns the current time. It's only replaced in tests.
+	now func() time.Time
+}
+
+// RateLimitStats are statistics about the requests of a class paced by a
+// RateLimiter.
+type RateLimitStats struct {
+	// Rate is the current rate in requests per second, which is lower than
+	// the configured one after requests were throttled by Stripe.
+	Rate float64
+
+	// Requests is the number of requests paced.
+	Requests int64
+
+	// Waits is the number of requests that had to wait.
+	Waits int64
+
+	// TotalWait and MaxWait are the total and longest time requests waited.
+	TotalWait time.Duration
+	MaxWait   time.Duration
+
+	// Throttled is the number of requests that Stripe responded to with 429
+	// Too Many Requests.
+	Throttled int64
+}
+
+// RateLimiterStats are the statistics of a RateLimiter for every class of
+// requests.
+type RateLimiterStats struct {
+	LiveRead  RateLimitStats
+	LiveWrite RateLimitStats
+	TestRead  RateLimitStats
+	TestWrite RateLimitStats
+}
+
+// NewRateLimiter returns a RateLimiter with the default rates.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		LiveRead:  RequestRate{Rate: DefaultLiveRate},
+		LiveWrite: RequestRate{Rate: DefaultLiveRate},
+		TestRead:  RequestRate{Rate: DefaultTestRate},
+		TestWrite: RequestRate{Rate: DefaultTestRate},
+	}
+}
+
+// Stats returns the limiter's current statistics.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := func(class rateLimitClass) RateLimitStats {
+		b := l.bucket(class)
+		if b == nil {
+			return RateLimitStats{}
+		}
+		s := b.stats
+		s.Rate = b.rate
+		return s
+	}
+
+	return RateLimiterStats{
+		LiveRead:  stats(rateLimitClass{live: true}),
+		LiveWrite: stats(rateLimitClass{live: true, write: true}),
+		TestRead:  stats(rateLimitClass{}),
+		TestWrite: stats(rateLimitClass{write: true}),
+	}
+}
+
+// wait blocks until req may be sent, or until its context is done.
+func (l *RateLimiter) wait(req *http.Request) error {
+	if l == nil {
+		return nil
+	}
+
+	class := newRateLimitClass(req)
+
+	l.mu.Lock()
+	b := l.bucket(class)
+	if b == nil {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := b.reserve(l.timeNow())
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := sleepContext(req.Context(), delay); err != nil {
+		// The request won't be sent, so its token can be used by
+		// another one.
+		l.mu.Lock()
+		b.cancel(delay)
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// observe adapts the rate of the class of req to the status Stripe responded
+// with.
+func (l *RateLimiter) observe(req *http.Request, status int) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(newRateLimitClass(req))
+	if b == nil {
+		return
+	}
+
+	b.refill(l.timeNow())
+	switch {
+	case status == http.StatusTooManyRequests:
+		b.stats.Throttled++
+		b.rate = math.Max(b.rate*rateLimitDecrease, b.limit.Rate*rateLimitMinFraction)
+	case status < http.StatusBadRequest:
+		b.rate = math.Min(b.rate+b.limit.Rate*rateLimitIncrease, b.limit.Rate)
+	}
+}
+
+// bucket returns the bucket of a class of requests, or nil if they aren't
+// paced. It must be called with the lock held.
+func (l *RateLimiter) bucket(class rateLimitClass) *tokenBucket {
+	if b, ok := l.buckets[class]; ok {
+		return b
+	}
+
+	var limit RequestRate
+	switch class {
+	case rateLimitClass{live: true}:
+		limit = l.LiveRead
+	case rateLimitClass{live: true, write: true}:
+		limit = l.LiveWrite
+	case rateLimitClass{}:
+		limit = l.TestRead
+	case rateLimitClass{write: true}:
+		limit = l.TestWrite
+	}
+
+	var b *tokenBucket
+	if limit.Rate > 0 {
+		b = newTokenBucket(limit, l.timeNow())
+	}
+
+	if l.buckets == nil {
+		l.buckets = make(map[rateLimitClass]*tokenBucket)
+	}
+	l.buckets[class] = b
+	return b
+}
+
+func (l *RateLimiter) timeNow() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+	return time.Now()
+}
+
+// rateLimitClass is a class of requests with its own rate limit.
+type rateLimitClass struct {
+	live  bool
+	write bool
+}
+
+// newRateLimitClass returns the class of req, according to its method and the
+// mode of the key it's authenticated with.
+func newRateLimitClass(req *http.Request) rateLimitClass {
+	key := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	return rateLimitClass{
+		live:  strings.Contains(key, "_live_"),
+		write: req.Method != http.MethodGet && req.Method != http.MethodHead,
+	}
+}
+
+// tokenBucket paces requests at a rate which may be lowered below the
+// configured one. Tokens can be reserved ahead of time, making the count
+// negative, so that waiting requests are sent in order.
+type tokenBucket struct {
+	limit  RequestRate
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+}
+
+func newTokenBucket(limit RequestRate, now time.Time) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Floor(limit.Rate))
+	}
+
+	return &tokenBucket{
+		limit:  limit,
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.tokens+elapsed.Seconds()*b.rate, b.burst)
+		b.last = now
+	}
+}
+
+// reserve takes a token, returning how long to wait until it's available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+	b.stats.Requests++
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.stats.Waits++
+	b.stats.TotalWait += delay
+	if delay > b.stats.MaxWait {
+		b.stats.MaxWait = delay
+	}
+	return delay
+}
+
+// cancel gives back a token reserved for a request that isn't sent.
+func (b *tokenBucket) cancel(delay time.Duration) {
+	b.tokens++
+	b.stats.Requests--
+	b.stats.Waits--
+	b.stats.TotalWait -= delay
+}
@@ -0,0 +1,146 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func newRateLimitRequest(t *testing.T, ctx context.Context, method, key string) *http.Request {
+	req, err := http.NewRequest(method, "https://api.stripe.com/v1/charges", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+key)
+	return req.WithContext(ctx)
+}
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	now := time.Now()
+	l := &RateLimiter{TestWrite: RequestRate{Rate: 10, Burst: 2}}
+	l.now = func() time.Time { return now }
+
+	b := l.bucket(rateLimitClass{write: true})
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, b.reserve(now))
+	assert.Equal(t, 200*time.Millisecond, b.reserve(now))
+
+	// Tokens accumulate up to the burst.
+	now = now.Add(time.Hour)
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, b.reserve(now))
+
+	stats := l.Stats().TestWrite
+	assert.Equal(t, int64(7), stats.Requests)
+	assert.Equal(t, int64(3), stats.Waits)
+	assert.Equal(t, 400*time.Millisecond, stats.TotalWait)
+	assert.Equal(t, 200*time.Millisecond, stats.MaxWait)
+	assert.Equal(t, float64(10), stats.Rate)
+
+	// Other classes aren't paced.
+	assert.Nil(t, l.bucket(rateLimitClass{}))
+	assert.Equal(t, RateLimitStats{}, l.Stats().LiveRead)
+}
+
+func TestRateLimiter_Classes(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, rateLimitClass{live: true},
+		newRateLimitClass(newRateLimitRequest(t, ctx, "GET", "sk_live_123")))
+	assert.Equal(t, rateLimitClass{live: true, write: true},
+		newRateLimitClass(newRateLimitRequest(t, ctx, "POST", "rk_live_123")))
+	assert.Equal(t, rateLimitClass{},
+		newRateLimitClass(newRateLimitRequest(t, ctx, "GET", "sk_test_123")))
+	assert.Equal(t, rateLimitClass{write: true},
+		newRateLimitClass(newRateLimitRequest(t, ctx, "DELETE", "sk_test_123")))
+}
+
+func TestRateLimiter_Adapt(t *testing.T) {
+	l := NewRateLimiter()
+	req := newRateLimitRequest(t, context.Background(), "GET", "sk_live_123")
+
+	l.observe(req, http.StatusTooManyRequests)
+	l.observe(req, http.StatusTooManyRequests)
+	assert.Equal(t, float64(DefaultLiveRate)/4, l.Stats().LiveRead.Rate)
+	assert.Equal(t, int64(2), l.Stats().LiveRead.Throttled)
+
+	// Client errors leave the rate as it is.
+	l.observe(req, http.StatusNotFound)
+	assert.Equal(t, float64(DefaultLiveRate)/4, l.Stats().LiveRead.Rate)
+
+	for i := 0; i < 100; i++ {
+		l.observe(req, http.StatusTooManyRequests)
+	}
+	assert.Equal(t, float64(DefaultLiveRate)*rateLimitMinFraction, l.Stats().LiveRead.Rate)
+
+	for i := 0; i < 100; i++ {
+		l.observe(req, http.StatusOK)
+	}
+	assert.Equal(t, float64(DefaultLiveRate), l.Stats().LiveRead.Rate)
+
+	// Other classes keep their own rate.
+	assert.Equal(t, float64(DefaultLiveRate), l.Stats().LiveWrite.Rate)
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	l := &RateLimiter{TestRead: RequestRate{Rate: 0.001}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, l.wait(newRateLimitRequest(t, ctx, "GET", "sk_test_123")))
+	assert.Equal(t, context.DeadlineExceeded, l.wait(newRateLimitRequest(t, ctx, "GET", "sk_test_123")))
+
+	stats := l.Stats().TestRead
+	assert.Equal(t, int64(1), stats.Requests)
+	assert.Equal(t, int64(0), stats.Waits)
+}
+
+func TestDo_RateLimiter(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		throttled := requests == 1
+		mu.Unlock()
+
+		if throttled {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":{"type":"rate_limit_error","message":"slow down"}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	limiter := &RateLimiter{TestRead: RequestRate{Rate: 200, Burst: 1}}
+	c := &BackendConfiguration{
+		Type:        APIBackend,
+		URL:         ts.URL,
+		HTTPClient:  &http.Client{},
+		RetryPolicy: &RetryPolicy{MaxRetries: 1, MinDelay: time.Millisecond},
+		RateLimiter: limiter,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.Call("GET", "/charges", "sk_test_123", nil, nil, nil))
+		}()
+	}
+	wg.Wait()
+
+	stats := limiter.Stats().TestRead
+	assert.Equal(t, 6, requests)
+	assert.Equal(t, int64(6), stats.Requests)
+	assert.True(t, stats.Waits > 0)
+	assert.True(t, stats.MaxWait > 0)
+	assert.Equal(t, int64(1), stats.Throttled)
+}
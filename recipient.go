@@ -49,40 +49,40 @@ type RecipientListParams struct {
 // Recipient is the resource representing a Stripe recipient.
 // For more details see https://stripe.com/docs/api#recipients.
 type Recipient struct {
-	Bank        *BankAccount      `json:"active_account"`
-	Cards       *CardList         `json:"cards"`
-	Created     int64             `json:"created"`
-	DefaultCard *Card             `json:"default_card"`
-	Deleted     bool              `json:"deleted"`
-	Desc        string            `json:"description"`
-	Email       string            `json:"email"`
-	ID          string            `json:"id"`
-	Live        bool              `json:"livemode"`
-	Meta        map[string]string `json:"metadata"`
-	MigratedTo  *Account          `json:"migrated_to"`
-	Name        string            `json:"name"`
-	Type        RecipientType     `json:"type"`
+	Bank        *BankAccount         `json:"active_account"`
+	Cards       *CardList            `json:"cards"`
+	Created     int64                `json:"created"`
+	DefaultCard *Expandable[Card]    `json:"default_card"`
+	Deleted     bool                 `json:"deleted"`
+	Desc        string               `json:"description"`
+	Email       string               `json:"email"`
+	ID          string               `json:"id"`
+	Live        bool                 `json:"livemode"`
+	Meta        map[string]string    `json:"metadata"`
+	MigratedTo  *Expandable[Account] `json:"migrated_to"`
+	Name        string               `json:"name"`
+	Type        RecipientType        `json:"type"`
 }
 
 // RecipientList is a list of recipients as retrieved from a list endpoint.
-type RecipientList struct {
-	ListMeta
-	Values []*Recipient `json:"data"`
-}
+type RecipientList = List[*Recipient]
 
 // UnmarshalJSON handles deserialization of a Recipient.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (r *Recipient) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		r.ID = id
+		return nil
+	}
+
 	type recipient Recipient
 	var rr recipient
 	err := json.Unmarshal(data, &rr)
-	if err == nil {
-		*r = Recipient(rr)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		r.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*r = Recipient(rr)
 	return nil
 }
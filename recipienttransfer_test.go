@@ -47,7 +47,7 @@ func TestRecipientTransferUnmarshal(t *testing.T) {
 
 	if recipientTransfer.BalanceTransaction == nil {
 		t.Errorf("Problem deserializing balance_transaction, got nothing.")
-	} else if recipientTransfer.BalanceTransaction.ID != "txn_xxx" {
+	} else if recipientTransfer.BalanceTransaction.ID() != "txn_xxx" {
 		t.Errorf("Problem deserializing balance_transaction, got %v", recipientTransfer.BalanceTransaction)
 	}
 
@@ -16,7 +16,7 @@ type RefundStatus string
 type RefundParams struct {
 	Params   `form:"*"`
 	Amount   uint64       `form:"amount"`
-	Charge   string       `form:"charge"`
+	Charge   string       `form:"charge" validate:"required"`
 	Fee      bool         `form:"refund_application_fee"`
 	Reason   RefundReason `form:"reason"`
 	Transfer bool         `form:"reverse_transfer"`
@@ -31,37 +31,42 @@ type RefundListParams struct {
 // Refund is the resource representing a Stripe refund.
 // For more details see https://stripe.com/docs/api#refunds.
 type Refund struct {
-	Amount        uint64            `json:"amount"`
-	Charge        *Charge           `json:"charge"`
-	Created       int64             `json:"created"`
-	Currency      Currency          `json:"currency"`
-	ID            string            `json:"id"`
-	Meta          map[string]string `json:"metadata"`
-	Reason        RefundReason      `json:"reason"`
-	ReceiptNumber string            `json:"receipt_number"`
-	Status        RefundStatus      `json:"status"`
-	Tx            *Transaction      `json:"balance_transaction"`
+	Amount        uint64                   `json:"amount"`
+	Charge        *Expandable[Charge]      `json:"charge"`
+	Created       int64                    `json:"created"`
+	Currency      Currency                 `json:"currency"`
+	ID            string                   `json:"id"`
+	Meta          map[string]string        `json:"metadata"`
+	Reason        RefundReason             `json:"reason"`
+	ReceiptNumber string                   `json:"receipt_number"`
+	Status        RefundStatus             `json:"status"`
+	Tx            *Expandable[Transaction] `json:"balance_transaction"`
 }
 
-// RefundList is a list object for refunds.
-type RefundList struct {
-	ListMeta
-	Values []*Refund `json:"data"`
+// AmountMoney returns the amount of the refund in its currency.
+func (r *Refund) AmountMoney() Money {
+	return moneyFromUint64(r.Amount, r.Currency)
 }
 
+// RefundList is a list object for refunds.
+type RefundList = List[*Refund]
+
 // UnmarshalJSON handles deserialization of a Refund.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (r *Refund) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		r.ID = id
+		return nil
+	}
+
 	type refund Refund
 	var rr refund
 	err := json.Unmarshal(data, &rr)
-	if err == nil {
-		*r = Refund(rr)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		r.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*r = Refund(rr)
 	return nil
 }
@@ -25,6 +25,10 @@ func New(params *stripe.RefundParams) (*stripe.Refund, error) {
 }
 
 func (c Client) New(params *stripe.RefundParams) (*stripe.Refund, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -41,6 +45,10 @@ func Get(id string, params *stripe.RefundParams) (*stripe.Refund, error) {
 }
 
 func (c Client) Get(id string, params *stripe.RefundParams) (*stripe.Refund, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -63,6 +71,10 @@ func Update(id string, params *stripe.RefundParams) (*stripe.Refund, error) {
 }
 
 func (c Client) Update(id string, params *stripe.RefundParams) (*stripe.Refund, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -81,22 +93,15 @@ func List(params *stripe.RefundListParams) *Iter {
 func (c Client) List(params *stripe.RefundListParams) *Iter {
 	body := &form.Values{}
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	form.AppendTo(body, params)
 	lp = &params.ListParams
-	p = params.ToParams()
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Refund, stripe.ListMeta, error) {
 		list := &stripe.RefundList{}
 		err := c.B.Call("GET", "/refunds", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -104,13 +109,13 @@ func (c Client) List(params *stripe.RefundListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Refund]
 }
 
 // Refund returns the most recent Refund
 // visited by a call to Next.
 func (i *Iter) Refund() *stripe.Refund {
-	return i.Current().(*stripe.Refund)
+	return i.Current()
 }
 
 func getC() Client {
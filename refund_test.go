@@ -31,7 +31,7 @@ func TestRefundUnmarshal(t *testing.T) {
 		t.Errorf("Problem deserializing refund, didn't get a Charge")
 	}
 
-	if refund.Charge.ID != "ch_1234" {
+	if refund.Charge.ID() != "ch_1234" {
 		t.Errorf("Problem deserializing refund.charge, wrong value for ID")
 	}
 }
@@ -65,7 +65,7 @@ func TestRefundUnmarshalExpandedCharge(t *testing.T) {
 		t.Errorf("Problem deserializing refund, didn't get a Charge")
 	}
 
-	if refund.Charge.ID != "ch_1234" {
+	if refund.Charge.ID() != "ch_1234" {
 		t.Errorf("Problem deserializing refund.charge, wrong value for ID")
 	}
 }
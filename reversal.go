@@ -18,34 +18,39 @@ type ReversalListParams struct {
 
 // Reversal represents a transfer reversal.
 type Reversal struct {
-	Amount   uint64            `json:"amount"`
-	Created  int64             `json:"created"`
-	Currency Currency          `json:"currency"`
-	ID       string            `json:"id"`
-	Meta     map[string]string `json:"metadata"`
-	Transfer string            `json:"transfer"`
-	Tx       *Transaction      `json:"balance_transaction"`
+	Amount   uint64                   `json:"amount"`
+	Created  int64                    `json:"created"`
+	Currency Currency                 `json:"currency"`
+	ID       string                   `json:"id"`
+	Meta     map[string]string        `json:"metadata"`
+	Transfer string                   `json:"transfer"`
+	Tx       *Expandable[Transaction] `json:"balance_transaction"`
 }
 
-// ReversalList is a list of object for reversals.
-type ReversalList struct {
-	ListMeta
-	Values []*Reversal `json:"data"`
+// AmountMoney returns the amount of the reversal in its currency.
+func (r *Reversal) AmountMoney() Money {
+	return moneyFromUint64(r.Amount, r.Currency)
 }
 
+// ReversalList is a list of object for reversals.
+type ReversalList = List[*Reversal]
+
 // UnmarshalJSON handles deserialization of a Reversal.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (r *Reversal) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		r.ID = id
+		return nil
+	}
+
 	type reversal Reversal
 	var rr reversal
 	err := json.Unmarshal(data, &rr)
-	if err == nil {
-		*r = Reversal(rr)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		r.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*r = Reversal(rr)
 	return nil
 }
@@ -20,6 +20,10 @@ func New(params *stripe.ReversalParams) (*stripe.Reversal, error) {
 }
 
 func (c Client) New(params *stripe.ReversalParams) (*stripe.Reversal, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -35,6 +39,10 @@ func Get(id string, params *stripe.ReversalParams) (*stripe.Reversal, error) {
 }
 
 func (c Client) Get(id string, params *stripe.ReversalParams) (*stripe.Reversal, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	if params == nil {
 		return nil, fmt.Errorf("params cannot be nil, and params.Transfer must be set")
 	}
@@ -54,6 +62,10 @@ func Update(id string, params *stripe.ReversalParams) (*stripe.Reversal, error)
 }
 
 func (c Client) Update(id string, params *stripe.ReversalParams) (*stripe.Reversal, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -71,19 +83,13 @@ func List(params *stripe.ReversalListParams) *Iter {
 func (c Client) List(params *stripe.ReversalListParams) *Iter {
 	body := &form.Values{}
 	var lp *stripe.ListParams = &params.ListParams
-	var p *stripe.Params = params.ToParams()
 	form.AppendTo(body, params)
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Reversal, stripe.ListMeta, error) {
 		list := &stripe.ReversalList{}
 		err := c.B.Call("GET", fmt.Sprintf("/transfers/%v/reversals", params.Transfer), c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -91,13 +97,13 @@ func (c Client) List(params *stripe.ReversalListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Reversal]
 }
 
 // Refund returns the most recent Reversals
 // visited by a call to Next.
 func (i *Iter) Reversal() *stripe.Reversal {
-	return i.Current().(*stripe.Reversal)
+	return i.Current()
 }
 
 func getC() Client {
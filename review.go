@@ -17,25 +17,27 @@ const (
 )
 
 type Review struct {
-	Charge  *Charge    `json:"charge"`
-	Created int64      `json:"created"`
-	ID      string     `json:"id"`
-	Live    bool       `json:"livemode"`
-	Open    bool       `json:"open"`
-	Reason  ReasonType `json:"reason"`
+	Charge  *Expandable[Charge] `json:"charge"`
+	Created int64               `json:"created"`
+	ID      string              `json:"id"`
+	Live    bool                `json:"livemode"`
+	Open    bool                `json:"open"`
+	Reason  ReasonType          `json:"reason"`
 }
 
 func (r *Review) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		r.ID = id
+		return nil
+	}
+
 	type review Review
 	var rr review
-
 	err := json.Unmarshal(data, &rr)
-	if err == nil {
-		*r = Review(rr)
-	} else {
-		// Otherwise...we have to strip the escaping
-		r.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*r = Review(rr)
 	return nil
 }
@@ -39,10 +39,7 @@ type SKU struct {
 	Updated           int64              `json:"updated"`
 }
 
-type SKUList struct {
-	ListMeta
-	Values []*SKU `json:"data"`
-}
+type SKUList = List[*SKU]
 
 type SKUListParams struct {
 	ListParams `form:"*"`
@@ -54,14 +51,18 @@ type SKUListParams struct {
 }
 
 func (s *SKU) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		s.ID = id
+		return nil
+	}
+
 	type sku SKU
 	var sk sku
 	err := json.Unmarshal(data, &sk)
-	if err == nil {
-		*s = SKU(sk)
-	} else {
-		s.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*s = SKU(sk)
 	return nil
 }
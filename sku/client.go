@@ -20,6 +20,10 @@ func New(params *stripe.SKUParams) (*stripe.SKU, error) {
 // New POSTs a new SKU.
 // For more details see https://stripe.com/docs/api#create_sku.
 func (c Client) New(params *stripe.SKUParams) (*stripe.SKU, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -44,6 +48,10 @@ func Update(id string, params *stripe.SKUParams) (*stripe.SKU, error) {
 // Update updates a SKU's properties.
 // For more details see https://stripe.com/docs/api#update_sku.
 func (c Client) Update(id string, params *stripe.SKUParams) (*stripe.SKU, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -66,6 +74,10 @@ func Get(id string, params *stripe.SKUParams) (*stripe.SKU, error) {
 }
 
 func (c Client) Get(id string, params *stripe.SKUParams) (*stripe.SKU, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -90,25 +102,18 @@ func List(params *stripe.SKUListParams) *Iter {
 func (c Client) List(params *stripe.SKUListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.SKU, stripe.ListMeta, error) {
 		list := &stripe.SKUList{}
 		err := c.B.Call("GET", "/skus", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -116,13 +121,13 @@ func (c Client) List(params *stripe.SKUListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.SKU]
 }
 
 // SKU returns the most recent SKU
 // visited by a call to Next.
 func (i *Iter) SKU() *stripe.SKU {
-	return i.Current().(*stripe.SKU)
+	return i.Current()
 }
 
 // Delete destroys a SKU.
@@ -134,6 +139,10 @@ func Del(id string, params *stripe.SKUParams) (*stripe.SKU, error) {
 // Delete destroys a SKU.
 // For more details see https://stripe.com/docs/api#delete_sku.
 func (c Client) Del(id string, params *stripe.SKUParams) (*stripe.SKU, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -80,13 +80,13 @@ type SourceObjectParams struct {
 	Amount              uint64             `form:"amount"`
 	Currency            Currency           `form:"currency"`
 	Customer            string             `form:"customer"`
-	Flow                SourceFlow         `form:"flow"`
+	Flow                SourceFlow         `form:"flow" validate:"oneof=code_verification none receiver redirect"`
 	OriginalSource      string             `form:"original_source"`
 	Owner               *SourceOwnerParams `form:"owner"`
 	Redirect            *RedirectParams    `form:"redirect"`
 	StatementDescriptor string             `form:"statement_descriptor"`
 	Token               string             `form:"token"`
-	Type                string             `form:"type"`
+	Type                string             `form:"type" validate:"required"`
 	TypeData            map[string]string  `form:"-"`
 	Usage               SourceUsage        `form:"usage"`
 }
@@ -9,10 +9,7 @@ type SourceTransactionListParams struct {
 }
 
 // SourceTransactionList is a list object for SourceTransactions.
-type SourceTransactionList struct {
-	ListMeta
-	Values []*SourceTransaction `json:"data"`
-}
+type SourceTransactionList = List[*SourceTransaction]
 
 // SourceTransaction is the resource representing a Stripe source transaction.
 type SourceTransaction struct {
@@ -24,13 +24,11 @@ func List(params *stripe.SourceTransactionListParams) *Iter {
 func (c Client) List(params *stripe.SourceTransactionListParams) *Iter {
 	body := &form.Values{}
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	form.AppendTo(body, params)
 	lp = &params.ListParams
-	p = params.ToParams()
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.SourceTransaction, stripe.ListMeta, error) {
 		list := &stripe.SourceTransactionList{}
 		var err error
 
@@ -40,12 +38,7 @@ func (c Client) List(params *stripe.SourceTransactionListParams) *Iter {
 			err = errors.New("Invalid source transaction params: Source needs to be set")
 		}
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -53,13 +46,13 @@ func (c Client) List(params *stripe.SourceTransactionListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.SourceTransaction]
 }
 
 // SourceTransaction returns the most recent SourceTransaction
 // visited by a call to Next.
 func (i *Iter) SourceTransaction() *stripe.SourceTransaction {
-	return i.Current().(*stripe.SourceTransaction)
+	return i.Current()
 }
 
 func getC() Client {
@@ -3,12 +3,16 @@ package stripe
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
+	"log/slog"
+	"math"
+	"math/rand"
 	"net/http"
 	"os"
 	"os/exec"
@@ -25,12 +29,20 @@ const (
 	uploadsURL = "https://uploads.stripe.com/v1"
 )
 
-// apiversion is the currently supported API version
-const apiversion = "2018-02-06"
+// APIVersion is the version of the Stripe API the library's structs model,
+// which requests ask for unless a backend or their Params set another one.
+const APIVersion = "2018-02-06"
 
 // clientversion is the binding version
 const clientversion = "30.6.0"
 
+// defaultMinRetryDelay and defaultMaxRetryDelay are the bounds on the delay
+// between retried requests used when a RetryPolicy doesn't specify its own.
+const (
+	defaultMinRetryDelay = 500 * time.Millisecond
+	defaultMaxRetryDelay = 5 * time.Second
+)
+
 // defaultHTTPTimeout is the default timeout on the http.Client used by the library.
 // This is chosen to be consistent with the other Stripe language libraries and
 // to coordinate with other timeouts configured in the Stripe infrastructure.
@@ -76,9 +88,179 @@ type Backend interface {
 
 // BackendConfiguration is the internal implementation for making HTTP calls to Stripe.
 type BackendConfiguration struct {
-	Type       SupportedBackend
-	URL        string
+	Type SupportedBackend
+	URL  string
+
+	// HTTPClient is the client requests are made with. The package level
+	// one set with SetHTTPClient is used when it's nil.
 	HTTPClient *http.Client
+
+	// APIVersion is the version of the Stripe API requested, sent in the
+	// Stripe-Version header, unless the request's Params set another one.
+	// It defaults to the package level APIVersion.
+	APIVersion string
+
+	// AppInfo identifies the plugin making requests through the backend.
+	// The package level one set with SetAppInfo is used when it's nil.
+	AppInfo *AppInfo
+
+	// StripeAccount is the connected account requests are made on behalf
+	// of, sent in the Stripe-Account header, unless their Params name
+	// another one.
+	StripeAccount string
+
+	// RetryPolicy controls whether and how requests that fail in a way that's
+	// likely to be transient are retried. Requests are not retried when it's
+	// nil.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter paces requests so they stay within Stripe's rate limits.
+	// Backends sharing a RateLimiter are paced together. Requests are not
+	// paced when it's nil.
+	RateLimiter *RateLimiter
+
+	// Telemetry collects metrics about the requests made by the backend,
+	// and optionally reports them to Stripe. No metrics are collected when
+	// it's nil.
+	Telemetry *Telemetry
+
+	// Middleware are hooks called around every request made by the
+	// backend, for example to add tracing, metrics or headers. See
+	// Middleware.
+	Middleware []Middleware
+
+	// Logger receives the backend's structured log records, which carry
+	// fields like the request's method, path, status and ID. Card numbers,
+	// card verification codes, bank account numbers and API keys are
+	// redacted from logged bodies. The package level Logger and LogLevel
+	// are used when it's nil.
+	Logger StructuredLogger
+
+	// ValidateParams enables validating parameters in the resource clients
+	// using the backend, like the package level ValidateParams does for
+	// all of them.
+	ValidateParams bool
+}
+
+// Middleware is a set of hooks called around the requests a
+// BackendConfiguration makes to Stripe. Any of them may be nil.
+//
+// BeforeRequest hooks are called in order before each attempt at a request,
+// including retries. AfterResponse and OnError hooks are called in reverse
+// order, so that the first Middleware wraps all the others.
+type Middleware struct {
+	// BeforeRequest is called before a request is sent. It may change the
+	// request's headers, or replace RequestInfo.Request altogether, for
+	// example with a copy carrying a different context. Returning an error
+	// aborts the request with that error.
+	BeforeRequest func(req *RequestInfo) error
+
+	// AfterResponse is called after a response is received, whatever its
+	// status code.
+	AfterResponse func(req *RequestInfo, res *ResponseInfo)
+
+	// OnError is called when a request ends in an error, which is either an
+	// *Error returned by Stripe, a connection error, or an error returned
+	// by a BeforeRequest hook. res is nil if no response was received.
+	OnError func(req *RequestInfo, res *ResponseInfo, err error)
+}
+
+// RequestInfo describes a request being made to Stripe.
+type RequestInfo struct {
+	// Method is the request's HTTP method.
+	Method string
+
+	// Path is the request's URL path, like "/v1/charges".
+	Path string
+
+	// Params are the parameters the request was made with. They're nil for
+	// requests passed to BackendConfiguration.Do directly.
+	Params *Params
+
+	// Request is the HTTP request that's sent.
+	Request *http.Request
+
+	// Attempt is the number of times the request was already attempted,
+	// which is 0 unless it's being retried.
+	Attempt int
+}
+
+// ResponseInfo describes a response received from Stripe.
+type ResponseInfo struct {
+	// StatusCode is the response's HTTP status code.
+	StatusCode int
+
+	// RequestID is the ID Stripe assigned to the request, from the
+	// Request-Id header.
+	RequestID string
+
+	// APIVersion is the version of the Stripe API that Stripe applied to
+	// the request, from the Stripe-Version header.
+	APIVersion string
+
+	// Header are the response's headers.
+	Header http.Header
+
+	// Latency is how long it took to send the request and read the
+	// response.
+	Latency time.Duration
+}
+
+// RetryPolicy configures automatic retries of failed requests. Requests are
+// retried after connection errors, 409 conflicts, 429 rate limiting and 5xx
+// server errors, with an exponentially increasing delay between attempts.
+//
+// POST requests are sent with an Idempotency-Key (one is generated if
+// Params.IdempotencyKey isn't set) so that retrying them is always safe.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried after
+	// its first attempt. Zero disables retries.
+	MaxRetries int
+
+	// MinDelay is the delay before the first retry. It doubles for every
+	// subsequent retry. Defaults to 500 milliseconds.
+	MinDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts. Defaults to 5 seconds.
+	MaxDelay time.Duration
+
+	// Jitter is the fraction of each delay, between 0 and 1, that's
+	// randomized so that many clients failing at once don't retry in
+	// lockstep. A Jitter of 0.5 produces delays between half and all of the
+	// computed backoff.
+	Jitter float64
+}
+
+// enabled returns whether the policy allows any retries at all.
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxRetries > 0
+}
+
+// delay returns how long to wait before the given retry, where the first
+// retry is 1.
+func (p *RetryPolicy) delay(retry int) time.Duration {
+	minDelay := p.MinDelay
+	if minDelay <= 0 {
+		minDelay = defaultMinRetryDelay
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+
+	d := minDelay
+	for i := 1; i < retry && d < maxDelay; i++ {
+		d *= 2
+	}
+	if d > maxDelay {
+		d = maxDelay
+	}
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		d -= time.Duration(jitter * rand.Float64() * float64(d))
+	}
+
+	return d
 }
 
 // SupportedBackend is an enumeration of supported Stripe endpoints.
@@ -120,7 +302,8 @@ type stripeClientUserAgent struct {
 // Key is the Stripe API key used globally in the binding.
 var Key string
 
-// LogLevel is the logging level for this library.
+// LogLevel is the logging level for this library, used by backends that
+// don't have their own Logger.
 // 0: no logging
 // 1: errors only
 // 2: errors + informational (default)
@@ -129,7 +312,7 @@ var LogLevel = 2
 
 // Logger controls how stripe performs logging at a package level. It is useful
 // to customise if you need it prefixed for your application to meet other
-// requirements
+// requirements. Backends with their own Logger don't use it.
 var Logger Printfer
 
 // Printfer is an interface to be implemented by Logger.
@@ -139,6 +322,7 @@ type Printfer interface {
 
 func init() {
 	Logger = log.New(os.Stderr, "", log.LstdFlags)
+	uname = getUname()
 	initUserAgent()
 }
 
@@ -148,6 +332,10 @@ var backends Backends
 var encodedStripeUserAgent string
 var encodedUserAgent string
 
+// uname describes the system in the X-Stripe-Client-User-Agent header. It's
+// only computed once since it runs a command.
+var uname string
+
 // SetHTTPClient overrides the default HTTP client.
 // This is useful if you're running in a Google AppEngine environment
 // where the http.DefaultClient is not available.
@@ -160,9 +348,9 @@ func SetHTTPClient(client *http.Client) {
 func NewBackends(httpClient *http.Client) *Backends {
 	return &Backends{
 		API: &BackendConfiguration{
-			APIBackend, APIURL, httpClient},
+			Type: APIBackend, URL: APIURL, HTTPClient: httpClient},
 		Uploads: &BackendConfiguration{
-			UploadsBackend, UploadsURL, httpClient},
+			Type: UploadsBackend, URL: UploadsURL, HTTPClient: httpClient},
 	}
 }
 
@@ -178,7 +366,7 @@ func GetBackend(backend SupportedBackend) Backend {
 		}
 		backends.mu.Lock()
 		defer backends.mu.Unlock()
-		backends.API = &BackendConfiguration{backend, apiURL, httpClient}
+		backends.API = &BackendConfiguration{Type: backend, URL: apiURL, HTTPClient: httpClient}
 		return backends.API
 
 	case UploadsBackend:
@@ -190,7 +378,7 @@ func GetBackend(backend SupportedBackend) Backend {
 		}
 		backends.mu.Lock()
 		defer backends.mu.Unlock()
-		backends.Uploads = &BackendConfiguration{backend, uploadsURL, httpClient}
+		backends.Uploads = &BackendConfiguration{Type: backend, URL: uploadsURL, HTTPClient: httpClient}
 		return backends.Uploads
 	}
 
@@ -199,6 +387,9 @@ func GetBackend(backend SupportedBackend) Backend {
 
 // SetBackend sets the backend used in the binding.
 func SetBackend(backend SupportedBackend, b Backend) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+
 	switch backend {
 	case APIBackend:
 		backends.API = b
@@ -224,13 +415,24 @@ func (s *BackendConfiguration) Call(method, path, key string, form *form.Values,
 		return err
 	}
 
-	if err := s.Do(req, v); err != nil {
+	if body != nil && s.logEnabled(req.Context(), slog.LevelDebug) {
+		s.log(req.Context(), slog.LevelDebug, "Stripe request",
+			"method", req.Method, "path", req.URL.Path, "body", redact(form.Encode()))
+	}
+
+	if err := s.do(req, params, v); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// ParamsValidationEnabled implements ParamsValidator, returning the
+// backend's ValidateParams.
+func (s *BackendConfiguration) ParamsValidationEnabled() bool {
+	return s.ValidateParams
+}
+
 // CallMultipart is the Backend.CallMultipart implementation for invoking Stripe APIs.
 func (s *BackendConfiguration) CallMultipart(method, path, key, boundary string, body io.Reader, params *Params, v interface{}) error {
 	contentType := "multipart/form-data; boundary=" + boundary
@@ -240,7 +442,15 @@ func (s *BackendConfiguration) CallMultipart(method, path, key, boundary string,
 		return err
 	}
 
-	if err := s.Do(req, v); err != nil {
+	// Streamed bodies, like a FileUploadBody, may know their length even
+	// though the HTTP client can't tell it.
+	if sized, ok := body.(interface {
+		ContentLength() int64
+	}); ok && sized.ContentLength() >= 0 {
+		req.ContentLength = sized.ContentLength()
+	}
+
+	if err := s.do(req, params, v); err != nil {
 		return err
 	}
 
@@ -258,19 +468,35 @@ func (s *BackendConfiguration) NewRequest(method, path, key, contentType string,
 
 	req, err := http.NewRequest(method, path, body)
 	if err != nil {
-		if LogLevel > 0 {
-			Logger.Printf("Cannot create Stripe request: %v\n", err)
-		}
+		s.log(context.Background(), slog.LevelError, "Cannot create Stripe request",
+			"method", method, "path", path, "error", err)
 		return nil, err
 	}
 
 	authorization := "Bearer " + key
 
+	version := s.APIVersion
+	if version == "" {
+		version = APIVersion
+	}
+
+	userAgent, stripeUserAgent := encodedUserAgent, encodedStripeUserAgent
+	if s.AppInfo != nil {
+		userAgent, stripeUserAgent = encodeUserAgent(s.AppInfo)
+	}
+
 	req.Header.Add("Authorization", authorization)
-	req.Header.Add("Stripe-Version", apiversion)
-	req.Header.Add("User-Agent", encodedUserAgent)
+	req.Header.Add("Stripe-Version", version)
+	req.Header.Add("User-Agent", userAgent)
 	req.Header.Add("Content-Type", contentType)
-	req.Header.Add("X-Stripe-Client-User-Agent", encodedStripeUserAgent)
+	req.Header.Add("X-Stripe-Client-User-Agent", stripeUserAgent)
+
+	// Retried POSTs must carry an idempotency key so that Stripe doesn't
+	// perform the same operation twice. One provided through params below
+	// takes precedence over this generated one.
+	if s.RetryPolicy.enabled() && strings.ToUpper(method) == "POST" {
+		req.Header.Set("Idempotency-Key", NewIdempotencyKey())
+	}
 
 	if params != nil {
 		if params.Context != nil {
@@ -282,7 +508,7 @@ func (s *BackendConfiguration) NewRequest(method, path, key, contentType string,
 				return nil, errors.New("Cannot use an IdempotencyKey longer than 255 characters long.")
 			}
 
-			req.Header.Add("Idempotency-Key", idempotency)
+			req.Header.Set("Idempotency-Key", idempotency)
 		}
 
 		// Support the value of the old Account field for now.
@@ -295,6 +521,10 @@ func (s *BackendConfiguration) NewRequest(method, path, key, contentType string,
 			req.Header.Add("Stripe-Account", stripeAccount)
 		}
 
+		if version := strings.TrimSpace(params.APIVersion); version != "" {
+			req.Header.Set("Stripe-Version", version)
+		}
+
 		for k, v := range params.Headers {
 			for _, line := range v {
 				req.Header.Add(k, line)
@@ -302,47 +532,174 @@ func (s *BackendConfiguration) NewRequest(method, path, key, contentType string,
 		}
 	}
 
+	if s.StripeAccount != "" && req.Header.Get("Stripe-Account") == "" {
+		req.Header.Set("Stripe-Account", s.StripeAccount)
+	}
+
 	return req, nil
 }
 
 // Do is used by Call to execute an API request and parse the response. It uses
 // the backend's HTTP client to execute the request and unmarshals the response
 // into v. It also handles unmarshaling errors returned by the API.
+//
+// If the backend has a RetryPolicy, failed attempts that are likely to
+// succeed when tried again are retried until the policy is exhausted or the
+// request's context is done.
 func (s *BackendConfiguration) Do(req *http.Request, v interface{}) error {
-	if LogLevel > 1 {
-		Logger.Printf("Requesting %v %v%v\n", req.Method, req.URL.Host, req.URL.Path)
-	}
+	return s.do(req, nil, v)
+}
 
-	start := time.Now()
+// do implements Do, passing params to the backend's Middleware.
+func (s *BackendConfiguration) do(req *http.Request, params *Params, v interface{}) error {
+	var reqInfo *RequestInfo
+	var resInfo *ResponseInfo
+	var res *http.Response
+	var resBody []byte
+	var err error
+
+	for retry := 0; ; retry++ {
+		if retry > 0 && req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return err
+			}
+		}
 
-	res, err := s.HTTPClient.Do(req)
+		reqInfo = &RequestInfo{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Params:  params,
+			Request: req,
+			Attempt: retry,
+		}
+		resInfo = nil
 
-	if LogLevel > 2 {
-		Logger.Printf("Completed in %v\n", time.Since(start))
-	}
+		s.Telemetry.attach(req)
 
-	if err != nil {
-		if LogLevel > 0 {
-			Logger.Printf("Request to Stripe failed: %v\n", err)
+		if err := s.beforeRequest(reqInfo); err != nil {
+			s.onError(reqInfo, nil, err)
+			return err
+		}
+
+		if err := s.RateLimiter.wait(reqInfo.Request); err != nil {
+			s.onError(reqInfo, nil, err)
+			return err
+		}
+
+		ctx := reqInfo.Request.Context()
+		s.log(ctx, slog.LevelInfo, "Requesting Stripe",
+			"method", req.Method, "host", req.URL.Host, "path", req.URL.Path,
+			"idempotency_key", reqInfo.Request.Header.Get("Idempotency-Key"),
+			"attempt", retry)
+
+		start := time.Now()
+
+		res, err = s.httpClient().Do(reqInfo.Request)
+
+		if err != nil {
+			duration := time.Since(start)
+			s.Telemetry.record(&RequestMetrics{
+				Method:   req.Method,
+				Endpoint: EndpointTemplate(req.URL.Path),
+				Duration: duration,
+				Attempt:  retry,
+				Err:      err,
+			})
+			s.log(ctx, slog.LevelError, "Request to Stripe failed",
+				"method", req.Method, "path", req.URL.Path,
+				"duration", duration, "attempt", retry, "error", err)
+
+			// Errors caused by the caller giving up are returned as they
+			// are.
+			if ctx.Err() == nil {
+				err = newConnectionError(err, false)
+			}
+		} else {
+			resBody, err = ioutil.ReadAll(res.Body)
+			res.Body.Close()
+
+			resInfo = &ResponseInfo{
+				StatusCode: res.StatusCode,
+				RequestID:  res.Header.Get("Request-Id"),
+				APIVersion: res.Header.Get("Stripe-Version"),
+				Header:     res.Header,
+				Latency:    time.Since(start),
+			}
+			s.RateLimiter.observe(reqInfo.Request, res.StatusCode)
+			s.Telemetry.record(&RequestMetrics{
+				Method:     req.Method,
+				Endpoint:   EndpointTemplate(req.URL.Path),
+				StatusCode: resInfo.StatusCode,
+				RequestID:  resInfo.RequestID,
+				Duration:   resInfo.Latency,
+				Attempt:    retry,
+				Err:        err,
+			})
+			s.afterResponse(reqInfo, resInfo)
+
+			if err != nil {
+				s.log(ctx, slog.LevelError, "Cannot read Stripe response",
+					"method", req.Method, "path", req.URL.Path,
+					"status", res.StatusCode, "request_id", resInfo.RequestID,
+					"attempt", retry, "error", err)
+				if ctx.Err() == nil {
+					connErr := newConnectionError(err, true)
+					connErr.HTTPStatusCode = res.StatusCode
+					connErr.RequestID = resInfo.RequestID
+					err = connErr
+				}
+				s.onError(reqInfo, resInfo, err)
+				return err
+			}
+		}
+
+		if resInfo != nil {
+			s.log(ctx, slog.LevelDebug, "Request to Stripe completed",
+				"method", req.Method, "path", req.URL.Path,
+				"status", resInfo.StatusCode, "request_id", resInfo.RequestID,
+				"api_version", resInfo.APIVersion,
+				"duration", resInfo.Latency, "attempt", retry)
+
+			requested := reqInfo.Request.Header.Get("Stripe-Version")
+			if resInfo.APIVersion != "" && resInfo.APIVersion != requested {
+				s.log(ctx, slog.LevelWarn, "Stripe applied a different API version than requested",
+					"method", req.Method, "path", req.URL.Path,
+					"request_id", resInfo.RequestID, "requested_api_version", requested,
+					"api_version", resInfo.APIVersion)
+			}
+		}
+
+		if !s.shouldRetry(reqInfo.Request, res, err, retry) {
+			break
+		}
+
+		delay := s.RetryPolicy.delay(retry + 1)
+		s.log(ctx, slog.LevelInfo, "Retrying request to Stripe",
+			"method", req.Method, "path", req.URL.Path,
+			"delay", delay, "attempt", retry+1, "max_retries", s.RetryPolicy.MaxRetries)
+
+		if err := sleepContext(req.Context(), delay); err != nil {
+			s.onError(reqInfo, resInfo, err)
+			return err
 		}
-		return err
 	}
-	defer res.Body.Close()
 
-	resBody, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		if LogLevel > 0 {
-			Logger.Printf("Cannot parse Stripe response: %v\n", err)
-		}
+		s.onError(reqInfo, resInfo, err)
 		return err
 	}
 
 	if res.StatusCode >= 400 {
-		return s.ResponseToError(res, resBody)
+		err = s.ResponseToError(res, resBody)
+		s.onError(reqInfo, resInfo, err)
+		return err
 	}
 
-	if LogLevel > 2 {
-		Logger.Printf("Stripe Response: %q\n", resBody)
+	if s.logEnabled(req.Context(), slog.LevelDebug) {
+		s.log(req.Context(), slog.LevelDebug, "Stripe response",
+			"method", req.Method, "path", req.URL.Path,
+			"request_id", resInfo.RequestID, "body", redact(string(resBody)))
 	}
 
 	if v != nil {
@@ -352,48 +709,125 @@ func (s *BackendConfiguration) Do(req *http.Request, v interface{}) error {
 	return nil
 }
 
-func (s *BackendConfiguration) ResponseToError(res *http.Response, resBody []byte) error {
-	// for some odd reason, the Erro structure doesn't unmarshal
-	// initially I thought it was because it's a struct inside of a struct
-	// but even after trying that, it still didn't work
-	// so unmarshalling to a map for now and parsing the results manually
-	// but should investigate later
-	var errMap map[string]interface{}
-
-	err := json.Unmarshal(resBody, &errMap)
-	if err != nil {
-		return err
+// httpClient returns the client the backend makes requests with.
+func (s *BackendConfiguration) httpClient() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
 	}
+	return httpClient
+}
 
-	e, ok := errMap["error"]
-	if !ok {
-		err := errors.New(string(resBody))
-		if LogLevel > 0 {
-			Logger.Printf("Unparsable error returned from Stripe: %v\n", err)
+// beforeRequest calls the BeforeRequest hooks of the backend's Middleware,
+// stopping at the first one returning an error.
+func (s *BackendConfiguration) beforeRequest(req *RequestInfo) error {
+	for _, m := range s.Middleware {
+		if m.BeforeRequest == nil {
+			continue
 		}
-		return err
+		if err := m.BeforeRequest(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// afterResponse calls the AfterResponse hooks of the backend's Middleware.
+func (s *BackendConfiguration) afterResponse(req *RequestInfo, res *ResponseInfo) {
+	for i := len(s.Middleware) - 1; i >= 0; i-- {
+		if hook := s.Middleware[i].AfterResponse; hook != nil {
+			hook(req, res)
+		}
+	}
+}
+
+// onError calls the OnError hooks of the backend's Middleware.
+func (s *BackendConfiguration) onError(req *RequestInfo, res *ResponseInfo, err error) {
+	for i := len(s.Middleware) - 1; i >= 0; i-- {
+		if hook := s.Middleware[i].OnError; hook != nil {
+			hook(req, res, err)
+		}
+	}
+}
+
+// shouldRetry returns whether a request that has already been retried the
+// given number of times should be attempted again after it produced res and
+// err.
+func (s *BackendConfiguration) shouldRetry(req *http.Request, res *http.Response, err error, retry int) bool {
+	if !s.RetryPolicy.enabled() || retry >= s.RetryPolicy.MaxRetries {
+		return false
+	}
+
+	// A request whose body can't be replayed can't be sent a second time.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	// Errors caused by the caller giving up are never transient.
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	// Any other error from the HTTP client is a connection level problem.
+	if err != nil {
+		return true
+	}
+
+	switch {
+	case res.StatusCode == http.StatusConflict:
+		return true
+	case res.StatusCode == http.StatusTooManyRequests:
+		return true
+	case res.StatusCode >= http.StatusInternalServerError:
+		return true
 	}
 
-	root := e.(map[string]interface{})
+	return false
+}
+
+// sleepContext waits for d to elapse, returning early with the context's
+// error if it's done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
 
-	stripeErr := &Error{
-		Type:           ErrorType(root["type"].(string)),
-		Msg:            root["message"].(string),
-		HTTPStatusCode: res.StatusCode,
-		RequestID:      res.Header.Get("Request-Id"),
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
+}
 
-	if code, ok := root["code"]; ok {
-		stripeErr.Code = ErrorCode(code.(string))
+// ResponseToError decodes the error Stripe responded with into an *Error,
+// whose Err holds the type specific error. Fields Stripe may add or omit are
+// tolerated, as are fields of an unexpected type, which are left empty. An
+// error object that can't be decoded at all is returned as an api_error
+// holding the body, and a body that isn't a Stripe error is returned as a
+// plain error.
+func (s *BackendConfiguration) ResponseToError(res *http.Response, resBody []byte) error {
+	var body struct {
+		Error json.RawMessage `json:"error"`
 	}
 
-	if param, ok := root["param"]; ok {
-		stripeErr.Param = param.(string)
+	if err := json.Unmarshal(resBody, &body); err != nil || len(body.Error) == 0 || string(body.Error) == "null" {
+		if err == nil {
+			err = errors.New(string(resBody))
+		}
+		s.log(responseContext(res), slog.LevelError, "Unparsable error returned from Stripe",
+			"status", res.StatusCode, "request_id", res.Header.Get("Request-Id"),
+			"body", redact(string(resBody)))
+		return err
 	}
 
-	if charge, ok := root["charge"]; ok {
-		stripeErr.ChargeID = charge.(string)
+	stripeErr, err := decodeError(body.Error)
+	if err != nil {
+		s.log(responseContext(res), slog.LevelError, "Unparsable error object returned from Stripe",
+			"status", res.StatusCode, "request_id", res.Header.Get("Request-Id"),
+			"body", redact(string(resBody)))
+		stripeErr = &Error{Type: ErrorTypeAPI, Msg: string(resBody)}
 	}
+	stripeErr.HTTPStatusCode = res.StatusCode
+	stripeErr.RequestID = res.Header.Get("Request-Id")
 
 	switch stripeErr.Type {
 	case ErrorTypeAPI:
@@ -406,12 +840,7 @@ func (s *BackendConfiguration) ResponseToError(res *http.Response, resBody []byt
 		stripeErr.Err = &AuthenticationError{stripeErr: stripeErr}
 
 	case ErrorTypeCard:
-		cardErr := &CardError{stripeErr: stripeErr}
-		stripeErr.Err = cardErr
-
-		if declineCode, ok := root["decline_code"]; ok {
-			cardErr.DeclineCode = declineCode.(string)
-		}
+		stripeErr.Err = &CardError{stripeErr: stripeErr, DeclineCode: stripeErr.DeclineCode}
 
 	case ErrorTypeInvalidRequest:
 		stripeErr.Err = &InvalidRequestError{stripeErr: stripeErr}
@@ -423,13 +852,21 @@ func (s *BackendConfiguration) ResponseToError(res *http.Response, resBody []byt
 		stripeErr.Err = &RateLimitError{stripeErr: stripeErr}
 	}
 
-	if LogLevel > 0 {
-		Logger.Printf("Error encountered from Stripe: %v\n", stripeErr)
-	}
+	s.log(responseContext(res), slog.LevelError, "Error encountered from Stripe",
+		"status", stripeErr.HTTPStatusCode, "request_id", stripeErr.RequestID,
+		"type", stripeErr.Type, "code", stripeErr.Code, "error", stripeErr.Msg)
 
 	return stripeErr
 }
 
+// responseContext returns the context of the request res is a response to.
+func responseContext(res *http.Response) context.Context {
+	if res.Request != nil {
+		return res.Request.Context()
+	}
+	return context.Background()
+}
+
 // SetAppInfo sets app information. See AppInfo.
 func SetAppInfo(info *AppInfo) {
 	if info != nil && info.Name == "" {
@@ -464,18 +901,24 @@ func getUname() string {
 }
 
 func initUserAgent() {
-	encodedUserAgent = "Stripe/v1 GoBindings/" + clientversion
-	if appInfo != nil {
-		encodedUserAgent += " " + appInfo.formatUserAgent()
+	encodedUserAgent, encodedStripeUserAgent = encodeUserAgent(appInfo)
+}
+
+// encodeUserAgent returns the User-Agent and X-Stripe-Client-User-Agent
+// headers identifying the library and the app using it, if any.
+func encodeUserAgent(info *AppInfo) (string, string) {
+	userAgent := "Stripe/v1 GoBindings/" + clientversion
+	if info != nil {
+		userAgent += " " + info.formatUserAgent()
 	}
 
 	stripeUserAgent := &stripeClientUserAgent{
-		Application:     appInfo,
+		Application:     info,
 		BindingsVersion: clientversion,
 		Language:        "go",
 		LanguageVersion: runtime.Version(),
 		Publisher:       "stripe",
-		Uname:           getUname(),
+		Uname:           uname,
 	}
 	marshaled, err := json.Marshal(stripeUserAgent)
 	// Encoding this struct should never be a problem, so we're okay to panic
@@ -483,5 +926,5 @@ func initUserAgent() {
 	if err != nil {
 		panic(err)
 	}
-	encodedStripeUserAgent = string(marshaled)
+	return userAgent, string(marshaled)
 }
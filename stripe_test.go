@@ -3,14 +3,19 @@ package stripe_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"runtime"
 	"sync"
 	"testing"
+	"time"
 
 	assert "github.com/stretchr/testify/require"
 	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/form"
 	. "github.com/stripe/stripe-go/testing"
 )
 
@@ -87,6 +92,241 @@ func TestMultipleAPICalls(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDo_Retry(t *testing.T) {
+	var requests int
+	var idempotencyKeys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		idempotencyKeys = append(idempotencyKeys, r.Header.Get("Idempotency-Key"))
+
+		err := r.ParseForm()
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", r.PostForm.Get("foo"))
+
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"conflict"}}`))
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":{"type":"api_error","message":"unavailable"}}`))
+		default:
+			w.Write([]byte(`{"id":"ch_123"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c := &stripe.BackendConfiguration{
+		Type:        stripe.APIBackend,
+		URL:         ts.URL,
+		HTTPClient:  &http.Client{},
+		RetryPolicy: &stripe.RetryPolicy{MaxRetries: 2, MinDelay: time.Millisecond},
+	}
+
+	body := &form.Values{}
+	body.Add("foo", "bar")
+
+	var v struct {
+		ID string `json:"id"`
+	}
+	err := c.Call("POST", "/charges", "sk_test_123", body, nil, &v)
+	assert.NoError(t, err)
+	assert.Equal(t, "ch_123", v.ID)
+	assert.Equal(t, 3, requests)
+
+	// The same generated key must be sent on every attempt.
+	assert.NotEmpty(t, idempotencyKeys[0])
+	assert.Equal(t, idempotencyKeys[0], idempotencyKeys[1])
+	assert.Equal(t, idempotencyKeys[0], idempotencyKeys[2])
+}
+
+func TestDo_RetryExhausted(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"type":"rate_limit_error","message":"slow down"}}`))
+	}))
+	defer ts.Close()
+
+	c := &stripe.BackendConfiguration{
+		Type:        stripe.APIBackend,
+		URL:         ts.URL,
+		HTTPClient:  &http.Client{},
+		RetryPolicy: &stripe.RetryPolicy{MaxRetries: 2, MinDelay: time.Millisecond},
+	}
+
+	err := c.Call("GET", "/charges", "sk_test_123", nil, nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 3, requests)
+
+	stripeErr := err.(*stripe.Error)
+	_, ok := stripeErr.Err.(*stripe.RateLimitError)
+	assert.True(t, ok)
+}
+
+func TestDo_NoRetryOnClientError(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"bad"}}`))
+	}))
+	defer ts.Close()
+
+	c := &stripe.BackendConfiguration{
+		Type:        stripe.APIBackend,
+		URL:         ts.URL,
+		HTTPClient:  &http.Client{},
+		RetryPolicy: &stripe.RetryPolicy{MaxRetries: 2, MinDelay: time.Millisecond},
+	}
+
+	err := c.Call("POST", "/charges", "sk_test_123", nil, &stripe.Params{IdempotencyKey: "my-key"}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestDo_RetryContextCanceled(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":{"type":"api_error","message":"boom"}}`))
+	}))
+	defer ts.Close()
+
+	c := &stripe.BackendConfiguration{
+		Type:        stripe.APIBackend,
+		URL:         ts.URL,
+		HTTPClient:  &http.Client{},
+		RetryPolicy: &stripe.RetryPolicy{MaxRetries: 5, MinDelay: time.Hour},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := c.Call("GET", "/charges", "sk_test_123", nil, &stripe.Params{Context: ctx}, nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestMiddleware(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "span_123", r.Header.Get("X-Trace-Id"))
+
+		w.Header().Set("Request-Id", fmt.Sprintf("req_%v", requests))
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":{"type":"api_error","message":"unavailable"}}`))
+			return
+		}
+		w.Write([]byte(`{"id":"ch_123"}`))
+	}))
+	defer ts.Close()
+
+	var calls []string
+	c := &stripe.BackendConfiguration{
+		Type:        stripe.APIBackend,
+		URL:         ts.URL,
+		HTTPClient:  &http.Client{},
+		RetryPolicy: &stripe.RetryPolicy{MaxRetries: 1, MinDelay: time.Millisecond},
+		Middleware: []stripe.Middleware{
+			{
+				BeforeRequest: func(req *stripe.RequestInfo) error {
+					calls = append(calls, fmt.Sprintf("trace before %v %v %v", req.Method, req.Path, req.Attempt))
+					req.Request.Header.Set("X-Trace-Id", "span_123")
+					return nil
+				},
+				AfterResponse: func(req *stripe.RequestInfo, res *stripe.ResponseInfo) {
+					calls = append(calls, fmt.Sprintf("trace after %v %v", res.StatusCode, res.RequestID))
+					assert.True(t, res.Latency > 0)
+				},
+			},
+			{
+				BeforeRequest: func(req *stripe.RequestInfo) error {
+					calls = append(calls, "metrics before "+req.Params.IdempotencyKey)
+					return nil
+				},
+				AfterResponse: func(req *stripe.RequestInfo, res *stripe.ResponseInfo) {
+					calls = append(calls, "metrics after")
+				},
+				OnError: func(req *stripe.RequestInfo, res *stripe.ResponseInfo, err error) {
+					calls = append(calls, "metrics error")
+				},
+			},
+		},
+	}
+
+	params := &stripe.Params{IdempotencyKey: "key_123"}
+	err := c.Call("POST", "/charges", "sk_test_123", nil, params, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"trace before POST /charges 0",
+		"metrics before key_123",
+		"metrics after",
+		"trace after 503 req_1",
+		"trace before POST /charges 1",
+		"metrics before key_123",
+		"metrics after",
+		"trace after 200 req_2",
+	}, calls)
+}
+
+func TestMiddleware_OnError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_123")
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"error":{"type":"card_error","message":"declined"}}`))
+	}))
+	defer ts.Close()
+
+	var gotRes *stripe.ResponseInfo
+	var gotErr error
+	c := &stripe.BackendConfiguration{
+		Type:       stripe.APIBackend,
+		URL:        ts.URL,
+		HTTPClient: &http.Client{},
+		Middleware: []stripe.Middleware{{
+			OnError: func(req *stripe.RequestInfo, res *stripe.ResponseInfo, err error) {
+				gotRes, gotErr = res, err
+			},
+		}},
+	}
+
+	err := c.Call("POST", "/charges", "sk_test_123", nil, nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, err, gotErr)
+	assert.Equal(t, http.StatusPaymentRequired, gotRes.StatusCode)
+	assert.Equal(t, "req_123", gotRes.RequestID)
+}
+
+func TestMiddleware_BeforeRequestError(t *testing.T) {
+	hookErr := errors.New("request blocked")
+
+	var gotRes *stripe.ResponseInfo
+	var gotErr error
+	c := &stripe.BackendConfiguration{
+		Type:       stripe.APIBackend,
+		URL:        "http://localhost:1",
+		HTTPClient: &http.Client{},
+		Middleware: []stripe.Middleware{{
+			BeforeRequest: func(req *stripe.RequestInfo) error {
+				return hookErr
+			},
+			OnError: func(req *stripe.RequestInfo, res *stripe.ResponseInfo, err error) {
+				gotRes, gotErr = res, err
+			},
+		}},
+	}
+
+	err := c.Call("GET", "/charges", "sk_test_123", nil, nil, nil)
+	assert.Equal(t, hookErr, err)
+	assert.Equal(t, hookErr, gotErr)
+	assert.Nil(t, gotRes)
+}
+
 func TestIdempotencyKey(t *testing.T) {
 	c := &stripe.BackendConfiguration{URL: stripe.APIURL}
 	p := &stripe.Params{IdempotencyKey: "idempotency-key"}
@@ -97,6 +337,27 @@ func TestIdempotencyKey(t *testing.T) {
 	assert.Equal(t, "idempotency-key", req.Header.Get("Idempotency-Key"))
 }
 
+func TestIdempotencyKey_GeneratedForRetries(t *testing.T) {
+	c := &stripe.BackendConfiguration{
+		URL:         stripe.APIURL,
+		RetryPolicy: &stripe.RetryPolicy{MaxRetries: 1},
+	}
+
+	req, err := c.NewRequest("POST", "", "", "", nil, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, req.Header.Get("Idempotency-Key"))
+
+	// A key passed through params wins over the generated one.
+	req, err = c.NewRequest("POST", "", "", "", nil, &stripe.Params{IdempotencyKey: "idempotency-key"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"idempotency-key"}, req.Header["Idempotency-Key"])
+
+	// GETs are idempotent by nature.
+	req, err = c.NewRequest("GET", "", "", "", nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, req.Header.Get("Idempotency-Key"))
+}
+
 func TestStripeAccount(t *testing.T) {
 	c := &stripe.BackendConfiguration{URL: stripe.APIURL}
 	p := &stripe.Params{StripeAccount: TestMerchantID}
@@ -205,6 +466,45 @@ func TestStripeClientUserAgentWithAppInfo(t *testing.T) {
 	assert.Equal(t, appInfo.Version, decodedAppInfo["version"])
 }
 
+func TestAPIVersion(t *testing.T) {
+	var requested []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.Header.Get("Stripe-Version"))
+		w.Header().Set("Stripe-Version", r.Header.Get("Stripe-Version"))
+		w.Write([]byte(`{"id":"ch_123"}`))
+	}))
+	defer ts.Close()
+
+	var applied []string
+	c := &stripe.BackendConfiguration{
+		Type:       stripe.APIBackend,
+		URL:        ts.URL,
+		HTTPClient: &http.Client{},
+		Middleware: []stripe.Middleware{{
+			AfterResponse: func(req *stripe.RequestInfo, res *stripe.ResponseInfo) {
+				applied = append(applied, res.APIVersion)
+			},
+		}},
+	}
+
+	err := c.Call("GET", "/charges/ch_123", "sk_test_123", nil, nil, nil)
+	assert.NoError(t, err)
+
+	c.APIVersion = "2017-08-15"
+	err = c.Call("GET", "/charges/ch_123", "sk_test_123", nil, nil, nil)
+	assert.NoError(t, err)
+
+	err = c.Call("GET", "/charges/ch_123", "sk_test_123", nil, &stripe.Params{APIVersion: "2017-12-14"}, nil)
+	assert.NoError(t, err)
+
+	expected := []string{stripe.APIVersion, "2017-08-15", "2017-12-14"}
+	assert.Equal(t, expected, requested)
+	assert.Equal(t, expected, applied)
+
+	listParams := &stripe.ListParams{APIVersion: "2017-12-14"}
+	assert.Equal(t, "2017-12-14", listParams.ToParams().APIVersion)
+}
+
 func TestResponseToError(t *testing.T) {
 	c := &stripe.BackendConfiguration{URL: stripe.APIURL}
 
@@ -262,7 +562,7 @@ func TestResponseToError(t *testing.T) {
 
 	cardErr, ok := stripeErr.Err.(*stripe.CardError)
 	assert.True(t, ok)
-	assert.Equal(t, expectedDeclineCode, cardErr.DeclineCode)
+	assert.Equal(t, stripe.DeclineCode(expectedDeclineCode), cardErr.DeclineCode)
 }
 
 //
@@ -2,6 +2,7 @@ package stripe
 
 import (
 	"encoding/json"
+	"net/url"
 
 	"github.com/stripe/stripe-go/form"
 )
@@ -19,28 +20,29 @@ type SubBilling string
 type SubParams struct {
 	Params                      `form:"*"`
 	Billing                     SubBilling        `form:"billing"`
-	BillingCycleAnchor          int64             `form:"billing_cycle_anchor"`
-	BillingCycleAnchorNow       bool              `form:"-"` // See custom AppendTo
+	BillingCycleAnchor          *int64            `form:"billing_cycle_anchor"`
+	BillingCycleAnchorNow       bool              `form:"-"` // Deprecated: use BillingCycleAnchor; see custom AppendTo
 	BillingCycleAnchorUnchanged bool              `form:"-"` // See custom AppendTo
 	Card                        *CardParams       `form:"card"`
-	Coupon                      string            `form:"coupon"`
-	CouponEmpty                 bool              `form:"coupon,empty"`
-	Customer                    string            `form:"customer"`
+	Coupon                      *string           `form:"coupon"`
+	CouponEmpty                 bool              `form:"coupon,empty"` // Deprecated: use Coupon
+	Customer                    string            `form:"customer" validate:"required"`
 	DaysUntilDue                uint64            `form:"days_until_due"`
-	FeePercent                  float64           `form:"application_fee_percent"`
-	FeePercentZero              bool              `form:"application_fee_percent,zero"`
+	FeePercent                  *float64          `form:"application_fee_percent"`
+	FeePercentZero              bool              `form:"application_fee_percent,zero"` // Deprecated: use FeePercent
 	Items                       []*SubItemsParams `form:"items,indexed"`
-	NoProrate                   bool              `form:"prorate,invert"`
+	Prorate                     *bool             `form:"prorate"`
+	NoProrate                   bool              `form:"prorate,invert"` // Deprecated: use Prorate
 	OnBehalfOf                  string            `form:"on_behalf_of"`
 	Plan                        string            `form:"plan"`
 	ProrationDate               int64             `form:"proration_date"`
-	Quantity                    uint64            `form:"quantity"`
-	QuantityZero                bool              `form:"quantity,zero"`
-	TaxPercent                  float64           `form:"tax_percent"`
-	TaxPercentZero              bool              `form:"tax_percent,zero"`
+	Quantity                    *uint64           `form:"quantity"`
+	QuantityZero                bool              `form:"quantity,zero"` // Deprecated: use Quantity
+	TaxPercent                  *float64          `form:"tax_percent"`
+	TaxPercentZero              bool              `form:"tax_percent,zero"` // Deprecated: use TaxPercent
 	Token                       string            `form:"card"`
-	TrialEnd                    int64             `form:"trial_end"`
-	TrialEndNow                 bool              `form:"-"` // See custom AppendTo
+	TrialEnd                    *int64            `form:"trial_end"`
+	TrialEndNow                 bool              `form:"-"` // Deprecated: use TrialEnd; see custom AppendTo
 	TrialFromPlan               bool              `form:"trial_from_plan"`
 	TrialPeriod                 int64             `form:"trial_period_days"`
 
@@ -66,16 +68,38 @@ func (p *SubParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// UnmarshalForm implements form.Unmarshaler so that the billing cycle anchor
+// being left unchanged, which is encoded by the custom AppendTo, is decoded.
+func (p *SubParams) UnmarshalForm(values url.Values, keyParts []string) error {
+	anchorKey := form.FormatKey(append(keyParts, "billing_cycle_anchor"))
+	if values.Get(anchorKey) == "unchanged" {
+		p.BillingCycleAnchorUnchanged = true
+		values = formValuesWithout(values, anchorKey)
+	}
+
+	type subParams SubParams
+	return form.UnmarshalPrefixed(values, (*subParams)(p), keyParts)
+}
+
+// Validate implements Validator, checking that only one of Card and Token is
+// set as they're both the customer's new card.
+func (p *SubParams) Validate() error {
+	if p.Card != nil && p.Token != "" {
+		return newValidationError("card", "Invalid card: Card and Token can't both be set.")
+	}
+	return nil
+}
+
 // SubItemsParams is the set of parameters that can be used when creating or updating a subscription item on a subscription
 // For more details see https://stripe.com/docs/api#create_subscription and https://stripe.com/docs/api#update_subscription.
 type SubItemsParams struct {
 	Params       `form:"*"`
-	ClearUsage   bool   `form:"clear_usage"`
-	Deleted      bool   `form:"deleted"`
-	ID           string `form:"id"`
-	Plan         string `form:"plan"`
-	Quantity     uint64 `form:"quantity"`
-	QuantityZero bool   `form:"quantity,zero"`
+	ClearUsage   bool    `form:"clear_usage"`
+	Deleted      bool    `form:"deleted"`
+	ID           string  `form:"id"`
+	Plan         string  `form:"plan"`
+	Quantity     *uint64 `form:"quantity"`
+	QuantityZero bool    `form:"quantity,zero"` // Deprecated: use Quantity
 }
 
 // SubListParams is the set of parameters that can be used when listing active subscriptions.
@@ -93,49 +117,49 @@ type SubListParams struct {
 // Sub is the resource representing a Stripe subscription.
 // For more details see https://stripe.com/docs/api#subscriptions.
 type Sub struct {
-	Billing            SubBilling        `json:"billing"`
-	BillingCycleAnchor int64             `json:"billing_cycle_anchor"`
-	Canceled           int64             `json:"canceled_at"`
-	Created            int64             `json:"created"`
-	Customer           *Customer         `json:"customer"`
-	DaysUntilDue       uint64            `json:"days_until_due"`
-	Discount           *Discount         `json:"discount"`
-	EndCancel          bool              `json:"cancel_at_period_end"`
-	Ended              int64             `json:"ended_at"`
-	FeePercent         float64           `json:"application_fee_percent"`
-	ID                 string            `json:"id"`
-	Items              *SubItemList      `json:"items"`
-	Meta               map[string]string `json:"metadata"`
-	PeriodEnd          int64             `json:"current_period_end"`
-	PeriodStart        int64             `json:"current_period_start"`
-	Plan               *Plan             `json:"plan"`
-	Quantity           uint64            `json:"quantity"`
-	Start              int64             `json:"start"`
-	Status             SubStatus         `json:"status"`
-	TaxPercent         float64           `json:"tax_percent"`
-	TrialEnd           int64             `json:"trial_end"`
-	TrialStart         int64             `json:"trial_start"`
+	Billing            SubBilling            `json:"billing"`
+	BillingCycleAnchor int64                 `json:"billing_cycle_anchor"`
+	Canceled           int64                 `json:"canceled_at"`
+	Created            int64                 `json:"created"`
+	Customer           *Expandable[Customer] `json:"customer"`
+	DaysUntilDue       uint64                `json:"days_until_due"`
+	Discount           *Discount             `json:"discount"`
+	EndCancel          bool                  `json:"cancel_at_period_end"`
+	Ended              int64                 `json:"ended_at"`
+	FeePercent         float64               `json:"application_fee_percent"`
+	ID                 string                `json:"id"`
+	Items              *SubItemList          `json:"items"`
+	Meta               map[string]string     `json:"metadata"`
+	PeriodEnd          int64                 `json:"current_period_end"`
+	PeriodStart        int64                 `json:"current_period_start"`
+	Plan               *Plan                 `json:"plan"`
+	Quantity           uint64                `json:"quantity"`
+	Start              int64                 `json:"start"`
+	Status             SubStatus             `json:"status"`
+	TaxPercent         float64               `json:"tax_percent"`
+	TrialEnd           int64                 `json:"trial_end"`
+	TrialStart         int64                 `json:"trial_start"`
 }
 
 // SubList is a list object for subscriptions.
-type SubList struct {
-	ListMeta
-	Values []*Sub `json:"data"`
-}
+type SubList = List[*Sub]
 
 // UnmarshalJSON handles deserialization of a Sub.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (s *Sub) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		s.ID = id
+		return nil
+	}
+
 	type sub Sub
 	var ss sub
 	err := json.Unmarshal(data, &ss)
-	if err == nil {
-		*s = Sub(ss)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		s.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*s = Sub(ss)
 	return nil
 }
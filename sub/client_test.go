@@ -34,9 +34,9 @@ func TestSubNew(t *testing.T) {
 	subscription, err := New(&stripe.SubParams{
 		Customer:           "cus_123",
 		Plan:               "plan_123",
-		Quantity:           10,
-		TaxPercent:         20.0,
-		BillingCycleAnchor: time.Now().AddDate(0, 0, 12).Unix(),
+		Quantity:           stripe.Uint64(10),
+		TaxPercent:         stripe.Float64(20.0),
+		BillingCycleAnchor: stripe.Int64(time.Now().AddDate(0, 0, 12).Unix()),
 		Billing:            "send_invoice",
 		DaysUntilDue:       30,
 	})
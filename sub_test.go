@@ -32,3 +32,36 @@ func TestSubParams_AppendTo(t *testing.T) {
 		assert.Equal(t, []string{"now"}, body.Get("trial_end"))
 	}
 }
+
+func TestSubParams_Optional(t *testing.T) {
+	params := &SubParams{
+		BillingCycleAnchor: Now(),
+		Coupon:             String(""),
+		FeePercent:         Float64(0),
+		Prorate:            Bool(false),
+		Quantity:           Uint64(0),
+		TaxPercent:         Clear[float64](),
+		TrialEnd:           Int64(1514764800),
+	}
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	assert.Equal(t, []string{"now"}, body.Get("billing_cycle_anchor"))
+	assert.Equal(t, []string{""}, body.Get("coupon"))
+	assert.Equal(t, []string{"0.0000"}, body.Get("application_fee_percent"))
+	assert.Equal(t, []string{"false"}, body.Get("prorate"))
+	assert.Equal(t, []string{"0"}, body.Get("quantity"))
+	assert.Equal(t, []string{""}, body.Get("tax_percent"))
+	assert.Equal(t, []string{"1514764800"}, body.Get("trial_end"))
+
+	// Unset optional parameters are omitted.
+	body = &form.Values{}
+	form.AppendTo(body, &SubParams{})
+	assert.True(t, body.Empty())
+
+	// Pointers aren't mistaken for the special values whatever they
+	// point to.
+	body = &form.Values{}
+	form.AppendTo(body, &SubParams{TaxPercent: Float64(Float64Value(Clear[float64]()))})
+	assert.Equal(t, []string{"0.0000"}, body.Get("tax_percent"))
+}
@@ -4,13 +4,14 @@ package stripe
 // For more details see https://stripe.com/docs/api#create_subscription_item and https://stripe.com/docs/api#update_subscription_item.
 type SubItemParams struct {
 	Params        `form:"*"`
-	ID            string `form:"-"` // Handled in URL
-	NoProrate     bool   `form:"prorate,invert"`
-	Plan          string `form:"plan"`
-	ProrationDate int64  `form:"proration_date"`
-	Quantity      uint64 `form:"quantity"`
-	QuantityZero  bool   `form:"quantity,zero"`
-	Sub           string `form:"subscription"`
+	ID            string  `form:"-"` // Handled in URL
+	Prorate       *bool   `form:"prorate"`
+	NoProrate     bool    `form:"prorate,invert"` // Deprecated: use Prorate
+	Plan          string  `form:"plan"`
+	ProrationDate int64   `form:"proration_date"`
+	Quantity      *uint64 `form:"quantity"`
+	QuantityZero  bool    `form:"quantity,zero"` // Deprecated: use Quantity
+	Sub           string  `form:"subscription"`
 }
 
 // SubItemListParams is the set of parameters that can be used when listing invoice items.
@@ -32,7 +33,4 @@ type SubItem struct {
 }
 
 // SubItemList is a list of invoice items as retrieved from a list endpoint.
-type SubItemList struct {
-	ListMeta
-	Values []*SubItem `json:"data"`
-}
+type SubItemList = List[*SubItem]
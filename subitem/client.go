@@ -21,6 +21,10 @@ func New(params *stripe.SubItemParams) (*stripe.SubItem, error) {
 }
 
 func (c Client) New(params *stripe.SubItemParams) (*stripe.SubItem, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 	token := c.Key
@@ -43,6 +47,10 @@ func Get(id string, params *stripe.SubItemParams) (*stripe.SubItem, error) {
 }
 
 func (c Client) Get(id string, params *stripe.SubItemParams) (*stripe.SubItem, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -65,6 +73,10 @@ func Update(id string, params *stripe.SubItemParams) (*stripe.SubItem, error) {
 }
 
 func (c Client) Update(id string, params *stripe.SubItemParams) (*stripe.SubItem, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 	token := c.Key
@@ -88,6 +100,10 @@ func Del(id string, params *stripe.SubItemParams) (*stripe.SubItem, error) {
 }
 
 func (c Client) Del(id string, params *stripe.SubItemParams) (*stripe.SubItem, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -112,25 +128,18 @@ func List(params *stripe.SubItemListParams) *Iter {
 func (c Client) List(params *stripe.SubItemListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.SubItem, stripe.ListMeta, error) {
 		list := &stripe.SubItemList{}
 		err := c.B.Call("GET", "/subscription_items", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -138,13 +147,13 @@ func (c Client) List(params *stripe.SubItemListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.SubItem]
 }
 
 // Sub returns the most recent Sub
 // visited by a call to Next.
 func (i *Iter) SubItem() *stripe.SubItem {
-	return i.Current().(*stripe.SubItem)
+	return i.Current()
 }
 
 func getC() Client {
@@ -31,7 +31,7 @@ func TestSubItemList(t *testing.T) {
 
 func TestSubItemNew(t *testing.T) {
 	item, err := New(&stripe.SubItemParams{
-		Quantity: 99,
+		Quantity: stripe.Uint64(99),
 		Plan:     "plan_123",
 		Sub:      "sub_123",
 	})
@@ -41,7 +41,7 @@ func TestSubItemNew(t *testing.T) {
 
 func TestSubItemUpdate(t *testing.T) {
 	item, err := Update("si_123", &stripe.SubItemParams{
-		Quantity: 10,
+		Quantity: stripe.Uint64(10),
 	})
 	assert.Nil(t, err)
 	assert.NotNil(t, item)
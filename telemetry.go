@@ -0,0 +1,321 @@
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// telemetryHeader is the header previous requests' metrics are sent in.
+const telemetryHeader = "X-Stripe-Client-Telemetry"
+
+// maxPendingMetrics is the number of requests whose metrics are kept until
+// they're reported to Stripe. Metrics of further requests are dropped.
+const maxPendingMetrics = 16
+
+// RequestMetrics are measurements of an attempt to make a request to Stripe.
+type RequestMetrics struct {
+	// Method is the request's HTTP method.
+	Method string
+
+	// Endpoint is the request's path with IDs replaced by placeholders,
+	// like "/charges/{id}/refunds", so that metrics of requests for
+	// different objects can be aggregated.
+	Endpoint string
+
+	// StatusCode is the status Stripe responded with, or 0 if the request
+	// failed before a response was received.
+	StatusCode int
+
+	// RequestID is the ID Stripe assigned to the request, if it responded.
+	RequestID string
+
+	// Duration is how long it took to send the request and read the
+	// response.
+	Duration time.Duration
+
+	// Attempt is the number of times the request was already attempted,
+	// which is 0 unless it's being retried.
+	Attempt int
+
+	// Err is the error that prevented a response from being received, if
+	// any.
+	Err error
+}
+
+// Telemetry collects metrics about the requests made by the backends sharing
+// it.
+//
+// A Telemetry is safe for concurrent use. Its fields must not be changed once
+// it's in use.
+type Telemetry struct {
+	// OnRequest, if not nil, is called with the metrics of every attempt
+	// to make a request, for example with a MetricsCollector's Observe
+	// method.
+	OnRequest func(*RequestMetrics)
+
+	// Report enables sending the request ID and duration of previous
+	// requests to Stripe in the X-Stripe-Client-Telemetry header, which
+	// helps Stripe monitor the latency experienced by the library's users.
+	Report bool
+
+	mu      sync.Mutex
+	pending []*RequestMetrics
+}
+
+// telemetryPayload is the content of the X-Stripe-Client-Telemetry header.
+type telemetryPayload struct {
+	LastRequestMetrics struct {
+		RequestID         string `json:"request_id"`
+		RequestDurationMS int64  `json:"request_duration_ms"`
+	} `json:"last_request_metrics"`
+}
+
+// attach adds the metrics of a previous request to req, if Report is enabled
+// and there's one that wasn't reported yet.
+func (t *Telemetry) attach(req *http.Request) {
+	if t == nil || !t.Report {
+		return
+	}
+
+	// Metrics attached to a previous attempt of a retried request were
+	// already reported.
+	req.Header.Del(telemetryHeader)
+
+	t.mu.Lock()
+	if len(t.pending) == 0 {
+		t.mu.Unlock()
+		return
+	}
+	metrics := t.pending[0]
+	t.pending = t.pending[1:]
+	t.mu.Unlock()
+
+	var payload telemetryPayload
+	payload.LastRequestMetrics.RequestID = metrics.RequestID
+	payload.LastRequestMetrics.RequestDurationMS = metrics.Duration.Milliseconds()
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	req.Header.Set(telemetryHeader, string(data))
+}
+
+// record passes metrics to OnRequest, and keeps them to be reported to Stripe
+// if they're for a request Stripe responded to.
+func (t *Telemetry) record(metrics *RequestMetrics) {
+	if t == nil {
+		return
+	}
+
+	if t.Report && metrics.RequestID != "" {
+		t.mu.Lock()
+		if len(t.pending) < maxPendingMetrics {
+			t.pending = append(t.pending, metrics)
+		}
+		t.mu.Unlock()
+	}
+
+	if t.OnRequest != nil {
+		t.OnRequest(metrics)
+	}
+}
+
+// singularSegments are path segments naming a single object, which aren't
+// followed by an ID.
+var singularSegments = map[string]bool{
+	"account":  true,
+	"balance":  true,
+	"discount": true,
+	"upcoming": true,
+}
+
+// namespaceSegments are path segments which, with the segment following
+// them, name a collection, like "apple_pay" in "/apple_pay/domains".
+var namespaceSegments = map[string]bool{
+	"apple_pay": true,
+	"bitcoin":   true,
+}
+
+// EndpointTemplate returns path with the IDs it contains replaced by "{id}"
+// and without its API version prefix, so that "/v1/charges/ch_123/refunds"
+// becomes "/charges/{id}/refunds".
+func EndpointTemplate(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && segments[0] == "v1" {
+		segments = segments[1:]
+	}
+
+	// Paths alternate between the names of collections and the IDs of
+	// objects in them.
+	expectID := false
+	for i, segment := range segments {
+		if !expectID && namespaceSegments[segment] {
+			continue
+		}
+		if expectID && !singularSegments[segment] {
+			segments[i] = "{id}"
+			expectID = false
+			continue
+		}
+		expectID = !singularSegments[segment]
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
+
+// durationBuckets are the upper bounds, in seconds, of the buckets of the
+// request duration histograms exposed by MetricsCollector.
+var durationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// MetricsCollector aggregates request metrics by method, endpoint and status.
+// Its Observe method is meant to be used as a Telemetry's OnRequest hook.
+//
+// It can be published with expvar, in which case its value is a JSON object
+// of the aggregated metrics, and served over HTTP in the Prometheus text
+// exposition format.
+type MetricsCollector struct {
+	mu      sync.Mutex
+	metrics map[metricsKey]*endpointMetrics
+}
+
+// metricsKey identifies the requests metrics are aggregated for.
+type metricsKey struct {
+	method   string
+	endpoint string
+}
+
+// endpointMetrics are the metrics aggregated for an endpoint.
+type endpointMetrics struct {
+	statuses map[string]int64
+	count    int64
+	sum      time.Duration
+	buckets  []int64
+}
+
+// NewMetricsCollector returns an empty MetricsCollector.
+func NewMetricsCollector() *MetricsCollector {
+	return &MetricsCollector{metrics: make(map[metricsKey]*endpointMetrics)}
+}
+
+// Observe adds the metrics of a request to the collector.
+func (c *MetricsCollector) Observe(metrics *RequestMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := metricsKey{method: metrics.Method, endpoint: metrics.Endpoint}
+	m, ok := c.metrics[key]
+	if !ok {
+		m = &endpointMetrics{
+			statuses: make(map[string]int64),
+			buckets:  make([]int64, len(durationBuckets)),
+		}
+		c.metrics[key] = m
+	}
+
+	status := "error"
+	if metrics.StatusCode != 0 {
+		status = strconv.Itoa(metrics.StatusCode)
+	}
+	m.statuses[status]++
+
+	m.count++
+	m.sum += metrics.Duration
+	for i, bound := range durationBuckets {
+		if metrics.Duration.Seconds() <= bound {
+			m.buckets[i]++
+		}
+	}
+}
+
+// sortedKeys returns the keys of the collected metrics in a stable order. It
+// must be called with the lock held.
+func (c *MetricsCollector) sortedKeys() []metricsKey {
+	keys := make([]metricsKey, 0, len(c.metrics))
+	for key := range c.metrics {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].method < keys[j].method
+	})
+	return keys
+}
+
+// String returns the collected metrics as a JSON object, keyed by method and
+// endpoint. It implements expvar.Var.
+func (c *MetricsCollector) String() string {
+	type summary struct {
+		Count           int64            `json:"count"`
+		DurationSeconds float64          `json:"duration_seconds"`
+		Statuses        map[string]int64 `json:"statuses"`
+	}
+
+	c.mu.Lock()
+	summaries := make(map[string]summary, len(c.metrics))
+	for key, m := range c.metrics {
+		statuses := make(map[string]int64, len(m.statuses))
+		for status, count := range m.statuses {
+			statuses[status] = count
+		}
+		summaries[key.method+" "+key.endpoint] = summary{
+			Count:           m.count,
+			DurationSeconds: m.sum.Seconds(),
+			Statuses:        statuses,
+		}
+	}
+	c.mu.Unlock()
+
+	data, _ := json.Marshal(summaries)
+	return string(data)
+}
+
+// ServeHTTP writes the collected metrics in the Prometheus text exposition
+// format.
+func (c *MetricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	keys := c.sortedKeys()
+
+	fmt.Fprintln(w, "# HELP stripe_requests_total Requests made to Stripe, by status.")
+	fmt.Fprintln(w, "# TYPE stripe_requests_total counter")
+	for _, key := range keys {
+		m := c.metrics[key]
+		statuses := make([]string, 0, len(m.statuses))
+		for status := range m.statuses {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+
+		for _, status := range statuses {
+			fmt.Fprintf(w, "stripe_requests_total{method=%q,endpoint=%q,status=%q} %d\n",
+				key.method, key.endpoint, status, m.statuses[status])
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP stripe_request_duration_seconds Duration of requests made to Stripe.")
+	fmt.Fprintln(w, "# TYPE stripe_request_duration_seconds histogram")
+	for _, key := range keys {
+		m := c.metrics[key]
+		labels := fmt.Sprintf("method=%q,endpoint=%q", key.method, key.endpoint)
+		for i, bound := range durationBuckets {
+			fmt.Fprintf(w, "stripe_request_duration_seconds_bucket{%v,le=%q} %d\n",
+				labels, strconv.FormatFloat(bound, 'g', -1, 64), m.buckets[i])
+		}
+		fmt.Fprintf(w, "stripe_request_duration_seconds_bucket{%v,le=\"+Inf\"} %d\n", labels, m.count)
+		fmt.Fprintf(w, "stripe_request_duration_seconds_sum{%v} %v\n",
+			labels, strconv.FormatFloat(m.sum.Seconds(), 'g', -1, 64))
+		fmt.Fprintf(w, "stripe_request_duration_seconds_count{%v} %d\n", labels, m.count)
+	}
+}
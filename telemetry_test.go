@@ -0,0 +1,125 @@
+package stripe
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/v1/charges":                                 "/charges",
+		"/v1/charges/ch_123":                          "/charges/{id}",
+		"/v1/charges/ch_123/refunds/re_123":           "/charges/{id}/refunds/{id}",
+		"/v1/charges/ch_123/capture":                  "/charges/{id}/capture",
+		"/v1/customers/cus_123/discount":              "/customers/{id}/discount",
+		"/v1/account":                                 "/account",
+		"/v1/account/external_accounts/ba_1":          "/account/external_accounts/{id}",
+		"/v1/balance/history/txn_123":                 "/balance/history/{id}",
+		"/v1/invoices/upcoming/lines":                 "/invoices/upcoming/lines",
+		"/v1/plans/gold":                              "/plans/{id}",
+		"/files":                                      "/files",
+		"/v1/apple_pay/domains":                       "/apple_pay/domains",
+		"/v1/apple_pay/domains/apwc_123":              "/apple_pay/domains/{id}",
+		"/v1/bitcoin/receivers/btcrcv_1":              "/bitcoin/receivers/{id}",
+		"/v1/bitcoin/receivers/btcrcv_1/transactions": "/bitcoin/receivers/{id}/transactions",
+	}
+	for path, expected := range cases {
+		assert.Equal(t, expected, EndpointTemplate(path), path)
+	}
+}
+
+func TestTelemetry(t *testing.T) {
+	var headers []string
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		headers = append(headers, r.Header.Get("X-Stripe-Client-Telemetry"))
+		w.Header().Set("Request-Id", fmt.Sprintf("req_%d", requests))
+		if requests == 3 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"No such charge"}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	collector := NewMetricsCollector()
+	var metrics []*RequestMetrics
+	c := &BackendConfiguration{
+		Type:       APIBackend,
+		URL:        ts.URL + "/v1",
+		HTTPClient: &http.Client{},
+		Telemetry: &Telemetry{
+			Report: true,
+			OnRequest: func(m *RequestMetrics) {
+				metrics = append(metrics, m)
+				collector.Observe(m)
+			},
+		},
+	}
+
+	assert.NoError(t, c.Call("POST", "/charges", "sk_test_123", nil, nil, nil))
+	assert.NoError(t, c.Call("GET", "/charges/ch_123", "sk_test_123", nil, nil, nil))
+	assert.Error(t, c.Call("GET", "/charges/ch_456", "sk_test_123", nil, nil, nil))
+
+	assert.Equal(t, "", headers[0])
+
+	var payload telemetryPayload
+	assert.NoError(t, json.Unmarshal([]byte(headers[1]), &payload))
+	assert.Equal(t, "req_1", payload.LastRequestMetrics.RequestID)
+	assert.NoError(t, json.Unmarshal([]byte(headers[2]), &payload))
+	assert.Equal(t, "req_2", payload.LastRequestMetrics.RequestID)
+
+	assert.Equal(t, 3, len(metrics))
+	assert.Equal(t, "POST", metrics[0].Method)
+	assert.Equal(t, "/charges", metrics[0].Endpoint)
+	assert.Equal(t, "/charges/{id}", metrics[2].Endpoint)
+	assert.Equal(t, http.StatusNotFound, metrics[2].StatusCode)
+	assert.Equal(t, "req_3", metrics[2].RequestID)
+	assert.True(t, metrics[2].Duration > 0)
+
+	w := httptest.NewRecorder()
+	collector.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+	assert.Contains(t, body, `stripe_requests_total{method="GET",endpoint="/charges/{id}",status="200"} 1`+"\n")
+	assert.Contains(t, body, `stripe_requests_total{method="GET",endpoint="/charges/{id}",status="404"} 1`+"\n")
+	assert.Contains(t, body, `stripe_request_duration_seconds_bucket{method="POST",endpoint="/charges",le="+Inf"} 1`+"\n")
+	assert.Contains(t, body, `stripe_request_duration_seconds_count{method="GET",endpoint="/charges/{id}"} 2`+"\n")
+	assert.True(t, strings.HasPrefix(body, "# HELP stripe_requests_total"))
+
+	var _ expvar.Var = collector
+	var summaries map[string]struct {
+		Count    int64            `json:"count"`
+		Statuses map[string]int64 `json:"statuses"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(collector.String()), &summaries))
+	assert.Equal(t, int64(2), summaries["GET /charges/{id}"].Count)
+	assert.Equal(t, int64(1), summaries["GET /charges/{id}"].Statuses["404"])
+}
+
+func TestTelemetry_ConnectionError(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+
+	collector := NewMetricsCollector()
+	c := &BackendConfiguration{
+		Type:       APIBackend,
+		URL:        ts.URL,
+		HTTPClient: &http.Client{},
+		Telemetry:  &Telemetry{Report: true, OnRequest: collector.Observe},
+	}
+
+	assert.Error(t, c.Call("GET", "/customers/cus_123", "sk_test_123", nil, nil, nil))
+	assert.Contains(t, collector.String(), `"error":1`)
+
+	// Requests that didn't reach Stripe aren't reported.
+	assert.Empty(t, c.Telemetry.pending)
+}
@@ -0,0 +1,503 @@
+// Package cassette provides a stripe.Backend recording the requests made to
+// Stripe and their responses into cassette files, and replaying them later.
+//
+// Cassettes make it possible to run integration style tests offline and
+// deterministically: they're recorded once against the Stripe API, committed
+// alongside the tests, and replayed in CI:
+//
+//	backend, err := cassette.NewBackend(config, "testdata/charges.json",
+//		&cassette.Options{Mode: cassette.Mode(os.Getenv("STRIPE_CASSETTE_MODE"))})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer backend.Save()
+//
+//	stripe.SetBackend(stripe.APIBackend, backend)
+//
+// API keys, card numbers, verification codes, bank account numbers and
+// secrets are scrubbed from the recorded bodies.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/form"
+)
+
+// Mode is how a Backend uses its cassette.
+type Mode string
+
+// List of values that Mode can take.
+const (
+	// ModeReplay answers requests with the recorded responses, never
+	// reaching Stripe. A request without a recorded response fails with an
+	// *UnmatchedError. It's the default.
+	ModeReplay Mode = "replay"
+
+	// ModeRecord sends every request to Stripe and records it, replacing
+	// the cassette's previous contents when saved.
+	ModeRecord Mode = "record"
+
+	// ModeRecordMissing replays the requests which were recorded, and sends
+	// and records the others.
+	ModeRecordMissing Mode = "record_missing"
+
+	// ModePassthrough sends every request to Stripe without using the
+	// cassette.
+	ModePassthrough Mode = "passthrough"
+)
+
+// DefaultMatchHeaders are the request headers which must match for a
+// recorded request to be replayed, in addition to its method, path and body,
+// when Options.MatchHeaders isn't set.
+var DefaultMatchHeaders = []string{"Stripe-Account", "Stripe-Version"}
+
+// Options configures a Backend.
+type Options struct {
+	// Mode is how the cassette is used. It defaults to ModeReplay.
+	Mode Mode
+
+	// MatchHeaders are the request headers which must match for a
+	// recorded request to be replayed. It defaults to DefaultMatchHeaders.
+	MatchHeaders []string
+}
+
+// Cassette is the set of recorded interactions stored in a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a request made to Stripe along with its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. Body is form encoded, with its parameters
+// sorted by key.
+type Request struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Body    string      `json:"body,omitempty"`
+	Headers http.Header `json:"headers,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// UnmatchedError is returned for a request which can't be replayed because
+// no unused interaction of the cassette matches it.
+type UnmatchedError struct {
+	Cassette string
+	Request  *Request
+}
+
+func (e *UnmatchedError) Error() string {
+	msg := fmt.Sprintf("No interaction in cassette %v matches %v %v",
+		e.Cassette, e.Request.Method, e.Request.Path)
+	if e.Request.Body != "" {
+		msg += " with body " + e.Request.Body
+	}
+	return msg
+}
+
+// Backend is a stripe.Backend recording and replaying the requests made by a
+// BackendConfiguration. It's safe for concurrent use.
+type Backend struct {
+	config       *stripe.BackendConfiguration
+	path         string
+	mode         Mode
+	matchHeaders []string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     map[*Interaction]bool
+	err      error
+}
+
+// NewBackend returns a Backend making its requests through a copy of config,
+// whose HTTP client's transport is wrapped to record and replay them. The
+// cassette at path is loaded unless the mode is ModeRecord or
+// ModePassthrough; it must exist in ModeReplay.
+func NewBackend(config *stripe.BackendConfiguration, path string, options *Options) (*Backend, error) {
+	if options == nil {
+		options = &Options{}
+	}
+
+	b := &Backend{
+		path:         path,
+		mode:         options.Mode,
+		matchHeaders: options.MatchHeaders,
+		cassette:     &Cassette{},
+		used:         make(map[*Interaction]bool),
+	}
+	if b.mode == "" {
+		b.mode = ModeReplay
+	}
+	if b.matchHeaders == nil {
+		b.matchHeaders = DefaultMatchHeaders
+	}
+
+	switch b.mode {
+	case ModeReplay, ModeRecordMissing:
+		cassette, err := Load(path)
+		if err != nil && (b.mode == ModeReplay || !os.IsNotExist(err)) {
+			return nil, err
+		}
+		if cassette != nil {
+			b.cassette = cassette
+		}
+	case ModeRecord, ModePassthrough:
+	default:
+		return nil, fmt.Errorf("Unknown cassette mode %q", b.mode)
+	}
+
+	httpClient := &http.Client{}
+	if config.HTTPClient != nil {
+		*httpClient = *config.HTTPClient
+	}
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = &transport{backend: b, next: next}
+
+	c := *config
+	c.HTTPClient = httpClient
+	b.config = &c
+
+	return b, nil
+}
+
+// Load reads the cassette at path.
+func Load(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := &Cassette{}
+	if err := json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("Cannot decode cassette %v: %v", path, err)
+	}
+	return cassette, nil
+}
+
+// Call implements stripe.Backend.
+func (b *Backend) Call(method, path, key string, body *form.Values, params *stripe.Params, v interface{}) error {
+	return b.unmatched(b.config.Call(method, path, key, body, params, v))
+}
+
+// CallMultipart implements stripe.Backend.
+func (b *Backend) CallMultipart(method, path, key, boundary string, body io.Reader, params *stripe.Params, v interface{}) error {
+	return b.unmatched(b.config.CallMultipart(method, path, key, boundary, body, params, v))
+}
+
+// ParamsValidationEnabled implements stripe.ParamsValidator, forwarding to
+// the wrapped BackendConfiguration.
+func (b *Backend) ParamsValidationEnabled() bool {
+	return b.config.ParamsValidationEnabled()
+}
+
+// unmatched returns the *UnmatchedError err wraps, if any, so that it isn't
+// hidden behind the HTTP client's error.
+func (b *Backend) unmatched(err error) error {
+	var unmatched *UnmatchedError
+	if errors.As(err, &unmatched) {
+		return unmatched
+	}
+	return err
+}
+
+// Err returns the first *UnmatchedError encountered by the backend, so that
+// tests can fail on unmatched requests even when the code under test ignores
+// errors.
+func (b *Backend) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+// Save writes the cassette to its path when recording, creating its
+// directory if needed. It does nothing in ModeReplay and ModePassthrough.
+func (b *Backend) Save() error {
+	if b.mode != ModeRecord && b.mode != ModeRecordMissing {
+		return nil
+	}
+
+	// Bodies are kept readable by not escaping characters like &.
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	b.mu.Lock()
+	err := encoder.Encode(b.cassette)
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(b.path, buf.Bytes(), 0644)
+}
+
+// find returns the first unused interaction matching req, marking it used.
+func (b *Backend) find(req *Request) *Interaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, interaction := range b.cassette.Interactions {
+		if !b.used[interaction] && b.matches(&interaction.Request, req) {
+			b.used[interaction] = true
+			return interaction
+		}
+	}
+	return nil
+}
+
+func (b *Backend) matches(recorded, req *Request) bool {
+	if recorded.Method != req.Method || recorded.Path != req.Path || recorded.Body != req.Body {
+		return false
+	}
+
+	for _, name := range b.matchHeaders {
+		if recorded.Headers.Get(name) != req.Headers.Get(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *Backend) record(interaction *Interaction) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cassette.Interactions = append(b.cassette.Interactions, interaction)
+	b.used[interaction] = true
+}
+
+func (b *Backend) fail(err error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err == nil {
+		b.err = err
+	}
+	return err
+}
+
+// transport is the http.RoundTripper through which a Backend records and
+// replays requests.
+type transport struct {
+	backend *Backend
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := t.backend
+	if b.mode == ModePassthrough {
+		return t.next.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(strings.NewReader(string(body)))
+	}
+
+	recorded, err := newRequest(req, body, b.matchHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.mode != ModeRecord {
+		if interaction := b.find(recorded); interaction != nil {
+			return interaction.Response.httpResponse(req), nil
+		}
+		if b.mode == ModeReplay {
+			return nil, b.fail(&UnmatchedError{Cassette: b.path, Request: recorded})
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(strings.NewReader(string(resBody)))
+
+	b.record(&Interaction{
+		Request: *recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Headers:    filterHeaders(res.Header, recordedResponseHeaders),
+			Body:       scrubJSON(string(resBody)),
+		},
+	})
+
+	return res, nil
+}
+
+// newRequest returns the normalized and scrubbed form of req, which is
+// compared to recorded requests.
+func newRequest(req *http.Request, body []byte, matchHeaders []string) (*Request, error) {
+	recorded := &Request{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Headers: filterHeaders(req.Header, append(recordedRequestHeaders, matchHeaders...)),
+	}
+
+	if req.URL.RawQuery != "" {
+		query, err := normalizeForm(req.URL.RawQuery)
+		if err != nil {
+			return nil, err
+		}
+		recorded.Path += "?" + query
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	switch {
+	case len(body) == 0:
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		normalized, err := normalizeForm(string(body))
+		if err != nil {
+			return nil, err
+		}
+		recorded.Body = normalized
+	default:
+		// Multipart boundaries are random, and uploaded files may not
+		// be valid UTF-8.
+		s := strings.ToValidUTF8(string(body), "\uFFFD")
+		if i := strings.Index(contentType, "boundary="); i >= 0 {
+			s = strings.Replace(s, contentType[i+len("boundary="):], "BOUNDARY", -1)
+		}
+		recorded.Body = scrubJSON(s)
+	}
+
+	return recorded, nil
+}
+
+func (r *Response) httpResponse(req *http.Request) *http.Response {
+	header := http.Header{}
+	for k, v := range r.Headers {
+		header[k] = append([]string(nil), v...)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+var (
+	// recordedRequestHeaders are the request headers which are recorded,
+	// on top of the ones requests are matched on.
+	recordedRequestHeaders = []string{"Idempotency-Key", "Stripe-Account", "Stripe-Version"}
+
+	// recordedResponseHeaders are the response headers which are recorded.
+	recordedResponseHeaders = []string{"Content-Type", "Idempotency-Key", "Request-Id",
+		"Stripe-Account", "Stripe-Should-Retry", "Stripe-Version"}
+)
+
+func filterHeaders(header http.Header, names []string) http.Header {
+	filtered := http.Header{}
+	for _, name := range names {
+		if values := header.Values(name); len(values) > 0 {
+			filtered[http.CanonicalHeaderKey(name)] = values
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+	return filtered
+}
+
+var (
+	// scrubbedAPIKey matches secret and restricted API keys.
+	scrubbedAPIKey = regexp.MustCompile(`\b([sr]k_(?:live|test)_)[0-9A-Za-z]+`)
+
+	// scrubbedJSONField matches the secret fields of JSON bodies.
+	scrubbedJSONField = regexp.MustCompile(
+		`("(?:cvc|account_number|client_secret|secret|personal_id_number)"\s*:\s*)"[^"]*"`)
+
+	// scrubbedFormFields are the names of secret form parameters, or of the
+	// last part of their key, like number in card[number].
+	scrubbedFormFields = map[string]bool{
+		"account_number":     true,
+		"cvc":                true,
+		"number":             true,
+		"personal_id_number": true,
+	}
+)
+
+// scrubbed replaces secrets in recorded bodies.
+const scrubbed = "SCRUBBED"
+
+func scrubJSON(body string) string {
+	body = scrubbedAPIKey.ReplaceAllString(body, "${1}"+scrubbed)
+	return scrubbedJSONField.ReplaceAllString(body, `${1}"`+scrubbed+`"`)
+}
+
+// normalizeForm scrubs form encoded parameters and sorts them by key, keeping
+// the order of the values of a key, so that the same parameters encoded in a
+// different order match.
+func normalizeForm(s string) (string, error) {
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return "", err
+	}
+
+	for key, keyValues := range values {
+		name := key
+		if i := strings.LastIndex(key, "["); i >= 0 {
+			name = strings.TrimSuffix(key[i+1:], "]")
+		}
+
+		for i, value := range keyValues {
+			if scrubbedFormFields[name] {
+				keyValues[i] = scrubbed
+			} else {
+				keyValues[i] = scrubbedAPIKey.ReplaceAllString(value, "${1}"+scrubbed)
+			}
+		}
+	}
+
+	return values.Encode(), nil
+}
@@ -0,0 +1,212 @@
+package cassette
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/charge"
+	"github.com/stripe/stripe-go/customer"
+)
+
+// newServer returns a server answering requests for customers and charges,
+// along with the number of requests it received.
+func newServer(t *testing.T) (*httptest.Server, *int) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Request-Id", "req_123")
+
+		switch {
+		case r.URL.Path == "/customers/cus_123":
+			w.Write([]byte(`{"id":"cus_123","email":"jenny@example.com"}`))
+		case r.URL.Path == "/charges" && r.Method == "POST":
+			assert.NoError(t, r.ParseForm())
+			if r.Form.Get("amount") == "999" {
+				w.WriteHeader(http.StatusPaymentRequired)
+				w.Write([]byte(`{"error":{"type":"card_error","code":"card_declined","message":"Your card was declined."}}`))
+				return
+			}
+			w.Write([]byte(`{"id":"ch_123","amount":` + r.Form.Get("amount") +
+				`,"description":"paid with sk_test_abc123"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return ts, &requests
+}
+
+func newConfig(url string) *stripe.BackendConfiguration {
+	return &stripe.BackendConfiguration{
+		Type:       stripe.APIBackend,
+		URL:        url,
+		HTTPClient: &http.Client{},
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ts, requests := newServer(t)
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+
+	backend, err := NewBackend(newConfig(ts.URL), path, &Options{Mode: ModeRecord})
+	assert.NoError(t, err)
+
+	params := &stripe.ChargeParams{Amount: 1000, Currency: "usd"}
+	params.SetSource(&stripe.CardParams{Number: "4242424242424242", CVC: "123", Month: "10", Year: "20"})
+	ch, err := charge.Client{B: backend, Key: "sk_test_123"}.New(params)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), ch.Amount)
+
+	_, err = charge.Client{B: backend, Key: "sk_test_123"}.New(&stripe.ChargeParams{Amount: 999, Currency: "usd"})
+	assert.Equal(t, stripe.CardDeclined, err.(*stripe.Error).Code)
+
+	cus, err := customer.Client{B: backend, Key: "sk_test_123"}.Get("cus_123", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "jenny@example.com", cus.Email)
+
+	assert.NoError(t, backend.Save())
+	assert.Equal(t, 3, *requests)
+	ts.Close()
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "4242424242424242")
+	assert.NotContains(t, string(data), "sk_test_abc123")
+	assert.Contains(t, string(data), "source%5Bcvc%5D=SCRUBBED")
+
+	backend, err = NewBackend(newConfig(ts.URL), path, nil)
+	assert.NoError(t, err)
+
+	// Requests are matched whatever the order they're made in.
+	cus, err = customer.Client{B: backend, Key: "sk_test_456"}.Get("cus_123", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "jenny@example.com", cus.Email)
+
+	ch, err = charge.Client{B: backend, Key: "sk_test_456"}.New(params)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), ch.Amount)
+	assert.Equal(t, "paid with sk_test_SCRUBBED", ch.Desc)
+
+	_, err = charge.Client{B: backend, Key: "sk_test_456"}.New(&stripe.ChargeParams{Amount: 999, Currency: "usd"})
+	stripeErr := err.(*stripe.Error)
+	assert.Equal(t, stripe.CardDeclined, stripeErr.Code)
+	assert.Equal(t, "req_123", stripeErr.RequestID)
+
+	// Interactions are only replayed once.
+	_, err = customer.Client{B: backend, Key: "sk_test_456"}.Get("cus_123", nil)
+	assert.IsType(t, &UnmatchedError{}, err)
+	assert.Equal(t, err, backend.Err())
+}
+
+func TestReplayUnmatched(t *testing.T) {
+	ts, _ := newServer(t)
+	defer ts.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	_, err := NewBackend(newConfig(ts.URL), path, nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"interactions":[]}`), 0644))
+	backend, err := NewBackend(newConfig(ts.URL), path, nil)
+	assert.NoError(t, err)
+
+	_, err = charge.Client{B: backend, Key: "sk_test_123"}.New(&stripe.ChargeParams{Amount: 1000, Currency: "usd"})
+	assert.EqualError(t, err, "No interaction in cassette "+path+" matches POST /charges "+
+		"with body amount=1000&currency=usd")
+}
+
+func TestRecordMissing(t *testing.T) {
+	ts, requests := newServer(t)
+	defer ts.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	backend, err := NewBackend(newConfig(ts.URL), path, &Options{Mode: ModeRecordMissing})
+	assert.NoError(t, err)
+	_, err = customer.Client{B: backend, Key: "sk_test_123"}.Get("cus_123", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Save())
+
+	backend, err = NewBackend(newConfig(ts.URL), path, &Options{Mode: ModeRecordMissing})
+	assert.NoError(t, err)
+	_, err = customer.Client{B: backend, Key: "sk_test_123"}.Get("cus_123", nil)
+	assert.NoError(t, err)
+	_, err = charge.Client{B: backend, Key: "sk_test_123"}.New(&stripe.ChargeParams{Amount: 1000, Currency: "usd"})
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Save())
+	assert.Equal(t, 2, *requests)
+
+	cassette, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(cassette.Interactions))
+	assert.Equal(t, "/customers/cus_123", cassette.Interactions[0].Request.Path)
+	assert.Equal(t, "amount=1000&currency=usd", cassette.Interactions[1].Request.Body)
+}
+
+func TestMatchHeaders(t *testing.T) {
+	ts, requests := newServer(t)
+	defer ts.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	backend, err := NewBackend(newConfig(ts.URL), path, &Options{Mode: ModeRecordMissing})
+	assert.NoError(t, err)
+
+	params := &stripe.CustomerParams{}
+	params.StripeAccount = "acct_123"
+	_, err = customer.Client{B: backend, Key: "sk_test_123"}.Get("cus_123", params)
+	assert.NoError(t, err)
+
+	params.StripeAccount = "acct_456"
+	_, err = customer.Client{B: backend, Key: "sk_test_123"}.Get("cus_123", params)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *requests)
+}
+
+func TestPassthrough(t *testing.T) {
+	ts, requests := newServer(t)
+	defer ts.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	backend, err := NewBackend(newConfig(ts.URL), path, &Options{Mode: ModePassthrough})
+	assert.NoError(t, err)
+	_, err = customer.Client{B: backend, Key: "sk_test_123"}.Get("cus_123", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Save())
+	assert.Equal(t, 1, *requests)
+
+	_, err = Load(path)
+	assert.Error(t, err)
+}
+
+func TestParamsValidation(t *testing.T) {
+	ts, requests := newServer(t)
+	defer ts.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	config := newConfig(ts.URL)
+	config.ValidateParams = true
+	backend, err := NewBackend(config, path, &Options{Mode: ModePassthrough})
+	assert.NoError(t, err)
+
+	// The validation enabled for the wrapped configuration still applies.
+	_, err = charge.Client{B: backend, Key: "sk_test_123"}.New(&stripe.ChargeParams{})
+	assert.Error(t, err)
+	assert.Equal(t, "amount", err.(*stripe.Error).Param)
+	assert.Equal(t, 0, *requests)
+}
+
+func TestNormalizeForm(t *testing.T) {
+	normalized, err := normalizeForm("metadata%5Bb%5D=2&expand%5B%5D=customer&metadata%5Ba%5D=1&" +
+		"expand%5B%5D=invoice&bank_account%5Baccount_number%5D=000123456789")
+	assert.NoError(t, err)
+	assert.Equal(t, "bank_account%5Baccount_number%5D=SCRUBBED&expand%5B%5D=customer&"+
+		"expand%5B%5D=invoice&metadata%5Ba%5D=1&metadata%5Bb%5D=2", normalized)
+
+	assert.Equal(t, `{"secret":"SCRUBBED","key":"rk_live_SCRUBBED"}`,
+		scrubJSON(`{"secret":"ek_test_123","key":"rk_live_abc"}`))
+	assert.False(t, strings.Contains(scrubJSON(`{"cvc": "123"}`), "123"))
+}
@@ -0,0 +1,653 @@
+// Package fake provides an in-process fake of the Stripe API for unit tests.
+//
+// Backend implements stripe.Backend and keeps the objects it creates in
+// memory, so that code using the library can be tested without stripe-mock or
+// a network connection:
+//
+//	backend := fake.NewBackend()
+//	stripe.SetBackend(stripe.APIBackend, backend)
+//
+//	c, err := customer.New(&stripe.CustomerParams{Email: "jenny@example.com"})
+//
+// It supports customers, charges, refunds, plans, subscriptions, invoices,
+// products, SKUs, orders and payouts. Requests honour idempotency keys,
+// pagination cursors and expansions, and invalid ones fail with the same
+// *stripe.Error values as the Stripe API.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/form"
+)
+
+const (
+	// defaultListLimit and maxListLimit bound the number of objects
+	// returned by a list request.
+	defaultListLimit = 10
+	maxListLimit     = 100
+)
+
+// object is a Stripe object as it's represented in JSON.
+type object = map[string]interface{}
+
+// Backend is a fake of the Stripe API implementing stripe.Backend. It's safe
+// for concurrent use. The zero value isn't usable; use NewBackend.
+type Backend struct {
+	mu          sync.Mutex
+	collections map[string]*collection
+	idempotent  map[string]*idempotentResponse
+	ids         int
+	requests    int
+
+	// now returns the current time. It's only replaced in tests.
+	now func() time.Time
+}
+
+// collection holds the objects of a resource in the order they were created.
+type collection struct {
+	objects map[string]object
+	ids     []string
+}
+
+// idempotentResponse is the response to a request made with an idempotency
+// key, replayed when the key is used again.
+type idempotentResponse struct {
+	request string
+	status  int
+	body    []byte
+}
+
+// NewBackend returns an empty fake backend.
+func NewBackend() *Backend {
+	return &Backend{
+		collections: make(map[string]*collection),
+		idempotent:  make(map[string]*idempotentResponse),
+		now:         time.Now,
+	}
+}
+
+// Call implements stripe.Backend.
+func (b *Backend) Call(method, path, key string, body *form.Values, params *stripe.Params, v interface{}) error {
+	if params != nil && params.Context != nil {
+		if err := params.Context.Err(); err != nil {
+			return err
+		}
+	}
+
+	b.mu.Lock()
+	status, resBody := b.call(method, path, key, body, params)
+	b.requests++
+	requestID := fmt.Sprintf("req_fake%d", b.requests)
+	b.mu.Unlock()
+
+	if status >= 400 {
+		res := &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Request-Id": []string{requestID}},
+		}
+		return errorDecoder.ResponseToError(res, resBody)
+	}
+
+	if v != nil {
+		return json.Unmarshal(resBody, v)
+	}
+	return nil
+}
+
+// CallMultipart implements stripe.Backend. File uploads aren't supported.
+func (b *Backend) CallMultipart(method, path, key, boundary string, body io.Reader, params *stripe.Params, v interface{}) error {
+	return b.Call(method, path, key, nil, params, v)
+}
+
+// call handles a request, replaying the response to an earlier one made with
+// the same idempotency key. It must be called with the lock held.
+func (b *Backend) call(method, path, key string, body *form.Values, params *stripe.Params) (int, []byte) {
+	idempotencyKey := ""
+	if params != nil && strings.ToUpper(method) == "POST" {
+		idempotencyKey = params.IdempotencyKey
+	}
+
+	var request string
+	if idempotencyKey != "" {
+		request = method + " " + path
+		if body != nil {
+			request += "?" + body.Encode()
+		}
+
+		if res, ok := b.idempotent[idempotencyKey]; ok {
+			if res.request != request {
+				return errorResponse(invalidRequest("", "Keys for idempotent requests can only be "+
+					"used with the same parameters they were first used with. Try using a key "+
+					"other than '%v' if you meant to execute a different request.", idempotencyKey))
+			}
+			return res.status, res.body
+		}
+	}
+
+	status, resBody := b.handle(method, path, key, body)
+
+	// Like Stripe, responses are only saved once the request has been
+	// processed, so that invalid requests can be retried with the same key.
+	if idempotencyKey != "" && status != http.StatusBadRequest && status != http.StatusUnauthorized {
+		b.idempotent[idempotencyKey] = &idempotentResponse{
+			request: request,
+			status:  status,
+			body:    resBody,
+		}
+	}
+
+	return status, resBody
+}
+
+// handle routes a request to the resource it's for.
+func (b *Backend) handle(method, path, key string, body *form.Values) (int, []byte) {
+	if key == "" {
+		err := invalidRequest("", "You did not provide an API key. You need to provide your "+
+			"API key in the Authorization header, using Bearer auth.")
+		err.status = http.StatusUnauthorized
+		return errorResponse(err)
+	}
+
+	method = strings.ToUpper(method)
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	notFound := invalidRequest("", "Unrecognized request URL (%v: /v1/%v).",
+		method, strings.Trim(path, "/"))
+	notFound.status = http.StatusNotFound
+
+	r, ok := resources[segments[0]]
+	if !ok || len(segments) > 3 {
+		return errorResponse(notFound)
+	}
+
+	params, err := decodeForm(body)
+	if err != nil {
+		return errorResponse(invalidRequest("", "%v", err))
+	}
+
+	expand := stringSlice(params["expand"])
+	delete(params, "expand")
+
+	var res object
+	var apiErr *apiError
+
+	switch {
+	case len(segments) == 1 && method == "POST":
+		res, apiErr = b.create(r, params)
+	case len(segments) == 1 && method == "GET":
+		res, apiErr = b.list(r, params)
+		expand = listExpansions(expand)
+	case len(segments) == 2 && method == "GET":
+		res, apiErr = b.get(r, segments[1])
+	case len(segments) == 2 && method == "POST":
+		res, apiErr = b.update(r, segments[1], params)
+	case len(segments) == 2 && method == "DELETE":
+		res, apiErr = b.delete(r, segments[1], params)
+	case len(segments) == 3 && method == "POST" && r.actions[segments[2]] != nil:
+		res, apiErr = b.act(r, segments[1], segments[2], params)
+	default:
+		apiErr = notFound
+	}
+
+	if apiErr != nil {
+		return errorResponse(apiErr)
+	}
+
+	res = copyObject(res)
+	for _, path := range expand {
+		b.expand(r, res, strings.Split(path, "."))
+	}
+
+	data, jsonErr := json.Marshal(res)
+	if jsonErr != nil {
+		return errorResponse(&apiError{
+			status:  http.StatusInternalServerError,
+			Type:    stripe.ErrorTypeAPI,
+			Message: jsonErr.Error(),
+		})
+	}
+	return http.StatusOK, data
+}
+
+// create creates an object of resource r from params.
+func (b *Backend) create(r *resource, params object) (object, *apiError) {
+	for _, name := range r.required {
+		if params[name] == nil {
+			return nil, invalidRequest(name, "Missing required param: %v.", name)
+		}
+	}
+
+	params, err := coerceParams(params, r.typ)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.checkReferences(r, params); err != nil {
+		return nil, err
+	}
+
+	id, _ := params["id"].(string)
+	if id != "" && !r.customID {
+		return nil, invalidRequest("id", "Received unknown parameter: id")
+	}
+	if id == "" {
+		id = b.newID(r.prefix)
+	} else if b.find(r, id) != nil {
+		err := invalidRequest("id", "%v already exists.", r.title())
+		err.Code = "resource_already_exists"
+		return nil, err
+	}
+
+	obj := object{
+		"id":       id,
+		"object":   r.object,
+		"created":  b.now().Unix(),
+		"livemode": false,
+		"metadata": object{},
+	}
+	for k, v := range params {
+		obj[k] = v
+	}
+
+	if r.create != nil {
+		if err := r.create(b, obj, params); err != nil {
+			return nil, err
+		}
+	}
+
+	b.store(r, obj)
+	return obj, nil
+}
+
+// get retrieves an object of resource r.
+func (b *Backend) get(r *resource, id string) (object, *apiError) {
+	obj := b.find(r, id)
+	if obj == nil {
+		return nil, resourceMissing("id", r.object, id)
+	}
+	return obj, nil
+}
+
+// update updates an object of resource r with params. Metadata is merged, and
+// a metadata key set to an empty string is removed.
+func (b *Backend) update(r *resource, id string, params object) (object, *apiError) {
+	obj := b.find(r, id)
+	if obj == nil {
+		return nil, resourceMissing("id", r.object, id)
+	}
+
+	params, err := coerceParams(params, r.typ)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.checkReferences(r, params); err != nil {
+		return nil, err
+	}
+
+	if r.update != nil {
+		if err := r.update(b, obj, params); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range params {
+		if k == "metadata" {
+			mergeMetadata(obj, v)
+			continue
+		}
+		obj[k] = v
+	}
+
+	return obj, nil
+}
+
+// delete deletes an object of resource r.
+func (b *Backend) delete(r *resource, id string, params object) (object, *apiError) {
+	obj := b.find(r, id)
+	if obj == nil {
+		return nil, resourceMissing("id", r.object, id)
+	}
+
+	if r.remove != nil {
+		return r.remove(b, obj, params)
+	}
+	if !r.deletable {
+		return nil, invalidRequest("", "%v objects can't be deleted.", r.title())
+	}
+
+	c := b.collections[r.name]
+	delete(c.objects, id)
+	for i, other := range c.ids {
+		if other == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+
+	return object{"id": id, "object": r.object, "deleted": true}, nil
+}
+
+// act performs an action, like capturing a charge, on an object of resource r.
+func (b *Backend) act(r *resource, id, action string, params object) (object, *apiError) {
+	obj := b.find(r, id)
+	if obj == nil {
+		return nil, resourceMissing("id", r.object, id)
+	}
+
+	params, err := coerceParams(params, r.typ)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.actions[action](b, obj, params); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// list lists the objects of resource r, newest first. Top level string
+// parameters, like customer, filter the objects by the value of the field
+// with the same name.
+func (b *Backend) list(r *resource, params object) (object, *apiError) {
+	limit := defaultListLimit
+	if s, ok := params["limit"].(string); ok {
+		l, err := strconv.Atoi(s)
+		if err != nil || l < 1 || l > maxListLimit {
+			return nil, invalidRequest("limit", "Invalid integer: %v", s)
+		}
+		limit = l
+	}
+
+	startingAfter, _ := params["starting_after"].(string)
+	endingBefore, _ := params["ending_before"].(string)
+
+	filters := object{}
+	for k, v := range params {
+		switch k {
+		case "limit", "starting_after", "ending_before":
+			continue
+		}
+		if s, ok := v.(string); ok {
+			filters[k] = s
+		}
+	}
+
+	var all []object
+	c := b.collections[r.name]
+	if c != nil {
+		for i := len(c.ids) - 1; i >= 0; i-- {
+			obj := c.objects[c.ids[i]]
+			if matches(obj, filters) {
+				all = append(all, obj)
+			}
+		}
+	}
+
+	start, end := 0, len(all)
+	switch {
+	case startingAfter != "":
+		i := indexOf(all, startingAfter)
+		if i < 0 {
+			return nil, resourceMissing("starting_after", r.object, startingAfter)
+		}
+		start = i + 1
+		if start+limit < end {
+			end = start + limit
+		}
+	case endingBefore != "":
+		i := indexOf(all, endingBefore)
+		if i < 0 {
+			return nil, resourceMissing("ending_before", r.object, endingBefore)
+		}
+		end = i
+		if end-limit > start {
+			start = end - limit
+		}
+	default:
+		if limit < end {
+			end = limit
+		}
+	}
+
+	data := make([]interface{}, 0, end-start)
+	for _, obj := range all[start:end] {
+		data = append(data, obj)
+	}
+
+	hasMore := end < len(all)
+	if endingBefore != "" {
+		hasMore = start > 0
+	}
+
+	return object{
+		"object":   "list",
+		"data":     data,
+		"has_more": hasMore,
+		"url":      "/v1/" + r.name,
+	}, nil
+}
+
+// expand replaces the ID at path in obj, an object of resource r, with the
+// object it references.
+func (b *Backend) expand(r *resource, obj object, path []string) {
+	if r == nil || len(path) == 0 {
+		return
+	}
+
+	if path[0] == "data" {
+		data, _ := obj["data"].([]interface{})
+		for _, elem := range data {
+			if elemObj, ok := elem.(object); ok {
+				b.expand(r, elemObj, path[1:])
+			}
+		}
+		return
+	}
+
+	field := path[0]
+	refName, ok := r.refs[field]
+	if !ok {
+		return
+	}
+	ref := resources[refName]
+
+	if id, ok := obj[field].(string); ok {
+		target := b.find(ref, id)
+		if target == nil {
+			return
+		}
+		obj[field] = copyObject(target)
+	}
+
+	if child, ok := obj[field].(object); ok {
+		b.expand(ref, child, path[1:])
+	}
+}
+
+// checkReferences checks that the objects referenced by params exist.
+func (b *Backend) checkReferences(r *resource, params object) *apiError {
+	for field, refName := range r.refs {
+		id, ok := params[field].(string)
+		if !ok || id == "" {
+			continue
+		}
+		ref := resources[refName]
+		if b.find(ref, id) == nil {
+			return resourceMissing(field, ref.object, id)
+		}
+	}
+	return nil
+}
+
+// find returns the object of resource r with the given ID, or nil.
+func (b *Backend) find(r *resource, id string) object {
+	c := b.collections[r.name]
+	if c == nil {
+		return nil
+	}
+	return c.objects[id]
+}
+
+// store adds a new object to resource r.
+func (b *Backend) store(r *resource, obj object) {
+	c := b.collections[r.name]
+	if c == nil {
+		c = &collection{objects: make(map[string]object)}
+		b.collections[r.name] = c
+	}
+
+	id := obj["id"].(string)
+	c.objects[id] = obj
+	c.ids = append(c.ids, id)
+}
+
+// newID returns a new object ID with the given prefix.
+func (b *Backend) newID(prefix string) string {
+	b.ids++
+	return fmt.Sprintf("%v_fake%014d", prefix, b.ids)
+}
+
+// apiError is an error as returned by the Stripe API.
+type apiError struct {
+	status int
+
+	Type        stripe.ErrorType   `json:"type"`
+	Code        stripe.ErrorCode   `json:"code,omitempty"`
+	DeclineCode stripe.DeclineCode `json:"decline_code,omitempty"`
+	Message     string             `json:"message"`
+	Param       string             `json:"param,omitempty"`
+	Charge      string             `json:"charge,omitempty"`
+}
+
+func invalidRequest(param, format string, args ...interface{}) *apiError {
+	return &apiError{
+		status:  http.StatusBadRequest,
+		Type:    stripe.ErrorTypeInvalidRequest,
+		Message: fmt.Sprintf(format, args...),
+		Param:   param,
+	}
+}
+
+func resourceMissing(param, objectName, id string) *apiError {
+	err := invalidRequest(param, "No such %v: %v", objectName, id)
+	err.status = http.StatusNotFound
+	err.Code = "resource_missing"
+	return err
+}
+
+func errorResponse(err *apiError) (int, []byte) {
+	data, jsonErr := json.Marshal(map[string]*apiError{"error": err})
+	if jsonErr != nil {
+		panic(jsonErr)
+	}
+	return err.status, data
+}
+
+// errorDecoder decodes errors the way a real backend does, without logging
+// them.
+var errorDecoder = &stripe.BackendConfiguration{Logger: discardLogger{}}
+
+// discardLogger is a stripe.StructuredLogger discarding every record.
+type discardLogger struct{}
+
+func (discardLogger) Enabled(ctx context.Context, level slog.Level) bool {
+	return false
+}
+
+func (discardLogger) Log(ctx context.Context, level slog.Level, msg string, args ...interface{}) {
+}
+
+// listExpansions returns the expansions that apply to the objects of a list,
+// which are prefixed with "data.".
+func listExpansions(expand []string) []string {
+	var paths []string
+	for _, path := range expand {
+		if strings.HasPrefix(path, "data.") {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// matches returns whether obj has the values in filters.
+func matches(obj object, filters object) bool {
+	for k, v := range filters {
+		if fmt.Sprint(obj[k]) != v {
+			return false
+		}
+	}
+	return true
+}
+
+func indexOf(objects []object, id string) int {
+	for i, obj := range objects {
+		if obj["id"] == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func mergeMetadata(obj object, v interface{}) {
+	meta, _ := obj["metadata"].(object)
+	if meta == nil {
+		meta = object{}
+		obj["metadata"] = meta
+	}
+
+	// Metadata is unset entirely with an empty string.
+	updates, ok := v.(object)
+	if !ok {
+		obj["metadata"] = object{}
+		return
+	}
+
+	for k, value := range updates {
+		if value == "" {
+			delete(meta, k)
+		} else {
+			meta[k] = value
+		}
+	}
+}
+
+func stringSlice(v interface{}) []string {
+	values, _ := v.([]interface{})
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// toInt64 returns the integer value of a JSON number or numeric string.
+func toInt64(v interface{}) int64 {
+	switch v := v.(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case int:
+		return int64(v)
+	case string:
+		i, _ := strconv.ParseInt(v, 10, 64)
+		return i
+	}
+	return 0
+}
@@ -0,0 +1,327 @@
+package fake
+
+import (
+	"net/http"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/charge"
+	"github.com/stripe/stripe-go/customer"
+	"github.com/stripe/stripe-go/invoice"
+	"github.com/stripe/stripe-go/order"
+	"github.com/stripe/stripe-go/payout"
+	"github.com/stripe/stripe-go/plan"
+	"github.com/stripe/stripe-go/product"
+	"github.com/stripe/stripe-go/refund"
+	"github.com/stripe/stripe-go/sku"
+	"github.com/stripe/stripe-go/sub"
+)
+
+const testKey = "sk_test_123"
+
+func TestCustomers(t *testing.T) {
+	c := customer.Client{B: NewBackend(), Key: testKey}
+
+	params := &stripe.CustomerParams{Email: "jenny@example.com"}
+	params.AddMeta("foo", "bar")
+	cus, err := c.New(params)
+	assert.NoError(t, err)
+	assert.Regexp(t, "^cus_", cus.ID)
+	assert.Equal(t, "jenny@example.com", cus.Email)
+	assert.Equal(t, map[string]string{"foo": "bar"}, cus.Meta)
+
+	params = &stripe.CustomerParams{Desc: "Jenny"}
+	params.AddMeta("foo", "")
+	cus, err = c.Update(cus.ID, params)
+	assert.NoError(t, err)
+	assert.Equal(t, "jenny@example.com", cus.Email)
+	assert.Equal(t, "Jenny", cus.Desc)
+	assert.Empty(t, cus.Meta)
+
+	cus, err = c.Get(cus.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Jenny", cus.Desc)
+
+	cus, err = c.Del(cus.ID, nil)
+	assert.NoError(t, err)
+	assert.True(t, cus.Deleted)
+
+	_, err = c.Get(cus.ID, nil)
+	stripeErr := err.(*stripe.Error)
+	assert.Equal(t, http.StatusNotFound, stripeErr.HTTPStatusCode)
+	assert.Equal(t, stripe.ErrorCode("resource_missing"), stripeErr.Code)
+	assert.Regexp(t, "^req_", stripeErr.RequestID)
+}
+
+func TestList(t *testing.T) {
+	c := customer.Client{B: NewBackend(), Key: testKey}
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		cus, err := c.New(&stripe.CustomerParams{})
+		assert.NoError(t, err)
+		ids = append([]string{cus.ID}, ids...)
+	}
+
+	params := &stripe.CustomerListParams{}
+	params.Filters.AddFilter("limit", "", "2")
+
+	var listed []string
+	it := c.List(params)
+	for it.Next() {
+		listed = append(listed, it.Customer().ID)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, ids, listed)
+
+	params = &stripe.CustomerListParams{}
+	params.Filters.AddFilter("limit", "", "2")
+	params.Single = true
+	params.End = ids[3]
+
+	listed = nil
+	it = c.List(params)
+	for it.Next() {
+		listed = append(listed, it.Customer().ID)
+	}
+	assert.NoError(t, it.Err())
+	// Pages fetched backwards are iterated oldest first.
+	assert.Equal(t, []string{ids[2], ids[1]}, listed)
+}
+
+func TestChargesAndRefunds(t *testing.T) {
+	backend := NewBackend()
+	charges := charge.Client{B: backend, Key: testKey}
+	refunds := refund.Client{B: backend, Key: testKey}
+	customers := customer.Client{B: backend, Key: testKey}
+
+	cus, err := customers.New(&stripe.CustomerParams{Source: &stripe.SourceParams{Token: "tok_visa"}})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cus.DefaultSource.ID())
+
+	params := &stripe.ChargeParams{Amount: 1000, Currency: "usd", Customer: cus.ID}
+	params.Expand("customer")
+	ch, err := charges.New(params)
+	assert.NoError(t, err)
+	assert.Regexp(t, "^ch_", ch.ID)
+	assert.True(t, ch.Paid)
+	assert.Equal(t, cus.DefaultSource.ID(), ch.Source.ID)
+	assert.True(t, ch.Customer.IsExpanded())
+	assert.Equal(t, cus.ID, ch.Customer.Object().ID)
+
+	re, err := refunds.New(&stripe.RefundParams{Charge: ch.ID, Amount: 400})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(400), re.Amount)
+
+	_, err = refunds.New(&stripe.RefundParams{Charge: ch.ID, Amount: 700})
+	assert.Equal(t, stripe.ErrorCode("amount_too_large"), err.(*stripe.Error).Code)
+
+	re, err = refunds.New(&stripe.RefundParams{Charge: ch.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(600), re.Amount)
+
+	ch, err = charges.Get(ch.ID, nil)
+	assert.NoError(t, err)
+	assert.True(t, ch.Refunded)
+	assert.Equal(t, uint64(1000), ch.AmountRefunded)
+	assert.Equal(t, 2, len(ch.Refunds.Values))
+
+	_, err = refunds.New(&stripe.RefundParams{Charge: ch.ID})
+	assert.Equal(t, stripe.ErrorCode("charge_already_refunded"), err.(*stripe.Error).Code)
+}
+
+func TestChargeErrors(t *testing.T) {
+	c := charge.Client{B: NewBackend(), Key: testKey}
+
+	params := &stripe.ChargeParams{Amount: 1000, Currency: "usd"}
+	params.SetSource("tok_chargeDeclined")
+	_, err := c.New(params)
+	stripeErr := err.(*stripe.Error)
+	assert.Equal(t, http.StatusPaymentRequired, stripeErr.HTTPStatusCode)
+	assert.Equal(t, stripe.ErrorTypeCard, stripeErr.Type)
+	assert.Equal(t, stripe.CardDeclined, stripeErr.Code)
+
+	_, err = c.New(&stripe.ChargeParams{Currency: "usd"})
+	stripeErr = err.(*stripe.Error)
+	assert.Equal(t, http.StatusBadRequest, stripeErr.HTTPStatusCode)
+	assert.Equal(t, "amount", stripeErr.Param)
+
+	params = &stripe.ChargeParams{Amount: 1000, Currency: "usd", NoCapture: true}
+	params.SetSource("tok_visa")
+	ch, err := c.New(params)
+	assert.NoError(t, err)
+	assert.False(t, ch.Captured)
+
+	ch, err = c.Capture(ch.ID, nil)
+	assert.NoError(t, err)
+	assert.True(t, ch.Captured)
+
+	_, err = c.Capture(ch.ID, nil)
+	assert.Equal(t, stripe.ErrorCode("charge_already_captured"), err.(*stripe.Error).Code)
+
+	_, err = charge.Client{B: NewBackend()}.Get(ch.ID, nil)
+	assert.Equal(t, http.StatusUnauthorized, err.(*stripe.Error).HTTPStatusCode)
+}
+
+func TestIdempotency(t *testing.T) {
+	c := customer.Client{B: NewBackend(), Key: testKey}
+
+	params := &stripe.CustomerParams{Email: "jenny@example.com"}
+	params.IdempotencyKey = "key"
+	first, err := c.New(params)
+	assert.NoError(t, err)
+
+	second, err := c.New(params)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	params = &stripe.CustomerParams{Email: "john@example.com"}
+	params.IdempotencyKey = "key"
+	_, err = c.New(params)
+	assert.Equal(t, http.StatusBadRequest, err.(*stripe.Error).HTTPStatusCode)
+}
+
+func TestSubscriptions(t *testing.T) {
+	backend := NewBackend()
+	customers := customer.Client{B: backend, Key: testKey}
+	plans := plan.Client{B: backend, Key: testKey}
+	subs := sub.Client{B: backend, Key: testKey}
+
+	cus, err := customers.New(&stripe.CustomerParams{})
+	assert.NoError(t, err)
+
+	p, err := plans.New(&stripe.PlanParams{
+		ID:       "gold",
+		Amount:   stripe.Uint64(2000),
+		Currency: "usd",
+		Interval: "month",
+		Product:  &stripe.PlanProductParams{Name: "Gold"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "gold", p.ID)
+	assert.Regexp(t, "^prod_", p.Product)
+
+	_, err = plans.New(&stripe.PlanParams{
+		ID:        "gold",
+		Amount:    stripe.Uint64(2000),
+		Currency:  "usd",
+		Interval:  "month",
+		ProductID: &p.Product,
+	})
+	assert.Equal(t, stripe.ErrorCode("resource_already_exists"), err.(*stripe.Error).Code)
+
+	s, err := subs.New(&stripe.SubParams{
+		Customer: cus.ID,
+		Items:    []*stripe.SubItemsParams{{Plan: "gold"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.SubStatus("active"), s.Status)
+	assert.Equal(t, "gold", s.Plan.ID)
+	assert.Equal(t, "gold", s.Items.Values[0].Plan.ID)
+
+	_, err = subs.New(&stripe.SubParams{Customer: cus.ID, Plan: "silver"})
+	assert.Equal(t, "plan", err.(*stripe.Error).Param)
+
+	s, err = subs.Cancel(s.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.SubStatus("canceled"), s.Status)
+	assert.NotZero(t, s.Canceled)
+}
+
+func TestInvoices(t *testing.T) {
+	backend := NewBackend()
+	customers := customer.Client{B: backend, Key: testKey}
+	invoices := invoice.Client{B: backend, Key: testKey}
+
+	cus, err := customers.New(&stripe.CustomerParams{})
+	assert.NoError(t, err)
+
+	in, err := invoices.New(&stripe.InvoiceParams{Customer: cus.ID})
+	assert.NoError(t, err)
+	assert.False(t, in.Paid)
+
+	in, err = invoices.Pay(in.ID, nil)
+	assert.NoError(t, err)
+	assert.True(t, in.Paid)
+
+	_, err = invoices.New(&stripe.InvoiceParams{Customer: "cus_missing"})
+	assert.Equal(t, "customer", err.(*stripe.Error).Param)
+}
+
+func TestOrders(t *testing.T) {
+	backend := NewBackend()
+	products := product.Client{B: backend, Key: testKey}
+	skus := sku.Client{B: backend, Key: testKey}
+	orders := order.Client{B: backend, Key: testKey}
+
+	prod, err := products.New(&stripe.ProductParams{Name: "T-shirt"})
+	assert.NoError(t, err)
+
+	s, err := skus.New(&stripe.SKUParams{
+		Product:   prod.ID,
+		Price:     1500,
+		Currency:  "usd",
+		Inventory: stripe.Inventory{Type: "infinite"},
+	})
+	assert.NoError(t, err)
+
+	quantity := int64(2)
+	o, err := orders.New(&stripe.OrderParams{
+		Currency: "usd",
+		Items:    []*stripe.OrderItemParams{{Type: "sku", Parent: s.ID, Quantity: &quantity}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000), o.Amount)
+	assert.Equal(t, stripe.OrderStatus("created"), o.Status)
+
+	payParams := &stripe.OrderPayParams{}
+	payParams.SetSource("tok_visa")
+	o, err = orders.Pay(o.ID, payParams)
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.OrderStatus("paid"), o.Status)
+
+	ch, err := charge.Client{B: backend, Key: testKey}.Get(o.Charge.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3000), ch.Amount)
+}
+
+func TestPayouts(t *testing.T) {
+	c := payout.Client{B: NewBackend(), Key: testKey}
+
+	po, err := c.New(&stripe.PayoutParams{Amount: 1000, Currency: "usd"})
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.PayoutStatus("pending"), po.Status)
+
+	po, err = c.Cancel(po.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.PayoutStatus("canceled"), po.Status)
+
+	_, err = c.Cancel(po.ID, nil)
+	assert.Equal(t, http.StatusBadRequest, err.(*stripe.Error).HTTPStatusCode)
+}
+
+func TestValidateParams(t *testing.T) {
+	stripe.ValidateParams = true
+	defer func() {
+		stripe.ValidateParams = false
+	}()
+
+	b := NewBackend()
+
+	// Invalid parameters, including missing ones, fail without a request.
+	_, err := sub.Client{B: b, Key: testKey}.New(nil)
+	assert.Error(t, err)
+	assert.Equal(t, "customer", err.(*stripe.Error).Param)
+
+	_, err = charge.Client{B: b, Key: testKey}.New(&stripe.ChargeParams{Amount: 1000})
+	assert.Error(t, err)
+	assert.Equal(t, "currency", err.(*stripe.Error).Param)
+	assert.Equal(t, 0, b.requests)
+
+	params := &stripe.ChargeParams{Amount: 1000, Currency: "usd"}
+	params.SetSource("tok_visa")
+	_, err = charge.Client{B: b, Key: testKey}.New(params)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, b.requests)
+}
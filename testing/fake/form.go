@@ -0,0 +1,187 @@
+package fake
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stripe/stripe-go/form"
+)
+
+// decodeForm decodes form encoded parameters into nested maps and slices, the
+// way the Stripe API interprets them. Keys like "metadata[foo]" produce maps,
+// while keys like "expand[]" or "items[0][plan]" produce slices. All values
+// are strings.
+func decodeForm(body *form.Values) (object, error) {
+	params := object{}
+	if body == nil || body.Empty() {
+		return params, nil
+	}
+
+	if err := form.Unmarshal(body.ToValues(), &params); err != nil {
+		return nil, err
+	}
+	return indexedMapsToSlices(params).(object), nil
+}
+
+// indexedMapsToSlices converts the maps that only have integer keys, which
+// come from keys like "items[0][plan]", to slices.
+func indexedMapsToSlices(v interface{}) interface{} {
+	switch v := v.(type) {
+	case object:
+		for k, child := range v {
+			v[k] = indexedMapsToSlices(child)
+		}
+
+		if len(v) == 0 {
+			return v
+		}
+		indexes := make([]int, 0, len(v))
+		for k := range v {
+			i, err := strconv.Atoi(k)
+			if err != nil {
+				return v
+			}
+			indexes = append(indexes, i)
+		}
+		sort.Ints(indexes)
+
+		values := make([]interface{}, 0, len(v))
+		for _, i := range indexes {
+			values = append(values, v[strconv.Itoa(i)])
+		}
+		return values
+
+	case []interface{}:
+		for i, child := range v {
+			v[i] = indexedMapsToSlices(child)
+		}
+	}
+
+	return v
+}
+
+// coerceParams converts the string values of decoded parameters to the JSON
+// types of the corresponding fields of t, so that an object built from them
+// decodes into t. Parameters that don't match a field are left as they are.
+func coerceParams(params object, t reflect.Type) (object, *apiError) {
+	v, err := coerce(params, t, nil)
+	if err != nil {
+		return nil, err
+	}
+	return v.(object), nil
+}
+
+func coerce(v interface{}, t reflect.Type, path []string) (interface{}, *apiError) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch v := v.(type) {
+	case string:
+		return coerceString(v, t, path)
+
+	case []interface{}:
+		if t.Kind() != reflect.Slice {
+			return v, nil
+		}
+		for i, elem := range v {
+			coerced, err := coerce(elem, t.Elem(), append(path, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			v[i] = coerced
+		}
+		return v, nil
+
+	case object:
+		if t.Kind() != reflect.Struct {
+			return v, nil
+		}
+		for key, value := range v {
+			field, ok := jsonField(t, key)
+			if !ok {
+				continue
+			}
+			coerced, err := coerce(value, field.Type, append(path, key))
+			if err != nil {
+				return nil, err
+			}
+			v[key] = coerced
+		}
+		return v, nil
+	}
+
+	return v, nil
+}
+
+func coerceString(s string, t reflect.Type, path []string) (interface{}, *apiError) {
+	var (
+		v    interface{}
+		err  error
+		kind string
+	)
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v, err = strconv.ParseBool(s)
+		kind = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err = strconv.ParseInt(s, 10, 64)
+		kind = "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err = strconv.ParseUint(s, 10, 64)
+		kind = "integer"
+	case reflect.Float32, reflect.Float64:
+		v, err = strconv.ParseFloat(s, 64)
+		kind = "decimal"
+	default:
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, invalidRequest(form.FormatKey(path), "Invalid %v: %v", kind, s)
+	}
+	return v, nil
+}
+
+// jsonField returns the field of struct type t whose JSON name is name.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if f, ok := jsonField(embedded, name); ok {
+					return f, true
+				}
+			}
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// copyObject returns a deep copy of an object.
+func copyObject(o object) object {
+	data, err := json.Marshal(o)
+	if err != nil {
+		panic(err)
+	}
+
+	var c object
+	if err := json.Unmarshal(data, &c); err != nil {
+		panic(err)
+	}
+	return c
+}
@@ -0,0 +1,609 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+// hook customizes how an object is created, updated or acted on. obj is the
+// stored object and params are the request's coerced parameters.
+type hook func(b *Backend, obj, params object) *apiError
+
+// resource describes an API resource served under /v1/<name>.
+type resource struct {
+	name   string
+	object string
+	prefix string
+
+	// typ is the type objects of the resource decode into, which parameter
+	// values are coerced to.
+	typ reflect.Type
+
+	// required are the parameters which must be given on creation.
+	required []string
+
+	// refs maps the fields referencing other objects, which can be
+	// expanded, to the name of the referenced resource.
+	refs map[string]string
+
+	// customID is whether an ID can be given on creation.
+	customID bool
+
+	// deletable is whether objects can be deleted. remove, when set,
+	// handles deletions instead.
+	deletable bool
+
+	create  hook
+	update  hook
+	remove  func(b *Backend, obj, params object) (object, *apiError)
+	actions map[string]hook
+}
+
+// title returns the name of the resource's objects for use in messages, like
+// "Plan".
+func (r *resource) title() string {
+	name := strings.Replace(r.object, "_", " ", -1)
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// resources are the resources the fake supports, by name. It's populated in
+// init because the hooks refer to it.
+var resources map[string]*resource
+
+func init() {
+	resources = map[string]*resource{
+		"charges": {
+			name:     "charges",
+			object:   "charge",
+			prefix:   "ch",
+			typ:      reflect.TypeOf(stripe.Charge{}),
+			required: []string{"amount", "currency"},
+			refs:     map[string]string{"customer": "customers", "invoice": "invoices"},
+			create:   createCharge,
+			actions:  map[string]hook{"capture": captureCharge},
+		},
+		"customers": {
+			name:      "customers",
+			object:    "customer",
+			prefix:    "cus",
+			typ:       reflect.TypeOf(stripe.Customer{}),
+			deletable: true,
+			create:    createCustomer,
+			update:    updateCustomer,
+		},
+		"invoices": {
+			name:     "invoices",
+			object:   "invoice",
+			prefix:   "in",
+			typ:      reflect.TypeOf(stripe.Invoice{}),
+			required: []string{"customer"},
+			refs:     map[string]string{"customer": "customers", "charge": "charges"},
+			create:   createInvoice,
+			actions:  map[string]hook{"pay": payInvoice},
+		},
+		"orders": {
+			name:     "orders",
+			object:   "order",
+			prefix:   "or",
+			typ:      reflect.TypeOf(stripe.Order{}),
+			required: []string{"currency"},
+			refs:     map[string]string{"customer": "customers", "charge": "charges"},
+			create:   createOrder,
+			actions:  map[string]hook{"pay": payOrder},
+		},
+		"payouts": {
+			name:     "payouts",
+			object:   "payout",
+			prefix:   "po",
+			typ:      reflect.TypeOf(stripe.Payout{}),
+			required: []string{"amount", "currency"},
+			create:   createPayout,
+			actions:  map[string]hook{"cancel": cancelPayout},
+		},
+		"plans": {
+			name:      "plans",
+			object:    "plan",
+			prefix:    "plan",
+			typ:       reflect.TypeOf(stripe.Plan{}),
+			required:  []string{"amount", "currency", "interval", "product"},
+			refs:      map[string]string{"product": "products"},
+			customID:  true,
+			deletable: true,
+			create:    createPlan,
+		},
+		"products": {
+			name:      "products",
+			object:    "product",
+			prefix:    "prod",
+			typ:       reflect.TypeOf(stripe.Product{}),
+			required:  []string{"name"},
+			deletable: true,
+			create:    createProduct,
+		},
+		"refunds": {
+			name:     "refunds",
+			object:   "refund",
+			prefix:   "re",
+			typ:      reflect.TypeOf(stripe.Refund{}),
+			required: []string{"charge"},
+			refs:     map[string]string{"charge": "charges"},
+			create:   createRefund,
+		},
+		"skus": {
+			name:      "skus",
+			object:    "sku",
+			prefix:    "sku",
+			typ:       reflect.TypeOf(stripe.SKU{}),
+			required:  []string{"currency", "inventory", "price", "product"},
+			refs:      map[string]string{"product": "products"},
+			deletable: true,
+			create:    createSKU,
+		},
+		"subscriptions": {
+			name:     "subscriptions",
+			object:   "subscription",
+			prefix:   "sub",
+			typ:      reflect.TypeOf(stripe.Sub{}),
+			required: []string{"customer"},
+			refs:     map[string]string{"customer": "customers"},
+			create:   createSub,
+			update:   updateSub,
+			remove:   cancelSub,
+		},
+	}
+}
+
+// Test tokens which produce a card, or a declined charge.
+const (
+	tokenChargeDeclined = "tok_chargeDeclined"
+	tokenPrefix         = "tok_"
+)
+
+// newCard returns the card created from a test token.
+func (b *Backend) newCard(token string) (object, *apiError) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, resourceMissing("source", "source", token)
+	}
+
+	return object{
+		"id":        b.newID("card"),
+		"object":    "card",
+		"brand":     "Visa",
+		"country":   "US",
+		"exp_month": 8,
+		"exp_year":  b.now().Year() + 1,
+		"funding":   "credit",
+		"last4":     "4242",
+		"metadata":  object{},
+		"token":     token,
+	}, nil
+}
+
+func createCustomer(b *Backend, obj, params object) *apiError {
+	obj["sources"] = newList(fmt.Sprintf("/v1/customers/%v/sources", obj["id"]))
+	obj["default_source"] = nil
+	return updateCustomer(b, obj, params)
+}
+
+// updateCustomer replaces the customer's source when a token is given.
+func updateCustomer(b *Backend, obj, params object) *apiError {
+	token, ok := params["source"].(string)
+	if !ok {
+		return nil
+	}
+	delete(params, "source")
+	delete(obj, "source")
+
+	card, err := b.newCard(token)
+	if err != nil {
+		return err
+	}
+	card["customer"] = obj["id"]
+
+	sources := obj["sources"].(object)
+	sources["data"] = []interface{}{card}
+	sources["total_count"] = 1
+	obj["default_source"] = card["id"]
+	return nil
+}
+
+// createCharge charges a source or a customer's default source. Charging the
+// tok_chargeDeclined token fails with a card error.
+func createCharge(b *Backend, obj, params object) *apiError {
+	token, _ := params["source"].(string)
+	customerID, _ := params["customer"].(string)
+
+	var card object
+	switch {
+	case token == tokenChargeDeclined:
+		return &apiError{
+			status:      http.StatusPaymentRequired,
+			Type:        stripe.ErrorTypeCard,
+			Code:        stripe.CardDeclined,
+			DeclineCode: stripe.DeclineCodeGenericDecline,
+			Message:     "Your card was declined.",
+		}
+	case token != "":
+		var err *apiError
+		if card, err = b.newCard(token); err != nil {
+			return err
+		}
+	case customerID != "":
+		card = defaultSource(b.find(resources["customers"], customerID))
+		if card == nil {
+			return invalidRequest("card", "Cannot charge a customer that has no active card")
+		}
+	default:
+		return invalidRequest("", "Must provide source or customer.")
+	}
+
+	captured := params["capture"] != "false"
+	delete(obj, "capture")
+
+	obj["source"] = card
+	obj["currency"] = strings.ToLower(fmt.Sprint(obj["currency"]))
+	obj["amount_refunded"] = int64(0)
+	obj["captured"] = captured
+	obj["paid"] = true
+	obj["refunded"] = false
+	obj["refunds"] = newList(fmt.Sprintf("/v1/charges/%v/refunds", obj["id"]))
+	obj["status"] = "succeeded"
+	return nil
+}
+
+func captureCharge(b *Backend, obj, params object) *apiError {
+	if obj["captured"] == true {
+		err := invalidRequest("", "Charge %v has already been captured.", obj["id"])
+		err.Code = "charge_already_captured"
+		return err
+	}
+
+	if amount, ok := params["amount"]; ok {
+		obj["amount_refunded"] = toInt64(obj["amount"]) - toInt64(amount)
+	}
+	obj["captured"] = true
+	return nil
+}
+
+// defaultSource returns a customer's default source, or nil.
+func defaultSource(customer object) object {
+	if customer == nil {
+		return nil
+	}
+
+	sources, _ := customer["sources"].(object)
+	data, _ := sources["data"].([]interface{})
+	for _, source := range data {
+		if source := source.(object); source["id"] == customer["default_source"] {
+			return source
+		}
+	}
+	return nil
+}
+
+// createRefund refunds all of a charge's remaining amount by default, or
+// part of it.
+func createRefund(b *Backend, obj, params object) *apiError {
+	charge := b.find(resources["charges"], obj["charge"].(string))
+
+	remaining := toInt64(charge["amount"]) - toInt64(charge["amount_refunded"])
+	if charge["refunded"] == true || remaining <= 0 {
+		err := invalidRequest("charge", "Charge %v has already been refunded.", charge["id"])
+		err.Code = "charge_already_refunded"
+		return err
+	}
+
+	amount := remaining
+	if a, ok := params["amount"]; ok {
+		amount = toInt64(a)
+	}
+	if amount > remaining {
+		err := invalidRequest("amount", "Refund amount (%v) is greater than unrefunded amount "+
+			"on charge (%v)", amount, remaining)
+		err.Code = "amount_too_large"
+		return err
+	}
+
+	obj["amount"] = amount
+	obj["currency"] = charge["currency"]
+	obj["status"] = "succeeded"
+
+	refunded := toInt64(charge["amount_refunded"]) + amount
+	charge["amount_refunded"] = refunded
+	charge["refunded"] = refunded == toInt64(charge["amount"])
+
+	refunds := charge["refunds"].(object)
+	data, _ := refunds["data"].([]interface{})
+	refunds["data"] = append([]interface{}{obj}, data...)
+	refunds["total_count"] = len(data) + 1
+	return nil
+}
+
+// createPlan creates the plan's product when it's given inline.
+func createPlan(b *Backend, obj, params object) *apiError {
+	if product, ok := params["product"].(object); ok {
+		created, err := b.create(resources["products"], product)
+		if err != nil {
+			err.Param = "product[" + err.Param + "]"
+			return err
+		}
+		obj["product"] = created["id"]
+	}
+
+	if obj["interval_count"] == nil {
+		obj["interval_count"] = uint64(1)
+	}
+	obj["currency"] = strings.ToLower(fmt.Sprint(obj["currency"]))
+	return nil
+}
+
+func createProduct(b *Backend, obj, params object) *apiError {
+	if obj["type"] == nil {
+		obj["type"] = "good"
+	}
+	if obj["active"] == nil {
+		obj["active"] = true
+	}
+	obj["updated"] = obj["created"]
+	return nil
+}
+
+func createSKU(b *Backend, obj, params object) *apiError {
+	if obj["active"] == nil {
+		obj["active"] = true
+	}
+	obj["updated"] = obj["created"]
+	return nil
+}
+
+// createOrder prices the order's SKU items.
+func createOrder(b *Backend, obj, params object) *apiError {
+	items, _ := params["items"].([]interface{})
+
+	var total int64
+	orderItems := make([]interface{}, 0, len(items))
+	for i, item := range items {
+		item, ok := item.(object)
+		if !ok {
+			return invalidRequest(fmt.Sprintf("items[%v]", i), "Invalid hash")
+		}
+
+		parent, _ := item["parent"].(string)
+		sku := b.find(resources["skus"], parent)
+		if sku == nil {
+			return resourceMissing(fmt.Sprintf("items[%v][parent]", i), "sku", parent)
+		}
+
+		quantity := int64(1)
+		if q, ok := item["quantity"]; ok {
+			quantity = toInt64(q)
+		}
+
+		amount := toInt64(sku["price"]) * quantity
+		total += amount
+
+		orderItems = append(orderItems, object{
+			"object":      "order_item",
+			"amount":      amount,
+			"currency":    sku["currency"],
+			"description": sku["id"],
+			"parent":      sku["id"],
+			"quantity":    quantity,
+			"type":        "sku",
+		})
+	}
+
+	obj["items"] = orderItems
+	obj["amount"] = total
+	obj["currency"] = strings.ToLower(fmt.Sprint(obj["currency"]))
+	obj["status"] = "created"
+	obj["status_transitions"] = object{}
+	obj["updated"] = obj["created"]
+	return nil
+}
+
+// payOrder pays an order by charging the given source or the order's
+// customer.
+func payOrder(b *Backend, obj, params object) *apiError {
+	if obj["status"] != "created" {
+		return invalidRequest("", "You cannot pay an order with status %v.", obj["status"])
+	}
+
+	chargeParams := object{
+		"amount":   obj["amount"],
+		"currency": obj["currency"],
+	}
+	if source, ok := params["source"].(string); ok {
+		chargeParams["source"] = source
+	} else if customer, ok := obj["customer"].(string); ok {
+		chargeParams["customer"] = customer
+	}
+	if customer, ok := params["customer"].(string); ok {
+		chargeParams["customer"] = customer
+	}
+
+	charge, err := b.create(resources["charges"], chargeParams)
+	if err != nil {
+		return err
+	}
+
+	obj["charge"] = charge["id"]
+	obj["status"] = "paid"
+	obj["status_transitions"].(object)["paid"] = b.now().Unix()
+	obj["updated"] = b.now().Unix()
+	delete(params, "source")
+	return nil
+}
+
+func createPayout(b *Backend, obj, params object) *apiError {
+	obj["currency"] = strings.ToLower(fmt.Sprint(obj["currency"]))
+	obj["arrival_date"] = b.now().Add(2 * 24 * time.Hour).Unix()
+	obj["automatic"] = false
+	obj["method"] = "standard"
+	obj["source_type"] = "card"
+	obj["status"] = "pending"
+	obj["type"] = "bank_account"
+	return nil
+}
+
+func cancelPayout(b *Backend, obj, params object) *apiError {
+	if obj["status"] != "pending" {
+		return invalidRequest("", "Payout %v can't be canceled because it's %v.",
+			obj["id"], obj["status"])
+	}
+	obj["status"] = "canceled"
+	return nil
+}
+
+// createSub subscribes a customer to a plan, given either as plan or as the
+// first item's plan.
+func createSub(b *Backend, obj, params object) *apiError {
+	planID, _ := params["plan"].(string)
+	if items, ok := params["items"].([]interface{}); ok && len(items) > 0 {
+		if item, ok := items[0].(object); ok && planID == "" {
+			planID, _ = item["plan"].(string)
+		}
+	}
+	if planID == "" {
+		return invalidRequest("plan", "Missing required param: plan.")
+	}
+
+	now := b.now().Unix()
+	obj["start"] = now
+	obj["current_period_start"] = now
+	obj["status"] = "active"
+	obj["cancel_at_period_end"] = false
+	obj["items"] = newList("/v1/subscription_items?subscription=" + obj["id"].(string))
+	if obj["quantity"] == nil {
+		obj["quantity"] = uint64(1)
+	}
+
+	return b.setSubPlan(obj, planID)
+}
+
+func updateSub(b *Backend, obj, params object) *apiError {
+	planID, ok := params["plan"].(string)
+	if !ok {
+		return nil
+	}
+	delete(params, "plan")
+	return b.setSubPlan(obj, planID)
+}
+
+// setSubPlan changes the plan of a subscription and its item.
+func (b *Backend) setSubPlan(obj object, planID string) *apiError {
+	plan := b.find(resources["plans"], planID)
+	if plan == nil {
+		return resourceMissing("plan", "plan", planID)
+	}
+	plan = copyObject(plan)
+
+	start := time.Unix(toInt64(obj["current_period_start"]), 0)
+	count := int(toInt64(plan["interval_count"]))
+	var end time.Time
+	switch plan["interval"] {
+	case "day":
+		end = start.AddDate(0, 0, count)
+	case "week":
+		end = start.AddDate(0, 0, 7*count)
+	case "year":
+		end = start.AddDate(count, 0, 0)
+	default:
+		end = start.AddDate(0, count, 0)
+	}
+
+	obj["plan"] = plan
+	obj["current_period_end"] = end.Unix()
+	obj["items"].(object)["data"] = []interface{}{object{
+		"id":       b.newID("si"),
+		"object":   "subscription_item",
+		"created":  b.now().Unix(),
+		"metadata": object{},
+		"plan":     plan,
+		"quantity": obj["quantity"],
+	}}
+	obj["items"].(object)["total_count"] = 1
+	return nil
+}
+
+// cancelSub cancels a subscription immediately, or at the end of its period
+// with at_period_end.
+func cancelSub(b *Backend, obj, params object) (object, *apiError) {
+	if obj["status"] == "canceled" {
+		return nil, resourceMissing("id", "subscription", obj["id"].(string))
+	}
+
+	if params["at_period_end"] == "true" {
+		obj["cancel_at_period_end"] = true
+		return obj, nil
+	}
+
+	now := b.now().Unix()
+	obj["status"] = "canceled"
+	obj["canceled_at"] = now
+	obj["ended_at"] = now
+	return obj, nil
+}
+
+func createInvoice(b *Backend, obj, params object) *apiError {
+	now := b.now().Unix()
+	if obj["currency"] == nil {
+		obj["currency"] = "usd"
+	}
+	obj["amount_due"] = int64(0)
+	obj["attempt_count"] = uint64(0)
+	obj["attempted"] = false
+	obj["closed"] = false
+	obj["date"] = now
+	obj["lines"] = newList(fmt.Sprintf("/v1/invoices/%v/lines", obj["id"]))
+	obj["paid"] = false
+	obj["period_start"] = now
+	obj["period_end"] = now
+	obj["subtotal"] = int64(0)
+	obj["total"] = int64(0)
+	return nil
+}
+
+// payInvoice pays an invoice, charging its customer when there's an amount
+// due.
+func payInvoice(b *Backend, obj, params object) *apiError {
+	if obj["paid"] == true {
+		return invalidRequest("", "Invoice is already paid")
+	}
+
+	obj["attempted"] = true
+	obj["attempt_count"] = uint64(toInt64(obj["attempt_count"]) + 1)
+
+	if amount := toInt64(obj["amount_due"]); amount > 0 {
+		charge, err := b.create(resources["charges"], object{
+			"amount":   amount,
+			"currency": obj["currency"],
+			"customer": obj["customer"],
+			"invoice":  obj["id"],
+		})
+		if err != nil {
+			return err
+		}
+		obj["charge"] = charge["id"]
+	}
+
+	obj["paid"] = true
+	obj["closed"] = true
+	return nil
+}
+
+// newList returns an empty list object.
+func newList(url string) object {
+	return object{
+		"object":      "list",
+		"data":        []interface{}{},
+		"has_more":    false,
+		"total_count": 0,
+		"url":         url,
+	}
+}
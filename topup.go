@@ -28,26 +28,28 @@ type TopupListParams struct {
 }
 
 // TopupList is a list of top-ups as retrieved from a list endpoint.
-type TopupList struct {
-	ListMeta
-	Values []*Topup `json:"data"`
-}
+type TopupList = List[*Topup]
 
 // Topup is the resource representing a Stripe top-up.
 // For more details see https://stripe.com/docs/api#topups.
 type Topup struct {
-	Amount                   uint64         `json:"amount"`
-	ArrivalDate              int64          `json:"arrival_date"`
-	Created                  int64          `json:"created"`
-	Currency                 Currency       `json:"currency"`
-	Desc                     string         `json:"description"`
-	ExpectedAvailabilityDate int64          `json:"expected_availability_date"`
-	FailCode                 string         `json:"failure_code"`
-	FailMsg                  string         `json:"failure_message"`
-	ID                       string         `json:"id"`
-	Live                     bool           `json:"livemode"`
-	Source                   *PaymentSource `json:"source"`
-	Statement                string         `json:"statement_descriptor"`
-	Status                   string         `json:"status"`
-	Tx                       *Transaction   `json:"balance_transaction"`
+	Amount                   uint64                   `json:"amount"`
+	ArrivalDate              int64                    `json:"arrival_date"`
+	Created                  int64                    `json:"created"`
+	Currency                 Currency                 `json:"currency"`
+	Desc                     string                   `json:"description"`
+	ExpectedAvailabilityDate int64                    `json:"expected_availability_date"`
+	FailCode                 string                   `json:"failure_code"`
+	FailMsg                  string                   `json:"failure_message"`
+	ID                       string                   `json:"id"`
+	Live                     bool                     `json:"livemode"`
+	Source                   *PaymentSource           `json:"source"`
+	Statement                string                   `json:"statement_descriptor"`
+	Status                   string                   `json:"status"`
+	Tx                       *Expandable[Transaction] `json:"balance_transaction"`
+}
+
+// AmountMoney returns the amount of the top-up in its currency.
+func (t *Topup) AmountMoney() Money {
+	return moneyFromUint64(t.Amount, t.Currency)
 }
@@ -18,6 +18,10 @@ func New(params *stripe.TopupParams) (*stripe.Topup, error) {
 }
 
 func (c Client) New(params *stripe.TopupParams) (*stripe.Topup, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -34,6 +38,10 @@ func Get(id string, params *stripe.TopupParams) (*stripe.Topup, error) {
 }
 
 func (c Client) Get(id string, params *stripe.TopupParams) (*stripe.Topup, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -56,6 +64,10 @@ func Update(id string, params *stripe.TopupParams) (*stripe.Topup, error) {
 }
 
 func (c Client) Update(id string, params *stripe.TopupParams) (*stripe.Topup, error) {
+	if err := stripe.CheckParams(c.B, params, false); err != nil {
+		return nil, err
+	}
+
 	var body *form.Values
 	var commonParams *stripe.Params
 
@@ -80,25 +92,18 @@ func List(params *stripe.TopupListParams) *Iter {
 func (c Client) List(params *stripe.TopupListParams) *Iter {
 	var body *form.Values
 	var lp *stripe.ListParams
-	var p *stripe.Params
 
 	if params != nil {
 		body = &form.Values{}
 		form.AppendTo(body, params)
 		lp = &params.ListParams
-		p = params.ToParams()
 	}
 
-	return &Iter{stripe.GetIter(lp, body, func(b *form.Values) ([]interface{}, stripe.ListMeta, error) {
+	return &Iter{stripe.GetIter(lp, body, func(p *stripe.Params, b *form.Values) ([]*stripe.Topup, stripe.ListMeta, error) {
 		list := &stripe.TopupList{}
 		err := c.B.Call("GET", "/topups", c.Key, b, p, list)
 
-		ret := make([]interface{}, len(list.Values))
-		for i, v := range list.Values {
-			ret[i] = v
-		}
-
-		return ret, list.ListMeta, err
+		return list.Values, list.ListMeta, err
 	})}
 }
 
@@ -106,7 +111,13 @@ func (c Client) List(params *stripe.TopupListParams) *Iter {
 // The embedded Iter carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*stripe.Iter
+	*stripe.Iter[*stripe.Topup]
+}
+
+// Topup returns the most recent Topup
+// visited by a call to Next.
+func (i *Iter) Topup() *stripe.Topup {
+	return i.Current()
 }
 
 func getC() Client {
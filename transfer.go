@@ -18,9 +18,9 @@ type TransferDestination struct {
 // For more details see https://stripe.com/docs/api#create_transfer and https://stripe.com/docs/api#update_transfer.
 type TransferParams struct {
 	Params        `form:"*"`
-	Amount        int64              `form:"amount"`
-	Currency      Currency           `form:"currency"`
-	Dest          string             `form:"destination"`
+	Amount        int64              `form:"amount" validate:"required,min=0"`
+	Currency      Currency           `form:"currency" validate:"required"`
+	Dest          string             `form:"destination" validate:"required"`
 	SourceTx      string             `form:"source_transaction"`
 	SourceType    TransferSourceType `form:"source_type"`
 	TransferGroup string             `form:"transfer_group"`
@@ -40,43 +40,53 @@ type TransferListParams struct {
 // Transfer is the resource representing a Stripe transfer.
 // For more details see https://stripe.com/docs/api#transfers.
 type Transfer struct {
-	Amount         int64               `json:"amount"`
-	AmountReversed int64               `json:"amount_reversed"`
-	Created        int64               `json:"created"`
-	Currency       Currency            `json:"currency"`
-	Dest           TransferDestination `json:"destination"`
-	DestPayment    *Charge             `json:"destination_payment"`
-	ID             string              `json:"id"`
-	Live           bool                `json:"livemode"`
-	Meta           map[string]string   `json:"metadata"`
-	Reversals      *ReversalList       `json:"reversals"`
-	Reversed       bool                `json:"reversed"`
-	SourceTx       *TransactionSource  `json:"source_transaction"`
-	Statement      string              `json:"statement_descriptor"`
-	TransferGroup  string              `json:"transfer_group"`
-	Tx             *Transaction        `json:"balance_transaction"`
+	Amount         int64                    `json:"amount"`
+	AmountReversed int64                    `json:"amount_reversed"`
+	Created        int64                    `json:"created"`
+	Currency       Currency                 `json:"currency"`
+	Dest           TransferDestination      `json:"destination"`
+	DestPayment    *Expandable[Charge]      `json:"destination_payment"`
+	ID             string                   `json:"id"`
+	Live           bool                     `json:"livemode"`
+	Meta           map[string]string        `json:"metadata"`
+	Reversals      *ReversalList            `json:"reversals"`
+	Reversed       bool                     `json:"reversed"`
+	SourceTx       *TransactionSource       `json:"source_transaction"`
+	Statement      string                   `json:"statement_descriptor"`
+	TransferGroup  string                   `json:"transfer_group"`
+	Tx             *Expandable[Transaction] `json:"balance_transaction"`
 }
 
-// TransferList is a list of transfers as retrieved from a list endpoint.
-type TransferList struct {
-	ListMeta
-	Values []*Transfer `json:"data"`
+// AmountMoney returns the amount of the transfer in its currency.
+func (t *Transfer) AmountMoney() Money {
+	return Money{Amount: t.Amount, Currency: t.Currency}
+}
+
+// AmountReversedMoney returns the amount reversed from the transfer in its currency.
+func (t *Transfer) AmountReversedMoney() Money {
+	return Money{Amount: t.AmountReversed, Currency: t.Currency}
 }
 
+// TransferList is a list of transfers as retrieved from a list endpoint.
+type TransferList = List[*Transfer]
+
 // UnmarshalJSON handles deserialization of a Transfer.
 // This custom unmarshaling is needed because the resulting
 // property may be an id or the full struct if it was expanded.
 func (t *Transfer) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		t.ID = id
+		return nil
+	}
+
 	type transfer Transfer
 	var tb transfer
 	err := json.Unmarshal(data, &tb)
-	if err == nil {
-		*t = Transfer(tb)
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		t.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
+	*t = Transfer(tb)
 	return nil
 }
 
@@ -84,23 +94,21 @@ func (t *Transfer) UnmarshalJSON(data []byte) error {
 // This custom unmarshaling is needed because the specific
 // type of destination it refers to is specified in the JSON
 func (d *TransferDestination) UnmarshalJSON(data []byte) error {
+	if id, ok := parseID(data); ok {
+		d.ID = id
+		return nil
+	}
+
 	type dest TransferDestination
 	var dd dest
 	err := json.Unmarshal(data, &dd)
-	if err == nil {
-		*d = TransferDestination(dd)
-
-		err = json.Unmarshal(data, &d.Account)
-
-		if err != nil {
-			return err
-		}
-	} else {
-		// the id is surrounded by "\" characters, so strip them
-		d.ID = string(data[1 : len(data)-1])
+	if err != nil {
+		return err
 	}
 
-	return nil
+	*d = TransferDestination(dd)
+
+	return json.Unmarshal(data, &d.Account)
 }
 
 // MarshalJSON handles serialization of a TransferDestination.
@@ -15,7 +15,7 @@ func TestUsageRecordParams_AppendTo(t *testing.T) {
 		want   interface{}
 	}{
 		{"action", &UsageRecordParams{Action: "increment"}, "increment"},
-		{"quantity", &UsageRecordParams{Quantity: 2000}, strconv.FormatUint(2000, 10)},
+		{"quantity", &UsageRecordParams{Quantity: Uint64(2000)}, strconv.FormatUint(2000, 10)},
 		{"quantity", &UsageRecordParams{QuantityZero: true}, strconv.FormatUint(0, 10)},
 		{"timestamp", &UsageRecordParams{Timestamp: 123123123}, strconv.FormatUint(123123123, 10)},
 	}
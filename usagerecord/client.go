@@ -23,6 +23,10 @@ func New(params *stripe.UsageRecordParams) (*stripe.UsageRecord, error) {
 
 // New internal implementation to create a new usage record.
 func (c Client) New(params *stripe.UsageRecordParams) (*stripe.UsageRecord, error) {
+	if err := stripe.CheckParams(c.B, params, true); err != nil {
+		return nil, err
+	}
+
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
@@ -12,7 +12,7 @@ import (
 func TestUsageRecordNew(t *testing.T) {
 	now := uint64(time.Now().Unix())
 	usageRecord, err := New(&stripe.UsageRecordParams{
-		Quantity:         123,
+		Quantity:         stripe.Uint64(123),
 		Timestamp:        now,
 		Action:           stripe.UsageRecordParamsActionIncrement,
 		SubscriptionItem: "si_123",
@@ -0,0 +1,298 @@
+package stripe
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/stripe/stripe-go/form"
+)
+
+// Limits Stripe applies to the metadata of objects.
+const (
+	maxMetadataKeys        = 50
+	maxMetadataKeyLength   = 40
+	maxMetadataValueLength = 500
+)
+
+// validateTagName is the name of the struct tag holding the rules parameters
+// are validated against. Rules are separated by commas:
+//
+//	required      the parameter must be set when creating an object
+//	oneof=a b c   the parameter, if set, must be one of the listed values
+//	min=N         the parameter, if set, must be a number at least N
+//	max=N         the parameter, if set, must be a number at most N, or a
+//	              string at most N characters long
+const validateTagName = "validate"
+
+// ValidateParams enables validating parameters in all the resource clients
+// before requests are made, so that mistakes like a missing required
+// parameter fail without a round trip to Stripe. It can also be enabled for
+// the clients using a backend with BackendConfiguration.ValidateParams.
+//
+// It's disabled by default as Stripe may accept parameters the library
+// doesn't know about yet, like new values of an enumeration.
+var ValidateParams = false
+
+// Validator is the interface implemented by parameters with rules that can't
+// be expressed with validate tags, like fields that are mutually exclusive.
+// Validate is called by Validate after the tags of the parameters are
+// checked.
+type Validator interface {
+	Validate() error
+}
+
+// Validate checks params against the rules in their validate tags, the
+// limits on metadata, and their Validate methods. Required parameters are
+// only checked when creating is true, as they can be left out when updating
+// an object. Nil params are checked like empty ones when creating, so that
+// the parameters they're missing are reported.
+//
+// The error returned for invalid parameters is an *Error of type
+// invalid_request_error with Param set, like the one Stripe would respond
+// with, but without a request ID or HTTP status code.
+func Validate(params interface{}, creating bool) error {
+	v := reflect.ValueOf(params)
+	if creating && v.Kind() == reflect.Ptr && v.IsNil() {
+		v = reflect.New(v.Type().Elem())
+	}
+	return validateValue(v, nil, creating)
+}
+
+// ParamsValidator is implemented by backends for which parameters can be
+// validated before requests are made, like BackendConfiguration. Backends
+// wrapping another one should forward ParamsValidationEnabled to it so that
+// the setting isn't lost.
+type ParamsValidator interface {
+	ParamsValidationEnabled() bool
+}
+
+// CheckParams validates params with Validate if ValidateParams is enabled,
+// either for the package or for backend through ParamsValidator. It's called
+// by the resource clients before encoding parameters and making requests.
+func CheckParams(backend Backend, params interface{}, creating bool) error {
+	enabled := ValidateParams
+	if validator, ok := backend.(ParamsValidator); ok && validator.ParamsValidationEnabled() {
+		enabled = true
+	}
+
+	if !enabled {
+		return nil
+	}
+	return Validate(params, creating)
+}
+
+// newValidationError returns the error for an invalid parameter.
+func newValidationError(param, msg string) *Error {
+	stripeErr := &Error{
+		Type:  ErrorTypeInvalidRequest,
+		Param: param,
+		Msg:   msg,
+	}
+	stripeErr.Err = &InvalidRequestError{stripeErr: stripeErr}
+	return stripeErr
+}
+
+// paramName returns the name of a parameter in its parent's, like the form
+// package formats keys.
+func paramName(keyParts []string, name string) string {
+	if len(keyParts) == 0 {
+		return name
+	}
+
+	i := strings.IndexByte(name, '[')
+	if i < 0 {
+		return form.FormatKey(append(keyParts, name))
+	}
+	return form.FormatKey(append(keyParts, name[:i])) + name[i:]
+}
+
+func validateValue(v reflect.Value, keyParts []string, creating bool) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(v.Index(i), append(keyParts, strconv.Itoa(i)), creating); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		return validateStruct(v, keyParts, creating)
+	}
+
+	return nil
+}
+
+func validateStruct(v reflect.Value, keyParts []string, creating bool) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fieldV := v.Field(i)
+		formName := strings.Split(field.Tag.Get("form"), ",")[0]
+
+		// Like when encoding, the fields of a struct tagged with the
+		// wildcard are named at the same level as the current struct's.
+		if formName == "*" {
+			if err := validateValue(fieldV, keyParts, creating); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := formName
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+		param := paramName(keyParts, name)
+
+		if tag := field.Tag.Get(validateTagName); tag != "" {
+			if err := validateRules(fieldV, param, tag, creating); err != nil {
+				return err
+			}
+		}
+
+		if formName == "metadata" && fieldV.Kind() == reflect.Map {
+			if err := validateMetadata(fieldV, param); err != nil {
+				return err
+			}
+		}
+
+		// Fields which aren't encoded from their tags, like a context, are
+		// left alone.
+		if formName != "" && formName != "-" {
+			if err := validateValue(fieldV, append(keyParts, formName), creating); err != nil {
+				return err
+			}
+		}
+	}
+
+	if v.CanAddr() {
+		if validator, ok := v.Addr().Interface().(Validator); ok {
+			if err := validator.Validate(); err != nil {
+				// The error is copied before its parameter is prefixed as
+				// it may be a value shared between calls.
+				if stripeErr, ok := err.(*Error); ok && stripeErr.Param != "" {
+					prefixed := *stripeErr
+					prefixed.Param = paramName(keyParts, stripeErr.Param)
+					if _, ok := stripeErr.Err.(*InvalidRequestError); ok {
+						prefixed.Err = &InvalidRequestError{stripeErr: &prefixed}
+					}
+					return &prefixed
+				}
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRules(v reflect.Value, param, tag string, creating bool) error {
+	set := !v.IsZero()
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg := rule, ""
+		if i := strings.IndexByte(rule, '='); i >= 0 {
+			name, arg = rule[:i], rule[i+1:]
+		}
+
+		switch name {
+		case "required":
+			if creating && !set {
+				return newValidationError(param, fmt.Sprintf("Missing required param: %s.", param))
+			}
+
+		case "oneof":
+			if !set || v.Kind() != reflect.String {
+				continue
+			}
+			values := strings.Fields(arg)
+			valid := false
+			for _, value := range values {
+				valid = valid || v.String() == value
+			}
+			if !valid {
+				return newValidationError(param, fmt.Sprintf("Invalid %s: must be one of %s.",
+					param, strings.Join(values, ", ")))
+			}
+
+		case "min", "max":
+			if !set {
+				continue
+			}
+			limit, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				panic(fmt.Sprintf("Invalid validate tag %q on %s: %v", tag, param, err))
+			}
+
+			var n float64
+			unit := ""
+			switch v.Kind() {
+			case reflect.Float32, reflect.Float64:
+				n = v.Float()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				n = float64(v.Int())
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				n = float64(v.Uint())
+			case reflect.String:
+				n = float64(len(v.String()))
+				unit = " characters"
+			default:
+				continue
+			}
+
+			if name == "min" && n < limit {
+				return newValidationError(param, fmt.Sprintf("Invalid %s: must be at least %s%s.",
+					param, arg, unit))
+			}
+			if name == "max" && n > limit {
+				return newValidationError(param, fmt.Sprintf("Invalid %s: must be at most %s%s.",
+					param, arg, unit))
+			}
+
+		default:
+			panic(fmt.Sprintf("Don't know how to handle validate tag part: %s (tag: %s)", rule, tag))
+		}
+	}
+
+	return nil
+}
+
+// validateMetadata checks metadata against the limits Stripe applies to it.
+func validateMetadata(v reflect.Value, param string) error {
+	if v.Len() > maxMetadataKeys {
+		return newValidationError(param, fmt.Sprintf(
+			"Invalid %s: can have at most %d keys.", param, maxMetadataKeys))
+	}
+
+	for _, key := range v.MapKeys() {
+		keyParam := form.FormatKey([]string{param, key.String()})
+		if len(key.String()) > maxMetadataKeyLength {
+			return newValidationError(keyParam, fmt.Sprintf(
+				"Invalid %s: keys can be at most %d characters long.", keyParam, maxMetadataKeyLength))
+		}
+
+		value := v.MapIndex(key)
+		if value.Kind() == reflect.String && len(value.String()) > maxMetadataValueLength {
+			return newValidationError(keyParam, fmt.Sprintf(
+				"Invalid %s: values can be at most %d characters long.", keyParam, maxMetadataValueLength))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package stripe_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/charge"
+	"github.com/stripe/stripe-go/client"
+	"github.com/stripe/stripe-go/currency"
+)
+
+func assertInvalidParam(t *testing.T, err error, param string) {
+	assert.Error(t, err)
+
+	stripeErr := err.(*stripe.Error)
+	assert.Equal(t, stripe.ErrorTypeInvalidRequest, stripeErr.Type)
+	assert.Equal(t, param, stripeErr.Param)
+	assert.Equal(t, "", stripeErr.RequestID)
+
+	var invalidErr *stripe.InvalidRequestError
+	assert.True(t, errors.As(err, &invalidErr))
+}
+
+func TestValidate_Required(t *testing.T) {
+	params := &stripe.ChargeParams{Amount: 1000}
+	err := stripe.Validate(params, true)
+	assertInvalidParam(t, err, "currency")
+	assert.Equal(t, "Missing required param: currency.", err.(*stripe.Error).Msg)
+
+	// Required parameters can be left out when updating.
+	assert.NoError(t, stripe.Validate(params, false))
+
+	params.Currency = currency.USD
+	assert.NoError(t, stripe.Validate(params, true))
+
+	// Nil parameters are missing every required one when creating.
+	assertInvalidParam(t, stripe.Validate((*stripe.ChargeParams)(nil), true), "amount")
+	assert.NoError(t, stripe.Validate((*stripe.ChargeParams)(nil), false))
+}
+
+func TestValidate_Rules(t *testing.T) {
+	err := stripe.Validate(&stripe.TransferParams{Amount: -100, Currency: currency.USD, Dest: "acct_123"}, true)
+	assertInvalidParam(t, err, "amount")
+	assert.Equal(t, "Invalid amount: must be at least 0.", err.(*stripe.Error).Msg)
+
+	err = stripe.Validate(&stripe.PlanParams{Currency: currency.USD, Interval: "fortnight"}, true)
+	assertInvalidParam(t, err, "interval")
+	assert.Equal(t, "Invalid interval: must be one of day, week, month, year.", err.(*stripe.Error).Msg)
+
+	err = stripe.Validate(&stripe.UsageRecordParams{Action: "add"}, false)
+	assertInvalidParam(t, err, "action")
+
+	err = stripe.Validate(&stripe.SourceObjectParams{Flow: "email"}, false)
+	assertInvalidParam(t, err, "flow")
+
+	// Nested parameters are named like they're encoded.
+	err = stripe.Validate(&stripe.AccountParams{
+		PayoutSchedule: &stripe.PayoutScheduleParams{Interval: "yearly"},
+	}, false)
+	assertInvalidParam(t, err, "payout_schedule[interval]")
+
+	params := &stripe.ChargeParams{}
+	params.IdempotencyKey = strings.Repeat("a", 256)
+	err = stripe.Validate(params, false)
+	assertInvalidParam(t, err, "IdempotencyKey")
+	assert.Equal(t, "Invalid IdempotencyKey: must be at most 255 characters.", err.(*stripe.Error).Msg)
+}
+
+func TestValidate_Validator(t *testing.T) {
+	err := stripe.Validate(&stripe.SubParams{Card: &stripe.CardParams{Number: "4242424242424242"}, Token: "tok_visa"}, false)
+	assertInvalidParam(t, err, "card")
+
+	err = stripe.Validate(&stripe.PlanParams{
+		Product:   &stripe.PlanProductParams{Name: "Gold"},
+		ProductID: stripe.String("prod_123"),
+	}, false)
+	assertInvalidParam(t, err, "product")
+
+	assert.NoError(t, stripe.Validate(&stripe.SubParams{Token: "tok_visa"}, false))
+}
+
+// sharedErrParams has a Validator returning the same error value every time.
+type sharedErrParams struct {
+	Nested *sharedErrNestedParams `form:"nested"`
+}
+
+type sharedErrNestedParams struct{}
+
+var errSharedInvalid = &stripe.Error{Type: stripe.ErrorTypeInvalidRequest, Param: "value", Msg: "Invalid value."}
+
+func (p *sharedErrNestedParams) Validate() error {
+	return errSharedInvalid
+}
+
+func TestValidate_ValidatorSharedError(t *testing.T) {
+	params := &sharedErrParams{Nested: &sharedErrNestedParams{}}
+
+	// The parameter is prefixed in a copy, so it's the same every time.
+	for i := 0; i < 2; i++ {
+		err := stripe.Validate(params, false)
+		assert.Error(t, err)
+		assert.Equal(t, "nested[value]", err.(*stripe.Error).Param)
+	}
+	assert.Equal(t, "value", errSharedInvalid.Param)
+}
+
+func TestValidate_Metadata(t *testing.T) {
+	params := &stripe.CustomerParams{}
+	params.Meta = make(map[string]string)
+	for i := 0; i < 50; i++ {
+		params.Meta[strconv.Itoa(i)] = "v"
+	}
+	assert.NoError(t, stripe.Validate(params, true))
+
+	params.Meta["one_too_many"] = "v"
+	assertInvalidParam(t, stripe.Validate(params, true), "metadata")
+
+	params.Meta = map[string]string{strings.Repeat("k", 41): "v"}
+	err := stripe.Validate(params, true)
+	keyParam := "metadata[" + strings.Repeat("k", 41) + "]"
+	assertInvalidParam(t, err, keyParam)
+	assert.Equal(t, "Invalid "+keyParam+": keys can be at most 40 characters long.", err.(*stripe.Error).Msg)
+
+	params.Meta = map[string]string{"order_id": strings.Repeat("v", 501)}
+	assertInvalidParam(t, stripe.Validate(params, true), "metadata[order_id]")
+}
+
+func TestCheckParams(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"id":"ch_123"}`))
+	}))
+	defer ts.Close()
+
+	backend := &stripe.BackendConfiguration{Type: stripe.APIBackend, URL: ts.URL, HTTPClient: &http.Client{}}
+	c := charge.Client{B: backend, Key: "sk_test_123"}
+
+	// Parameters aren't validated unless it's enabled.
+	assert.NoError(t, stripe.CheckParams(backend, &stripe.ChargeParams{}, true))
+	_, err := c.New(&stripe.ChargeParams{Currency: currency.USD})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	stripe.ValidateParams = true
+	defer func() {
+		stripe.ValidateParams = false
+	}()
+
+	_, err = c.New(&stripe.ChargeParams{Currency: currency.USD})
+	assertInvalidParam(t, err, "amount")
+	assert.Equal(t, 1, requests)
+}
+
+func TestCheckParams_Client(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"id":"ch_123"}`))
+	}))
+	defer ts.Close()
+
+	// Validation can be enabled for a single client.
+	validating := client.New(&client.Options{Key: "sk_test_123", APIURL: ts.URL, ValidateParams: true})
+	_, err := validating.Charges.New(&stripe.ChargeParams{Currency: currency.USD})
+	assertInvalidParam(t, err, "amount")
+	assert.Equal(t, 0, requests)
+
+	other := client.New(&client.Options{Key: "sk_test_123", APIURL: ts.URL})
+	_, err = other.Charges.New(&stripe.ChargeParams{Currency: currency.USD})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+}
@@ -27,9 +27,9 @@ var (
 	ErrNoValidSignature error = errors.New("Webhook had no valid signature")
 )
 
-// Computes a webhook signature using Stripe's v1 signing method. See
-// https://stripe.com/docs/webhooks#signatures
-func computeSignature(t time.Time, payload []byte, secret string) []byte {
+// ComputeSignature computes a webhook signature using Stripe's v1 signing
+// method. See https://stripe.com/docs/webhooks#signatures
+func ComputeSignature(t time.Time, payload []byte, secret string) []byte {
 	mac := hmac.New(sha256.New, []byte(secret))
 	mac.Write([]byte(fmt.Sprintf("%d", t.Unix())))
 	mac.Write([]byte("."))
@@ -138,7 +138,7 @@ func constructEvent(payload []byte, sigHeader string, secret string, tolerance t
 		return e, err
 	}
 
-	expectedSignature := computeSignature(header.timestamp, payload, secret)
+	expectedSignature := ComputeSignature(header.timestamp, payload, secret)
 	expiredTimestamp := time.Since(header.timestamp) > tolerance
 	if enforceTolerance && expiredTimestamp {
 		return e, ErrTooOld
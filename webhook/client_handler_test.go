@@ -1,11 +1,13 @@
 package webhook_test
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 
+	"github.com/stripe/stripe-go"
 	"github.com/stripe/stripe-go/webhook"
 )
 
@@ -32,3 +34,16 @@ func Example() {
 	})
 	log.Fatal(http.ListenAndServe(":8080", nil))
 }
+
+func ExampleHandler() {
+	h := webhook.NewHandler("whsec_DaLRHCRs35vEXqOE8uTEAXGLGUOnyaFf")
+
+	// The callback receives the event's object already decoded
+	h.OnCharge("charge.succeeded", func(ctx context.Context, c *stripe.Charge) error {
+		fmt.Printf("Charge %v succeeded\n", c.ID)
+		return nil
+	})
+
+	http.Handle("/webhook", h)
+	log.Fatal(http.ListenAndServe(":8080", nil))
+}
@@ -13,131 +13,115 @@ var testPayload = []byte(`{
 }`)
 var testSecret = "whsec_test_secret"
 
-type SignedPayload struct {
-	timestamp time.Time
-	payload   []byte
-	secret    string
-	scheme    string
-	signature []byte
-	header    string
-}
-
-func newSignedPayload(options ...func(*SignedPayload)) *SignedPayload {
-	signedPayload := &SignedPayload{}
-	signedPayload.timestamp = time.Now()
-	signedPayload.payload = testPayload
-	signedPayload.secret = testSecret
-	signedPayload.scheme = "v1"
-
-	for _, opt := range options {
-		opt(signedPayload)
+func newSignedPayload(options ...func(*UnsignedPayload)) *SignedPayload {
+	unsignedPayload := &UnsignedPayload{
+		Payload:   testPayload,
+		Secrets:   []string{testSecret},
+		Timestamp: time.Now(),
 	}
 
-	if signedPayload.signature == nil {
-		signedPayload.signature = computeSignature(signedPayload.timestamp, signedPayload.payload, signedPayload.secret)
+	for _, opt := range options {
+		opt(unsignedPayload)
 	}
-	signedPayload.header = generateHeader(*signedPayload)
-	return signedPayload
-}
 
-func (p *SignedPayload) hexSignature() string {
-	return hex.EncodeToString(p.signature)
+	return GenerateTestSignedPayload(unsignedPayload)
 }
 
-func generateHeader(p SignedPayload) string {
-	return fmt.Sprintf("t=%d,%s=%s", p.timestamp.Unix(), p.scheme, hex.EncodeToString(p.signature))
+func hexSignature(p *SignedPayload) string {
+	return hex.EncodeToString(p.Signatures[0])
 }
 
 func TestTokenNew(t *testing.T) {
 	p := newSignedPayload()
 
-	evt, err := ConstructEvent(p.payload, p.header, p.secret)
+	evt, err := ConstructEvent(p.Payload, p.Header, testSecret)
 	if err != nil {
 		t.Errorf("Error validating signature: %v", err)
 	} else if evt.ID != "evt_test_webhook" {
 		t.Errorf("Expected a parsed event matching the test payload, got %v", evt)
 	}
 
-	p = newSignedPayload(func(p *SignedPayload) {
-		p.payload = append(p.payload, byte('['))
+	p = newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = append(p.Payload, byte('['))
 	})
-	evt, err = ConstructEvent(p.payload, p.header, p.secret)
+	evt, err = ConstructEvent(p.Payload, p.Header, testSecret)
 	if err == nil {
 		t.Errorf("Invalid JSON did not cause a parse error")
 	}
 
 	p = newSignedPayload()
-	evt, err = ConstructEvent(p.payload, "", p.secret)
+	evt, err = ConstructEvent(p.Payload, "", testSecret)
 	if err != ErrNotSigned {
 		t.Errorf("Expected ErrNotSigned from missing signature, got %v", err)
 	}
 
-	evt, err = ConstructEvent(p.payload, "v1,t=1", p.secret)
+	evt, err = ConstructEvent(p.Payload, "v1,t=1", testSecret)
 	if err != ErrInvalidHeader {
 		t.Errorf("Expected ErrInvalidHeader from bad header format, got %v", err)
 	}
 
-	evt, err = ConstructEvent(p.payload, "t=", p.secret)
+	evt, err = ConstructEvent(p.Payload, "t=", testSecret)
 	if err != ErrInvalidHeader {
 		t.Errorf("Expected ErrInvalidHeader from bad header format, got %v", err)
 	}
 
-	evt, err = ConstructEvent(p.payload, p.header+",v1=bad_signature", p.secret)
+	evt, err = ConstructEvent(p.Payload, p.Header+",v1=bad_signature", testSecret)
 	if err != nil {
 		t.Errorf("Received unexpected %v error with an unreadable signature in the header (should be ignored)", err)
 	}
 
-	p = newSignedPayload(func(p *SignedPayload) {
-		p.scheme = "v0"
+	p = newSignedPayload(func(p *UnsignedPayload) {
+		p.Secrets = nil
+		p.V0Secret = testSecret
 	})
-	evt, err = ConstructEvent(p.payload, p.header, p.secret)
+	evt, err = ConstructEvent(p.Payload, p.Header, testSecret)
 	if err != ErrNoValidSignature {
 		t.Errorf("Expected error from mismatched schema, got %v", err)
 	}
 
-	p = newSignedPayload(func(p *SignedPayload) {
-		p.signature = []byte("deadbeef")
-	})
-	evt, err = ConstructEvent(p.payload, p.header, p.secret)
+	p = newSignedPayload()
+	fakeHeader := fmt.Sprintf("t=%d,v1=%s", p.Timestamp.Unix(), hex.EncodeToString([]byte("deadbeef")))
+	evt, err = ConstructEvent(p.Payload, fakeHeader, testSecret)
 	if err != ErrNoValidSignature {
 		t.Errorf("Expected error from fake signature, got %v", err)
 	}
 
 	p = newSignedPayload()
-	p2 := newSignedPayload(func(p *SignedPayload) {
-		p.secret = testSecret + "_rolled_key"
+	rolledSecret := testSecret + "_rolled_key"
+	p2 := newSignedPayload(func(p *UnsignedPayload) {
+		p.Secrets = []string{rolledSecret}
 	})
-	headerWithRolledKey := p.header + ",v1=" + p2.hexSignature()
-	if p.hexSignature() == p2.hexSignature() {
+	headerWithRolledKey := p.Header + ",v1=" + hexSignature(p2)
+	if hexSignature(p) == hexSignature(p2) {
 		t.Errorf("Got the same signature with two different secret keys")
 	}
 
-	evt, err = ConstructEvent(p.payload, headerWithRolledKey, p.secret)
+	evt, err = ConstructEvent(p.Payload, headerWithRolledKey, testSecret)
 	if err != nil {
 		t.Errorf("Expected to be able to decode webhook with old key after rolling key, but got %v", err)
 	}
-	evt, err = ConstructEvent(p.payload, headerWithRolledKey, p2.secret)
+	evt, err = ConstructEvent(p.Payload, headerWithRolledKey, rolledSecret)
 	if err != nil {
 		t.Errorf("Expected to be able to decode webhook with new key after rolling key, but got %v", err)
 	}
 
-	p = newSignedPayload(func(p *SignedPayload) {
-		p.timestamp = time.Now().Add(-15 * time.Second)
+	p = newSignedPayload(func(p *UnsignedPayload) {
+		p.Timestamp = time.Now().Add(-15 * time.Second)
 	})
-	evt, err = ConstructEventWithTolerance(p.payload, p.header, p.secret, 10*time.Second)
+	evt, err = ConstructEventWithTolerance(p.Payload, p.Header, testSecret, 10*time.Second)
 	if err != ErrTooOld {
 		t.Errorf("Received %v error when validating timestamp outside of allowed timing window", err)
 	}
 
-	evt, err = ConstructEventWithTolerance(p.payload, p.header, p.secret, 20*time.Second)
+	evt, err = ConstructEventWithTolerance(p.Payload, p.Header, testSecret, 20*time.Second)
 	if err != nil {
 		t.Errorf("Received %v error when validating timestamp inside allowed timing window", err)
 	}
 
-	p = newSignedPayload(func(p *SignedPayload) {
-		p.timestamp = time.Unix(12345, 0)
+	p = newSignedPayload(func(p *UnsignedPayload) {
+		p.Timestamp = time.Unix(12345, 0)
 	})
-	evt, err = ConstructEventIgnoringTolerance(p.payload, p.header, p.secret)
+	evt, err = ConstructEventIgnoringTolerance(p.Payload, p.Header, testSecret)
 	if err != nil {
 		t.Errorf("Received %v error when timestamp outside window but no tolerance specified", err)
 	}
@@ -0,0 +1,343 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileStore is an EventStore keeping one small file per event in a local
+// directory. Claims are made by exclusively creating the event's file, so a
+// FileStore deduplicates events across every process sharing the directory
+// on the same machine, and its records survive restarts.
+//
+// Expired records are replaced when their event is claimed again, or removed
+// in bulk by Prune. Changes to existing records are made while holding a
+// lock file for the event, so that a process never overwrites or removes a
+// claim another process just made.
+type FileStore struct {
+	// Dir is the directory records are kept in.
+	Dir string
+
+	// TTL is how long processed events are remembered. Defaults to
+	// DefaultEventTTL.
+	TTL time.Duration
+
+	// ClaimTimeout is how long a claim lasts. Defaults to
+	// DefaultClaimTimeout.
+	ClaimTimeout time.Duration
+
+	// now returns the current time. It's only replaced in tests.
+	now func() time.Time
+}
+
+// staleLockAge is how old the lock file of an event has to be to be
+// considered abandoned, and lockRetryInterval how often a lock held by
+// another process is checked again.
+const (
+	staleLockAge      = 30 * time.Second
+	lockRetryInterval = 10 * time.Millisecond
+)
+
+// NewFileStore returns a FileStore keeping records in dir, which is created
+// if it doesn't exist, and remembering processed events for ttl.
+func NewFileStore(dir string, ttl time.Duration) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileStore{Dir: dir, TTL: ttl}, nil
+}
+
+// Claim implements EventStore.
+func (s *FileStore) Claim(ctx context.Context, eventID string) (string, error) {
+	path, err := s.path(eventID)
+	if err != nil {
+		return "", err
+	}
+
+	now := s.currentTime()
+	token, err := newClaimToken()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(&eventRecord{
+		ID:        eventID,
+		Status:    eventStatusInProgress,
+		ExpiresAt: now.Add(durationOrDefault(s.ClaimTimeout, DefaultClaimTimeout)),
+		Token:     token,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// A second attempt is made if the record is removed while it's being
+	// looked at.
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_, err = f.Write(data)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return "", err
+			}
+			return token, nil
+		}
+		if !os.IsExist(err) {
+			return "", err
+		}
+
+		r, err := s.read(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if err := r.claimError(now); err != nil {
+			return "", err
+		}
+
+		// The record has expired. It's replaced under the event's lock,
+		// after checking again that it's still expired, so that a claim
+		// made by another process in the meantime isn't overwritten.
+		replaced, err := s.withLock(ctx, eventID, func() (bool, error) {
+			r, err := s.read(path)
+			if os.IsNotExist(err) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			if err := r.claimError(now); err != nil {
+				return false, err
+			}
+			return true, s.replace(path, data)
+		})
+		if err != nil {
+			return "", err
+		}
+		if replaced {
+			return token, nil
+		}
+	}
+
+	return "", ErrEventInProgress
+}
+
+// MarkProcessed implements EventStore.
+func (s *FileStore) MarkProcessed(ctx context.Context, eventID, token string) error {
+	path, err := s.path(eventID)
+	if err != nil {
+		return err
+	}
+
+	now := s.currentTime()
+	data, err := json.Marshal(&eventRecord{
+		ID:        eventID,
+		Status:    eventStatusProcessed,
+		ExpiresAt: now.Add(durationOrDefault(s.TTL, DefaultEventTTL)),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.withLock(ctx, eventID, func() (bool, error) {
+		r, err := s.read(path)
+		if err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		if err == nil && !r.ownedBy(token, now) {
+			return false, ErrClaimLost
+		}
+		return true, s.replace(path, data)
+	})
+	return err
+}
+
+// MarkFailed implements EventStore.
+func (s *FileStore) MarkFailed(ctx context.Context, eventID, token string) error {
+	path, err := s.path(eventID)
+	if err != nil {
+		return err
+	}
+
+	now := s.currentTime()
+	_, err = s.withLock(ctx, eventID, func() (bool, error) {
+		r, err := s.read(path)
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if !r.ownedBy(token, now) {
+			return false, ErrClaimLost
+		}
+		if r.Status != eventStatusInProgress {
+			return false, nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		return true, nil
+	})
+	return err
+}
+
+// Prune removes all expired records and returns how many were removed.
+func (s *FileStore) Prune() (int, error) {
+	files, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.currentTime()
+	removed := 0
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(s.Dir, file.Name())
+		r, err := s.read(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return removed, err
+		}
+		if r.claimError(now) != nil {
+			continue
+		}
+
+		// The record is checked again under the event's lock, as it may
+		// have been claimed since it was read.
+		ok, err := s.withLock(context.Background(), file.Name(), func() (bool, error) {
+			r, err := s.read(path)
+			if os.IsNotExist(err) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			if r.claimError(now) != nil {
+				return false, nil
+			}
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return false, err
+			}
+			return true, nil
+		})
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// path returns the path of an event's record.
+func (s *FileStore) path(eventID string) (string, error) {
+	if eventID == "" || strings.HasPrefix(eventID, ".") || strings.ContainsAny(eventID, `/\`) {
+		return "", fmt.Errorf("Invalid event ID for a file store: %q", eventID)
+	}
+	return filepath.Join(s.Dir, eventID), nil
+}
+
+// withLock calls f while holding the lock of an event, which guards every
+// change to its existing record. Records are only created without the lock,
+// by exclusively creating their file.
+//
+// The lock is a file created exclusively next to the record. It's only held
+// for a few file operations, so a lock file older than staleLockAge was left
+// behind by a process that died while holding it and is removed.
+func (s *FileStore) withLock(ctx context.Context, eventID string, f func() (bool, error)) (bool, error) {
+	path := filepath.Join(s.Dir, ".lock-"+eventID)
+	for {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			file.Close()
+			break
+		}
+		if !os.IsExist(err) {
+			return false, err
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(path)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+	defer os.Remove(path)
+
+	return f()
+}
+
+// replace atomically replaces the record at path with data, by writing it to
+// a temporary file first, so that a claim never sees a partially written
+// record.
+func (s *FileStore) replace(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(s.Dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileStore) read(path string) (*eventRecord, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The file of a claim that's just being made may still be empty.
+	if len(data) == 0 {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		return &eventRecord{
+			Status:    eventStatusInProgress,
+			ExpiresAt: info.ModTime().Add(durationOrDefault(s.ClaimTimeout, DefaultClaimTimeout)),
+		}, nil
+	}
+
+	r := &eventRecord{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("Failed to parse event record %s: %s", path, err.Error())
+	}
+	return r, nil
+}
+
+func (s *FileStore) currentTime() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,314 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/stripe/stripe-go"
+)
+
+// DefaultMaxBodyBytes is the default limit on the size of a webhook request
+// body accepted by a Handler.
+const DefaultMaxBodyBytes int64 = 65536
+
+// EventFunc is a callback invoked by a Handler for a verified event.
+// Returning an error responds to Stripe with a 500 so that the event is
+// delivered again later.
+type EventFunc func(ctx context.Context, e *stripe.Event) error
+
+// Handler is an http.Handler that receives webhook events from Stripe. It
+// verifies the Stripe-Signature header of every request with its Verifier and
+// routes the event to the callback registered for its type. The secret that
+// matched is available to callbacks through SecretFromContext.
+//
+// Handler responds with:
+//
+//   - 405 if the request isn't a POST
+//   - 413 if the body is larger than MaxBodyBytes
+//   - 400 if the body can't be read, isn't an event, isn't signed correctly
+//     or has an API version other than the Verifier's APIVersion
+//   - 409 if the event is being processed by another request
+//   - 500 if the callback for the event returned an error
+//   - 200 otherwise, including for event types without a callback, events
+//     that were already processed, and events whose data object can't be
+//     decoded for their typed callback, which are logged instead as Stripe
+//     redelivering them wouldn't help
+//
+// The body of error responses is the status text only, so that the errors of
+// callbacks aren't sent back.
+//
+// Callbacks must be registered before the Handler starts serving requests.
+type Handler struct {
+	// MaxBodyBytes is the largest request body accepted. Defaults to
+	// DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+
+	// Verifier checks the signature of every request.
+	Verifier *Verifier
+
+	// Store optionally records which events were processed so that events
+	// redelivered by Stripe are only passed to a callback once.
+	Store EventStore
+
+	// Logger receives the handler's log records, like failures to record
+	// events in the Store. The package level stripe.Logger, filtered by
+	// stripe.LogLevel, is used when it's nil.
+	Logger stripe.StructuredLogger
+
+	mu        sync.RWMutex
+	callbacks map[string]EventFunc
+}
+
+// NewHandler returns a Handler verifying events with the given endpoint
+// signing secrets. Use NewHandlerWithVerifier for secrets that expire.
+func NewHandler(secrets ...string) *Handler {
+	return NewHandlerWithVerifier(NewVerifier(secrets...))
+}
+
+// NewHandlerWithVerifier returns a Handler verifying events with v.
+func NewHandlerWithVerifier(v *Verifier) *Handler {
+	return &Handler{
+		MaxBodyBytes: DefaultMaxBodyBytes,
+		Verifier:     v,
+		callbacks:    make(map[string]EventFunc),
+	}
+}
+
+type secretContextKey struct{}
+
+// SecretFromContext returns the secret that matched the signature of the
+// event being handled, given the context passed to a Handler's callback.
+func SecretFromContext(ctx context.Context) (Secret, bool) {
+	secret, ok := ctx.Value(secretContextKey{}).(Secret)
+	return secret, ok
+}
+
+// On registers fn to be called for events of the given type (e.g.
+// "charge.succeeded"), replacing any callback previously registered for it.
+func (h *Handler) On(eventType string, fn EventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.callbacks == nil {
+		h.callbacks = make(map[string]EventFunc)
+	}
+	h.callbacks[eventType] = fn
+}
+
+// OnAccount registers fn for an event type whose object is an Account.
+func (h *Handler) OnAccount(eventType string, fn func(context.Context, *stripe.Account) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnCharge registers fn for an event type whose object is a Charge.
+func (h *Handler) OnCharge(eventType string, fn func(context.Context, *stripe.Charge) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnCustomer registers fn for an event type whose object is a Customer.
+func (h *Handler) OnCustomer(eventType string, fn func(context.Context, *stripe.Customer) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnDispute registers fn for an event type whose object is a Dispute.
+func (h *Handler) OnDispute(eventType string, fn func(context.Context, *stripe.Dispute) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnInvoice registers fn for an event type whose object is an Invoice.
+func (h *Handler) OnInvoice(eventType string, fn func(context.Context, *stripe.Invoice) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnInvoiceItem registers fn for an event type whose object is an
+// InvoiceItem.
+func (h *Handler) OnInvoiceItem(eventType string, fn func(context.Context, *stripe.InvoiceItem) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnOrder registers fn for an event type whose object is an Order.
+func (h *Handler) OnOrder(eventType string, fn func(context.Context, *stripe.Order) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnPayout registers fn for an event type whose object is a Payout.
+func (h *Handler) OnPayout(eventType string, fn func(context.Context, *stripe.Payout) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnPlan registers fn for an event type whose object is a Plan.
+func (h *Handler) OnPlan(eventType string, fn func(context.Context, *stripe.Plan) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnProduct registers fn for an event type whose object is a Product.
+func (h *Handler) OnProduct(eventType string, fn func(context.Context, *stripe.Product) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnRefund registers fn for an event type whose object is a Refund.
+func (h *Handler) OnRefund(eventType string, fn func(context.Context, *stripe.Refund) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnSource registers fn for an event type whose object is a Source.
+func (h *Handler) OnSource(eventType string, fn func(context.Context, *stripe.Source) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnSub registers fn for an event type whose object is a Sub.
+func (h *Handler) OnSub(eventType string, fn func(context.Context, *stripe.Sub) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// OnTransfer registers fn for an event type whose object is a Transfer.
+func (h *Handler) OnTransfer(eventType string, fn func(context.Context, *stripe.Transfer) error) {
+	h.On(eventType, decodeObject(fn))
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Webhook requests must be POSTs", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := h.readBody(r)
+	if err == errBodyTooLarge {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	event, secret, err := h.Verifier.ConstructEvent(payload, r.Header.Get("Stripe-Signature"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.mu.RLock()
+	fn, ok := h.callbacks[event.Type]
+	h.mu.RUnlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), secretContextKey{}, secret)
+	status, err := h.dispatch(ctx, fn, &event)
+	if err != nil {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	w.WriteHeader(status)
+}
+
+// dispatch passes an event to its callback, consulting the Handler's store if
+// it has one. It returns the status to respond with.
+func (h *Handler) dispatch(ctx context.Context, fn EventFunc, event *stripe.Event) (int, error) {
+	if h.Store == nil {
+		if err := fn(ctx, event); err != nil {
+			return h.callbackErrorStatus(ctx, event, err), err
+		}
+		return http.StatusOK, nil
+	}
+
+	token, err := h.Store.Claim(ctx, event.ID)
+	switch err {
+	case nil:
+	case ErrEventProcessed:
+		return http.StatusOK, nil
+	case ErrEventInProgress:
+		return http.StatusConflict, err
+	default:
+		return http.StatusInternalServerError, err
+	}
+
+	if err := fn(ctx, event); err != nil {
+		if markErr := h.Store.MarkFailed(ctx, event.ID, token); markErr != nil {
+			stripe.LogTo(ctx, h.Logger, slog.LevelError, "Cannot mark event as failed",
+				"event_id", event.ID, "error", markErr)
+		}
+		return h.callbackErrorStatus(ctx, event, err), err
+	}
+
+	// The event was processed, so failing to record it shouldn't make Stripe
+	// deliver it again. At worst, it'll be processed twice.
+	if err := h.Store.MarkProcessed(ctx, event.ID, token); err != nil {
+		stripe.LogTo(ctx, h.Logger, slog.LevelError, "Cannot mark event as processed",
+			"event_id", event.ID, "error", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// callbackErrorStatus returns the status to respond with when the callback
+// for an event fails. It's 500 so that Stripe delivers the event again,
+// except when its data object can't be decoded, which would fail just the
+// same every time.
+func (h *Handler) callbackErrorStatus(ctx context.Context, event *stripe.Event, err error) int {
+	if !errors.Is(err, ErrUndecodableObject) {
+		return http.StatusInternalServerError
+	}
+
+	stripe.LogTo(ctx, h.Logger, slog.LevelError, "Cannot decode data object of event",
+		"event_id", event.ID, "type", event.Type, "error", err)
+	return http.StatusOK
+}
+
+// ErrUndecodableObject is the error returned, wrapped, by the typed callbacks
+// of a Handler, like those registered with OnCharge, when an event's data
+// object can't be decoded into their resource.
+var ErrUndecodableObject = errors.New("Event data object can't be decoded")
+
+var errBodyTooLarge = errors.New("Webhook body is too large")
+
+// readBody reads the request body, failing with errBodyTooLarge when it
+// exceeds MaxBodyBytes.
+func (h *Handler) readBody(r *http.Request) ([]byte, error) {
+	max := h.MaxBodyBytes
+	if max <= 0 {
+		max = DefaultMaxBodyBytes
+	}
+
+	// Read one extra byte so that a body of exactly max bytes is accepted.
+	payload, err := ioutil.ReadAll(io.LimitReader(r.Body, max+1))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read webhook body: %s", err.Error())
+	}
+	if int64(len(payload)) > max {
+		return nil, errBodyTooLarge
+	}
+
+	return payload, nil
+}
+
+// decodeObject adapts a callback taking a specific resource into an
+// EventFunc by decoding the event's data object into that resource.
+func decodeObject[T any](fn func(context.Context, *T) error) EventFunc {
+	return func(ctx context.Context, e *stripe.Event) error {
+		if e.Data == nil {
+			return fmt.Errorf("%w: event %s has no data object", ErrUndecodableObject, e.ID)
+		}
+
+		obj := new(T)
+		if err := json.Unmarshal(e.Data.Raw, obj); err != nil {
+			return fmt.Errorf("%w: event %s: %s", ErrUndecodableObject, e.ID, err.Error())
+		}
+
+		return fn(ctx, obj)
+	}
+}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go"
+)
+
+var testChargePayload = []byte(`{
+  "id": "evt_test_charge",
+  "object": "event",
+  "type": "charge.succeeded",
+  "data": {
+    "object": {
+      "id": "ch_123",
+      "object": "charge",
+      "amount": 100
+    }
+  }
+}`)
+
+func serveWebhook(h http.Handler, p *SignedPayload) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(p.Payload))
+	req.Header.Set("Stripe-Signature", p.Header)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandlerDispatchesTypedObject(t *testing.T) {
+	var got *stripe.Charge
+	h := NewHandler(testSecret)
+	h.OnCharge("charge.succeeded", func(ctx context.Context, c *stripe.Charge) error {
+		got = c
+		return nil
+	})
+	h.OnCharge("charge.failed", func(ctx context.Context, c *stripe.Charge) error {
+		t.Errorf("Callback for another event type was called")
+		return nil
+	})
+
+	w := serveWebhook(h, newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = testChargePayload
+	}))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %v", w.Code)
+	}
+	if got == nil || got.ID != "ch_123" || got.Amount != 100 {
+		t.Errorf("Expected the decoded charge to be passed to the callback, got %v", got)
+	}
+}
+
+func TestHandlerUnhandledType(t *testing.T) {
+	h := NewHandler(testSecret)
+
+	w := serveWebhook(h, newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = testChargePayload
+	}))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for an event without callback, got %v", w.Code)
+	}
+}
+
+func TestHandlerCallbackError(t *testing.T) {
+	h := NewHandler(testSecret)
+	h.On("charge.succeeded", func(ctx context.Context, e *stripe.Event) error {
+		return errors.New("database is down")
+	})
+
+	w := serveWebhook(h, newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = testChargePayload
+	}))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 when the callback fails, got %v", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "database") {
+		t.Errorf("Expected the callback's error not to be sent back, got %q", w.Body.String())
+	}
+}
+
+func TestHandlerUndecodableObject(t *testing.T) {
+	logger := &recordingLogger{}
+	called := false
+	h := NewHandler(testSecret)
+	h.Store = NewMemoryStore(0, time.Hour)
+	h.Logger = logger
+	h.OnCharge("charge.succeeded", func(ctx context.Context, c *stripe.Charge) error {
+		called = true
+		return nil
+	})
+
+	// Redelivering an event whose object can't be decoded wouldn't help, so
+	// it's acknowledged and logged.
+	w := serveWebhook(h, newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = []byte(`{"id": "evt_123", "type": "charge.succeeded", ` +
+			`"data": {"object": {"id": "ch_123", "amount": "a lot"}}}`)
+	}))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for an undecodable object, got %v", w.Code)
+	}
+	if called {
+		t.Errorf("Expected the callback not to be called")
+	}
+	if len(logger.messages) != 1 || !strings.Contains(logger.messages[0], "Cannot decode data object of event") {
+		t.Errorf("Expected the decoding error to be logged, got %q", logger.messages)
+	}
+}
+
+func TestHandlerBadSignature(t *testing.T) {
+	called := false
+	h := NewHandler(testSecret)
+	h.On("charge.succeeded", func(ctx context.Context, e *stripe.Event) error {
+		called = true
+		return nil
+	})
+
+	w := serveWebhook(h, newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = testChargePayload
+		p.Secrets = []string{"whsec_other_secret"}
+	}))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a bad signature, got %v", w.Code)
+	}
+	if called {
+		t.Errorf("Callback was called for an event with a bad signature")
+	}
+}
+
+func TestHandlerReportsMatchedSecret(t *testing.T) {
+	var got Secret
+	h := NewHandlerWithVerifier(&Verifier{Secrets: []Secret{
+		{Name: "endpoint_a", Value: "whsec_endpoint_a"},
+		{Name: "endpoint_b", Value: testSecret},
+	}})
+	h.On("charge.succeeded", func(ctx context.Context, e *stripe.Event) error {
+		got, _ = SecretFromContext(ctx)
+		return nil
+	})
+
+	w := serveWebhook(h, newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = testChargePayload
+	}))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %v", w.Code)
+	}
+	if got.Name != "endpoint_b" {
+		t.Errorf("Expected the matched secret in the callback's context, got %v", got)
+	}
+}
+
+func TestHandlerBodyTooLarge(t *testing.T) {
+	h := NewHandler(testSecret)
+	h.MaxBodyBytes = 10
+
+	w := serveWebhook(h, newSignedPayload())
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for a large body, got %v", w.Code)
+	}
+}
+
+func TestHandlerMethodNotAllowed(t *testing.T) {
+	h := NewHandler(testSecret)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/webhook", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405 for a GET, got %v", w.Code)
+	}
+}
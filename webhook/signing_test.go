@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go"
+)
+
+func TestGenerateTestSignedPayload(t *testing.T) {
+	timestamp := time.Unix(1495999758, 0)
+	p := GenerateTestSignedPayload(&UnsignedPayload{
+		Payload:   testPayload,
+		Secrets:   []string{testSecret, "whsec_rolled"},
+		V0Secret:  "whsec_test_mode",
+		Timestamp: timestamp,
+	})
+
+	expected := fmt.Sprintf("t=1495999758,v1=%s,v1=%s,v0=%s",
+		hex.EncodeToString(ComputeSignature(timestamp, testPayload, testSecret)),
+		hex.EncodeToString(ComputeSignature(timestamp, testPayload, "whsec_rolled")),
+		hex.EncodeToString(ComputeSignature(timestamp, testPayload, "whsec_test_mode")))
+	if p.Header != expected {
+		t.Errorf("Expected header %v, got %v", expected, p.Header)
+	}
+	if len(p.Signatures) != 2 {
+		t.Errorf("Expected two v1 signatures, got %v", len(p.Signatures))
+	}
+
+	for _, secret := range []string{testSecret, "whsec_rolled"} {
+		_, err := ConstructEventIgnoringTolerance(p.Payload, p.Header, secret)
+		if err != nil {
+			t.Errorf("Expected the payload to be verified with %v, got %v", secret, err)
+		}
+	}
+
+	// v0 signatures are never accepted.
+	_, err := ConstructEventIgnoringTolerance(p.Payload, p.Header, "whsec_test_mode")
+	if err != ErrNoValidSignature {
+		t.Errorf("Expected ErrNoValidSignature for the v0 secret, got %v", err)
+	}
+}
+
+func TestGenerateTestSignedEvent(t *testing.T) {
+	e := &stripe.Event{
+		ID:   "evt_123",
+		Type: "charge.succeeded",
+		Data: &stripe.EventData{Raw: []byte(`{"id":"ch_123","object":"charge"}`)},
+	}
+
+	p, err := GenerateTestSignedEvent(e, &UnsignedPayload{Secrets: []string{testSecret}})
+	if err != nil {
+		t.Fatalf("Error signing event: %v", err)
+	}
+
+	evt, err := ConstructEvent(p.Payload, p.Header, testSecret)
+	if err != nil {
+		t.Errorf("Error validating signature: %v", err)
+	}
+	if evt.ID != "evt_123" || evt.GetObjValue("id") != "ch_123" {
+		t.Errorf("Expected the event to round trip, got %v", evt)
+	}
+}
+
+func TestSignPayload(t *testing.T) {
+	header := SignPayload(testPayload, testSecret)
+
+	evt, err := ConstructEvent(testPayload, header, testSecret)
+	if err != nil {
+		t.Errorf("Error validating signature: %v", err)
+	} else if evt.ID != "evt_test_webhook" {
+		t.Errorf("Expected a parsed event matching the test payload, got %v", evt)
+	}
+}
@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultEventTTL is how long an EventStore remembers that an event was
+	// processed by default. Stripe stops redelivering an event after three
+	// days.
+	DefaultEventTTL = 72 * time.Hour
+
+	// DefaultClaimTimeout is how long an EventStore considers an event to
+	// be in progress by default. A claim that's neither marked processed nor
+	// failed within that time, for example because the process handling it
+	// crashed, is released so that a redelivery can claim the event again.
+	DefaultClaimTimeout = 5 * time.Minute
+)
+
+var (
+	ErrEventProcessed  error = errors.New("Event has already been processed")
+	ErrEventInProgress error = errors.New("Event is already being processed")
+
+	// ErrClaimLost is returned when marking an event processed or failed
+	// after the claim on it expired and another one was made.
+	ErrClaimLost error = errors.New("Claim on event expired and was taken over")
+)
+
+// EventStore records which events have been processed so that an event
+// Stripe delivers more than once is only processed once. Events are
+// identified by their ID.
+//
+// A Handler with a store claims every event before calling its callback,
+// and marks it processed or failed depending on the callback's result.
+//
+// Claim returns a token identifying the claim, which is passed back to
+// MarkProcessed and MarkFailed so that they only change the record of an
+// event if it's still the caller's claim. Otherwise, if the claim expired
+// and the event was claimed again, they return ErrClaimLost.
+type EventStore interface {
+	// Claim marks an event as being processed. It returns
+	// ErrEventProcessed if the event has already been processed, or
+	// ErrEventInProgress if it's currently claimed.
+	Claim(ctx context.Context, eventID string) (token string, err error)
+
+	// MarkProcessed records that a claimed event was processed
+	// successfully. Claiming it again fails until its record expires.
+	MarkProcessed(ctx context.Context, eventID, token string) error
+
+	// MarkFailed releases the claim on an event that couldn't be processed
+	// so that it's processed again when Stripe redelivers it.
+	MarkFailed(ctx context.Context, eventID, token string) error
+}
+
+// eventStatus is the state of an event in a store.
+type eventStatus string
+
+const (
+	eventStatusInProgress eventStatus = "in_progress"
+	eventStatusProcessed  eventStatus = "processed"
+)
+
+// eventRecord is the state of an event in a store along with when that state
+// stops applying.
+type eventRecord struct {
+	ID        string      `json:"id"`
+	Status    eventStatus `json:"status"`
+	ExpiresAt time.Time   `json:"expires_at"`
+
+	// Token identifies the claim of an event in progress.
+	Token string `json:"token,omitempty"`
+}
+
+// claimError returns the error Claim should return for an existing record, or
+// nil if the record has expired and the event can be claimed.
+func (r *eventRecord) claimError(now time.Time) error {
+	if !now.Before(r.ExpiresAt) {
+		return nil
+	}
+	if r.Status == eventStatusProcessed {
+		return ErrEventProcessed
+	}
+	return ErrEventInProgress
+}
+
+// ownedBy returns whether the record doesn't belong to a claim other than the
+// one identified by token which is still in progress, in which case it may be
+// changed by the holder of token.
+func (r *eventRecord) ownedBy(token string, now time.Time) bool {
+	return r.Status != eventStatusInProgress || r.Token == token || !now.Before(r.ExpiresAt)
+}
+
+// newClaimToken returns a random token identifying a claim.
+func newClaimToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// MemoryStore is an EventStore keeping records in memory. Once it holds
+// Capacity records, the least recently used ones are evicted. It's safe for
+// concurrent use, but only deduplicates events within a single process.
+type MemoryStore struct {
+	// Capacity is the maximum number of records kept. Zero means no limit.
+	Capacity int
+
+	// TTL is how long processed events are remembered. Defaults to
+	// DefaultEventTTL.
+	TTL time.Duration
+
+	// ClaimTimeout is how long a claim lasts. Defaults to
+	// DefaultClaimTimeout.
+	ClaimTimeout time.Duration
+
+	mu      sync.Mutex
+	lru     *list.List
+	records map[string]*list.Element
+
+	// now returns the current time. It's only replaced in tests.
+	now func() time.Time
+}
+
+// NewMemoryStore returns a MemoryStore holding up to capacity records which
+// remembers processed events for ttl.
+func NewMemoryStore(capacity int, ttl time.Duration) *MemoryStore {
+	return &MemoryStore{Capacity: capacity, TTL: ttl}
+}
+
+// Claim implements EventStore.
+func (s *MemoryStore) Claim(ctx context.Context, eventID string) (string, error) {
+	token, err := newClaimToken()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.currentTime()
+	if elem, ok := s.records[eventID]; ok {
+		if err := elem.Value.(*eventRecord).claimError(now); err != nil {
+			s.lru.MoveToFront(elem)
+			return "", err
+		}
+	}
+
+	s.set(&eventRecord{
+		ID:        eventID,
+		Status:    eventStatusInProgress,
+		ExpiresAt: now.Add(durationOrDefault(s.ClaimTimeout, DefaultClaimTimeout)),
+		Token:     token,
+	})
+	return token, nil
+}
+
+// MarkProcessed implements EventStore.
+func (s *MemoryStore) MarkProcessed(ctx context.Context, eventID, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.currentTime()
+	if elem, ok := s.records[eventID]; ok && !elem.Value.(*eventRecord).ownedBy(token, now) {
+		return ErrClaimLost
+	}
+
+	s.set(&eventRecord{
+		ID:        eventID,
+		Status:    eventStatusProcessed,
+		ExpiresAt: now.Add(durationOrDefault(s.TTL, DefaultEventTTL)),
+	})
+	return nil
+}
+
+// MarkFailed implements EventStore.
+func (s *MemoryStore) MarkFailed(ctx context.Context, eventID, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.records[eventID]
+	if !ok {
+		return nil
+	}
+
+	r := elem.Value.(*eventRecord)
+	if !r.ownedBy(token, s.currentTime()) {
+		return ErrClaimLost
+	}
+	if r.Status == eventStatusInProgress {
+		s.lru.Remove(elem)
+		delete(s.records, eventID)
+	}
+	return nil
+}
+
+// Len returns the number of records currently held, including expired ones
+// that haven't been evicted yet.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.records)
+}
+
+// set stores a record as the most recently used one, evicting the least
+// recently used records if the store is over capacity. It must be called
+// with the lock held.
+func (s *MemoryStore) set(r *eventRecord) {
+	if s.records == nil {
+		s.lru = list.New()
+		s.records = make(map[string]*list.Element)
+	}
+
+	if elem, ok := s.records[r.ID]; ok {
+		elem.Value = r
+		s.lru.MoveToFront(elem)
+		return
+	}
+
+	s.records[r.ID] = s.lru.PushFront(r)
+
+	for s.Capacity > 0 && s.lru.Len() > s.Capacity {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.records, oldest.Value.(*eventRecord).ID)
+	}
+}
+
+func (s *MemoryStore) currentTime() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+func durationOrDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
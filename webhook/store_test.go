@@ -0,0 +1,294 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go"
+)
+
+// testEventStore exercises the behavior shared by all EventStore
+// implementations. advance moves the store's clock forward.
+func testEventStore(t *testing.T, s EventStore, advance func(time.Duration)) {
+	ctx := context.Background()
+
+	token, err := s.Claim(ctx, "evt_1")
+	if err != nil {
+		t.Fatalf("Expected to claim a new event, got %v", err)
+	}
+	if _, err := s.Claim(ctx, "evt_1"); err != ErrEventInProgress {
+		t.Errorf("Expected ErrEventInProgress for a claimed event, got %v", err)
+	}
+
+	// A failed event can be claimed again.
+	if err := s.MarkFailed(ctx, "evt_1", token); err != nil {
+		t.Fatalf("Error marking event failed: %v", err)
+	}
+	token, err = s.Claim(ctx, "evt_1")
+	if err != nil {
+		t.Errorf("Expected to claim a failed event again, got %v", err)
+	}
+
+	if err := s.MarkProcessed(ctx, "evt_1", token); err != nil {
+		t.Fatalf("Error marking event processed: %v", err)
+	}
+	if _, err := s.Claim(ctx, "evt_1"); err != ErrEventProcessed {
+		t.Errorf("Expected ErrEventProcessed for a processed event, got %v", err)
+	}
+
+	// A claim that's never completed is released after the claim timeout.
+	expired, err := s.Claim(ctx, "evt_2")
+	if err != nil {
+		t.Fatalf("Expected to claim a new event, got %v", err)
+	}
+	advance(DefaultClaimTimeout)
+	token, err = s.Claim(ctx, "evt_2")
+	if err != nil {
+		t.Errorf("Expected to claim an event with an expired claim, got %v", err)
+	}
+
+	// The holder of the expired claim can't release or complete the new
+	// one.
+	if err := s.MarkFailed(ctx, "evt_2", expired); err != ErrClaimLost {
+		t.Errorf("Expected ErrClaimLost for an expired claim, got %v", err)
+	}
+	if _, err := s.Claim(ctx, "evt_2"); err != ErrEventInProgress {
+		t.Errorf("Expected the new claim to be kept, got %v", err)
+	}
+	if err := s.MarkProcessed(ctx, "evt_2", expired); err != ErrClaimLost {
+		t.Errorf("Expected ErrClaimLost for an expired claim, got %v", err)
+	}
+	if err := s.MarkProcessed(ctx, "evt_2", token); err != nil {
+		t.Errorf("Error marking event processed: %v", err)
+	}
+
+	// Processed events are forgotten after the TTL.
+	advance(time.Hour)
+	if _, err := s.Claim(ctx, "evt_1"); err != nil {
+		t.Errorf("Expected to claim an event after its TTL, got %v", err)
+	}
+}
+
+// testTakeover checks that when stores race to take over an expired claim,
+// only one of them gets it.
+func testTakeover(t *testing.T, first EventStore, store func() EventStore, advance func(time.Duration)) {
+	ctx := context.Background()
+	if _, err := first.Claim(ctx, "evt_1"); err != nil {
+		t.Fatal(err)
+	}
+	advance(DefaultClaimTimeout)
+
+	const racers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, racers)
+	for i := 0; i < racers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store().Claim(ctx, "evt_1")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	claimed := 0
+	for err := range errs {
+		if err == nil {
+			claimed++
+		} else if err != ErrEventInProgress {
+			t.Errorf("Expected ErrEventInProgress, got %v", err)
+		}
+	}
+	if claimed != 1 {
+		t.Errorf("Expected exactly one claim to take over the expired one, got %v", claimed)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Now()
+	s := NewMemoryStore(0, time.Hour)
+	s.now = func() time.Time { return now }
+
+	testEventStore(t, s, func(d time.Duration) { now = now.Add(d) })
+}
+
+func TestMemoryStoreTakeover(t *testing.T) {
+	now := time.Now()
+	s := NewMemoryStore(0, time.Hour)
+	s.now = func() time.Time { return now }
+
+	testTakeover(t, s, func() EventStore { return s }, func(d time.Duration) { now = now.Add(d) })
+}
+
+func TestMemoryStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(2, time.Hour)
+
+	for _, id := range []string{"evt_1", "evt_2"} {
+		token, _ := s.Claim(ctx, id)
+		s.MarkProcessed(ctx, id, token)
+	}
+
+	// Touch evt_1 so that evt_2 is the least recently used record.
+	if _, err := s.Claim(ctx, "evt_1"); err != ErrEventProcessed {
+		t.Errorf("Expected ErrEventProcessed, got %v", err)
+	}
+
+	s.Claim(ctx, "evt_3")
+	if s.Len() != 2 {
+		t.Errorf("Expected 2 records, got %v", s.Len())
+	}
+	if _, err := s.Claim(ctx, "evt_2"); err != nil {
+		t.Errorf("Expected evt_2 to have been evicted, got %v", err)
+	}
+	if _, err := s.Claim(ctx, "evt_3"); err != ErrEventInProgress {
+		t.Errorf("Expected evt_3 to still be claimed, got %v", err)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stripe-webhook-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	s, err := NewFileStore(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return now }
+
+	testEventStore(t, s, func(d time.Duration) { now = now.Add(d) })
+
+	// Records survive across stores sharing a directory.
+	s2 := &FileStore{Dir: dir, now: s.now}
+	if _, err := s2.Claim(context.Background(), "evt_1"); err != ErrEventInProgress {
+		t.Errorf("Expected another store to see the claim, got %v", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	removed, err := s.Prune()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 expired records to be pruned, got %v", removed)
+	}
+
+	if _, err := s.Claim(context.Background(), "../evt_1"); err == nil {
+		t.Errorf("Expected an error for an event ID that's not a file name")
+	}
+}
+
+func TestFileStoreTakeover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stripe-webhook-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	// Every racer has its own store, like separate processes would.
+	testTakeover(t, &FileStore{Dir: dir, now: clock}, func() EventStore {
+		return &FileStore{Dir: dir, now: clock}
+	}, func(d time.Duration) { now = now.Add(d) })
+}
+
+func TestHandlerWithStore(t *testing.T) {
+	calls := 0
+	fail := true
+	h := NewHandler(testSecret)
+	h.Store = NewMemoryStore(0, time.Hour)
+	h.On("charge.succeeded", func(ctx context.Context, e *stripe.Event) error {
+		calls++
+		if fail {
+			return errors.New("database is down")
+		}
+		return nil
+	})
+
+	p := newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = testChargePayload
+	})
+
+	if w := serveWebhook(h, p); w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 when the callback fails, got %v", w.Code)
+	}
+
+	// The failed event is processed again when it's redelivered.
+	fail = false
+	if w := serveWebhook(h, p); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %v", w.Code)
+	}
+	if w := serveWebhook(h, p); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for a duplicate event, got %v", w.Code)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the callback to be called twice, got %v", calls)
+	}
+
+	h.Store.Claim(context.Background(), "evt_in_progress")
+	p = newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = []byte(`{"id": "evt_in_progress", "type": "charge.succeeded"}`)
+	})
+	if w := serveWebhook(h, p); w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for an event in progress, got %v", w.Code)
+	}
+}
+
+// failingStore is a MemoryStore that fails to mark events processed.
+type failingStore struct {
+	*MemoryStore
+}
+
+func (s failingStore) MarkProcessed(ctx context.Context, eventID, token string) error {
+	return errors.New("disk is full")
+}
+
+// recordingLogger is a stripe.StructuredLogger keeping the messages it logs.
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Enabled(ctx context.Context, level slog.Level) bool {
+	return true
+}
+
+func (l *recordingLogger) Log(ctx context.Context, level slog.Level, msg string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf("%v %s %v", level, msg, args))
+}
+
+func TestHandlerLogsStoreErrors(t *testing.T) {
+	logger := &recordingLogger{}
+	h := NewHandler(testSecret)
+	h.Store = failingStore{NewMemoryStore(0, time.Hour)}
+	h.Logger = logger
+	h.On("charge.succeeded", func(ctx context.Context, e *stripe.Event) error {
+		return nil
+	})
+
+	p := newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = testChargePayload
+	})
+	if w := serveWebhook(h, p); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 when the event can't be recorded, got %v", w.Code)
+	}
+
+	expected := "ERROR Cannot mark event as processed [event_id evt_test_charge error disk is full]"
+	if len(logger.messages) != 1 || logger.messages[0] != expected {
+		t.Errorf("Expected %q to be logged, got %q", expected, logger.messages)
+	}
+}
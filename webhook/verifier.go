@@ -0,0 +1,166 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stripe/stripe-go"
+)
+
+// These errors are returned by a Verifier in addition to ErrNotSigned and
+// ErrInvalidHeader. They're more specific versions of the errors returned
+// by ConstructEvent, so errors.Is(ErrNoSecretMatched, ErrNoValidSignature)
+// and errors.Is(ErrTimestampOutsideTolerance, ErrTooOld) both hold.
+var (
+	ErrNoV1Signature             error = &verifyError{"Webhook has no v1 signature in its Stripe-Signature header", ErrNoValidSignature}
+	ErrTimestampOutsideTolerance error = &verifyError{"Timestamp wasn't within tolerance of the current time", ErrTooOld}
+	ErrNoSecretMatched           error = &verifyError{"Webhook wasn't signed by any of the given secrets", ErrNoValidSignature}
+)
+
+// ErrAPIVersionMismatch is returned by a Verifier with an APIVersion for
+// events rendered with another version of the Stripe API. The error returned
+// wraps it with the versions involved.
+var ErrAPIVersionMismatch = errors.New("Event has an unexpected API version")
+
+// verifyError is a sentinel error that's also a more specific version of
+// another sentinel.
+type verifyError struct {
+	msg  string
+	base error
+}
+
+func (e *verifyError) Error() string {
+	return e.msg
+}
+
+func (e *verifyError) Unwrap() error {
+	return e.base
+}
+
+// Secret is an endpoint signing secret accepted by a Verifier.
+type Secret struct {
+	// Name optionally identifies the secret, for example with the endpoint
+	// it belongs to, so that callers can tell which secret matched.
+	Name string
+
+	// Value is the signing secret itself, as shown in the Stripe dashboard.
+	Value string
+
+	// ExpiresAt is when the secret stops being accepted, which is useful
+	// while an endpoint's secret is being rolled. The zero value means it
+	// never expires.
+	ExpiresAt time.Time
+}
+
+// expired returns whether the secret isn't accepted anymore at now.
+func (s Secret) expired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && !now.Before(s.ExpiresAt)
+}
+
+// Verifier checks Stripe-Signature headers against a set of secrets. While
+// an endpoint's secret is being rolled, Stripe signs events with both the old
+// and the new secret, and a service may receive events for several
+// endpoints, so a signature matching any of the secrets is accepted.
+type Verifier struct {
+	// Secrets are the secrets signatures are checked against.
+	Secrets []Secret
+
+	// Tolerance is how far a signature's timestamp may be from the current
+	// time, in either direction. Defaults to DefaultTolerance.
+	Tolerance time.Duration
+
+	// IgnoreTolerance disables checking the signature's timestamp.
+	IgnoreTolerance bool
+
+	// APIVersion, when set, is the only version of the Stripe API that
+	// constructed events may have been rendered with. Setting it to
+	// stripe.APIVersion refuses events that the library's structs may not
+	// decode correctly, which happens when the endpoint's version differs.
+	APIVersion string
+
+	// now returns the current time. It's only replaced in tests.
+	now func() time.Time
+}
+
+// NewVerifier returns a Verifier accepting any of the given secrets, none of
+// which expire.
+func NewVerifier(secrets ...string) *Verifier {
+	v := &Verifier{}
+	for _, s := range secrets {
+		v.Secrets = append(v.Secrets, Secret{Value: s})
+	}
+	return v
+}
+
+// Verify checks that payload was signed by one of the verifier's secrets
+// according to the Stripe-Signature header and returns the secret that
+// matched.
+func (v *Verifier) Verify(payload []byte, header string) (Secret, error) {
+	sh, err := parseSignatureHeader(header)
+	if err == ErrNoValidSignature {
+		return Secret{}, ErrNoV1Signature
+	}
+	if err != nil {
+		return Secret{}, err
+	}
+
+	now := time.Now()
+	if v.now != nil {
+		now = v.now()
+	}
+
+	if !v.IgnoreTolerance {
+		tolerance := v.Tolerance
+		if tolerance <= 0 {
+			tolerance = DefaultTolerance
+		}
+
+		age := now.Sub(sh.timestamp)
+		if age > tolerance || age < -tolerance {
+			return Secret{}, ErrTimestampOutsideTolerance
+		}
+	}
+
+	for _, secret := range v.Secrets {
+		if secret.expired(now) {
+			continue
+		}
+
+		expectedSignature := ComputeSignature(sh.timestamp, payload, secret.Value)
+		for _, sig := range sh.signatures {
+			if hmac.Equal(expectedSignature, sig) {
+				return secret, nil
+			}
+		}
+	}
+
+	return Secret{}, ErrNoSecretMatched
+}
+
+// ConstructEvent initializes an Event object from a JSON webhook payload
+// after verifying its Stripe-Signature header like Verify does. It also
+// returns the secret that matched. When the verifier has an APIVersion, an
+// event with another version is refused with an error wrapping
+// ErrAPIVersionMismatch.
+func (v *Verifier) ConstructEvent(payload []byte, header string) (stripe.Event, Secret, error) {
+	e := stripe.Event{}
+
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return e, Secret{}, fmt.Errorf("Failed to parse webhook body json: %s", err.Error())
+	}
+
+	secret, err := v.Verify(payload, header)
+	if err != nil {
+		return e, secret, err
+	}
+
+	if v.APIVersion != "" && e.APIVersion != v.APIVersion {
+		return e, secret, fmt.Errorf("%w: event %v was rendered with API version %q "+
+			"instead of %q", ErrAPIVersionMismatch, e.ID, e.APIVersion, v.APIVersion)
+	}
+
+	return e, secret, nil
+}
@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go"
+)
+
+func TestVerifierMultipleSecrets(t *testing.T) {
+	oldSecret := Secret{Name: "old", Value: testSecret}
+	newSecret := Secret{Name: "new", Value: testSecret + "_rolled_key"}
+	v := &Verifier{Secrets: []Secret{oldSecret, newSecret}}
+
+	p := newSignedPayload(func(p *UnsignedPayload) {
+		p.Secrets = []string{newSecret.Value}
+	})
+	secret, err := v.Verify(p.Payload, p.Header)
+	if err != nil {
+		t.Errorf("Expected the payload to be verified, got %v", err)
+	}
+	if secret.Name != "new" {
+		t.Errorf("Expected the new secret to match, got %v", secret.Name)
+	}
+
+	p = newSignedPayload()
+	evt, secret, err := v.ConstructEvent(p.Payload, p.Header)
+	if err != nil {
+		t.Errorf("Expected the payload to be verified, got %v", err)
+	}
+	if secret.Name != "old" {
+		t.Errorf("Expected the old secret to match, got %v", secret.Name)
+	}
+	if evt.ID != "evt_test_webhook" {
+		t.Errorf("Expected a parsed event matching the test payload, got %v", evt)
+	}
+}
+
+func TestVerifierExpiredSecret(t *testing.T) {
+	now := time.Now()
+	v := &Verifier{
+		Secrets: []Secret{{Value: testSecret, ExpiresAt: now.Add(-time.Minute)}},
+		now:     func() time.Time { return now },
+	}
+
+	p := newSignedPayload()
+	_, err := v.Verify(p.Payload, p.Header)
+	if err != ErrNoSecretMatched {
+		t.Errorf("Expected ErrNoSecretMatched for an expired secret, got %v", err)
+	}
+
+	v.Secrets[0].ExpiresAt = now.Add(time.Minute)
+	_, err = v.Verify(p.Payload, p.Header)
+	if err != nil {
+		t.Errorf("Expected a secret expiring later to be accepted, got %v", err)
+	}
+}
+
+func TestVerifierErrors(t *testing.T) {
+	v := NewVerifier(testSecret)
+
+	p := newSignedPayload()
+	_, err := v.Verify(p.Payload, "")
+	if err != ErrNotSigned {
+		t.Errorf("Expected ErrNotSigned from missing signature, got %v", err)
+	}
+
+	_, err = v.Verify(p.Payload, "t=")
+	if err != ErrInvalidHeader {
+		t.Errorf("Expected ErrInvalidHeader from bad header format, got %v", err)
+	}
+
+	p = newSignedPayload(func(p *UnsignedPayload) {
+		p.Secrets = nil
+		p.V0Secret = testSecret
+	})
+	_, err = v.Verify(p.Payload, p.Header)
+	if err != ErrNoV1Signature {
+		t.Errorf("Expected ErrNoV1Signature from a v0 only header, got %v", err)
+	}
+	if !errors.Is(err, ErrNoValidSignature) {
+		t.Errorf("Expected ErrNoV1Signature to be an ErrNoValidSignature")
+	}
+
+	p = newSignedPayload(func(p *UnsignedPayload) {
+		p.Secrets = []string{"whsec_other_secret"}
+	})
+	_, err = v.Verify(p.Payload, p.Header)
+	if err != ErrNoSecretMatched {
+		t.Errorf("Expected ErrNoSecretMatched from an unknown secret, got %v", err)
+	}
+	if !errors.Is(err, ErrNoValidSignature) {
+		t.Errorf("Expected ErrNoSecretMatched to be an ErrNoValidSignature")
+	}
+
+	for _, offset := range []time.Duration{-15 * time.Second, 15 * time.Second} {
+		p = newSignedPayload(func(p *UnsignedPayload) {
+			p.Timestamp = time.Now().Add(offset)
+		})
+
+		v.Tolerance = 10 * time.Second
+		_, err = v.Verify(p.Payload, p.Header)
+		if err != ErrTimestampOutsideTolerance {
+			t.Errorf("Expected ErrTimestampOutsideTolerance for an offset of %v, got %v", offset, err)
+		}
+		if !errors.Is(err, ErrTooOld) {
+			t.Errorf("Expected ErrTimestampOutsideTolerance to be an ErrTooOld")
+		}
+
+		v.IgnoreTolerance = true
+		_, err = v.Verify(p.Payload, p.Header)
+		if err != nil {
+			t.Errorf("Received %v error when timestamp outside window but tolerance ignored", err)
+		}
+		v.IgnoreTolerance = false
+	}
+}
+
+func TestVerifierAPIVersion(t *testing.T) {
+	v := NewVerifier(testSecret)
+	v.APIVersion = stripe.APIVersion
+
+	p := newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = []byte(`{"id": "evt_test_webhook", "object": "event", "api_version": "` + stripe.APIVersion + `"}`)
+	})
+	evt, _, err := v.ConstructEvent(p.Payload, p.Header)
+	if err != nil {
+		t.Errorf("Expected an event with the expected API version to be accepted, got %v", err)
+	}
+	if evt.APIVersion != stripe.APIVersion {
+		t.Errorf("Expected the event's API version to be decoded, got %v", evt.APIVersion)
+	}
+
+	p = newSignedPayload(func(p *UnsignedPayload) {
+		p.Payload = []byte(`{"id": "evt_test_webhook", "object": "event", "api_version": "2017-08-15"}`)
+	})
+	_, _, err = v.ConstructEvent(p.Payload, p.Header)
+	if !errors.Is(err, ErrAPIVersionMismatch) {
+		t.Errorf("Expected ErrAPIVersionMismatch for another API version, got %v", err)
+	}
+	expected := `Event has an unexpected API version: event evt_test_webhook was rendered with ` +
+		`API version "2017-08-15" instead of "` + stripe.APIVersion + `"`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+
+	v.APIVersion = ""
+	_, _, err = v.ConstructEvent(p.Payload, p.Header)
+	if err != nil {
+		t.Errorf("Expected any API version to be accepted without an APIVersion, got %v", err)
+	}
+}